@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runCheck implements the "check" subcommand.
+//
+// It prints a structural report and exits 0 when the file is clean, or 1
+// when it has problems or cannot be parsed at all (the message says which),
+// so scripts can gate transforms on it.
+func runCheck(g *globalOptions, args []string, out, errOut io.Writer) int {
+	fs := g.newFlagSet("check", errOut, checkUsage)
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "check requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	path := fs.Arg(0)
+	rep, err := csvio.CheckFile(path, g.inputOptions(path))
+	if err != nil {
+		fmt.Fprintf(errOut, "error: %s is not parseable as CSV (after %d rows): %v\n", path, rep.Rows, err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "Rows: %d\n", rep.Rows)
+	fmt.Fprintf(out, "Columns: %d\n", rep.Columns)
+
+	problems := 0
+	report := func(label string, count int, detail string) {
+		if count == 0 {
+			fmt.Fprintf(out, "%s: none\n", label)
+			return
+		}
+		problems++
+		fmt.Fprintf(out, "%s: %d (%s)\n", label, count, detail)
+	}
+
+	examples := make([]string, len(rep.JaggedExamples))
+	for i, j := range rep.JaggedExamples {
+		examples[i] = fmt.Sprintf("row %d has %d fields", j.Row, j.Fields)
+	}
+	report("Jagged rows", rep.JaggedRows, "e.g. "+strings.Join(examples, ", "))
+
+	positions := make([]string, len(rep.EmptyHeaders))
+	for i, p := range rep.EmptyHeaders {
+		positions[i] = strconv.Itoa(p)
+	}
+	report("Empty headers", len(rep.EmptyHeaders), "at position "+strings.Join(positions, ", "))
+
+	report("Duplicate headers", len(rep.DuplicateHeaders), strings.Join(rep.DuplicateHeaders, ", "))
+
+	rows := make([]string, len(rep.MultilineRows))
+	for i, r := range rep.MultilineRows {
+		rows[i] = strconv.Itoa(r)
+	}
+	detail := "in rows " + strings.Join(rows, ", ")
+	if len(rows) == 0 {
+		detail = "in the header"
+	}
+	report("Multiline cells", rep.MultilineCells, detail)
+
+	if problems > 0 {
+		fmt.Fprintf(out, "Found %d kinds of problem\n", problems)
+		return 1
+	}
+	fmt.Fprintln(out, "OK: no structural problems found")
+	return 0
+}
+
+// checkUsage prints help for the "check" subcommand.
+func checkUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df check <file.csv>
+
+Read the whole file and report structural problems: rows whose field count
+differs from the header (jagged rows), blank header names, duplicate header
+names (case-insensitive), and cells containing line breaks.
+
+Exit status is 0 when no problems are found and 1 when some are, or when
+the file cannot be parsed as CSV at all.
+
+Examples:
+  df check input.csv
+  df check input.csv && df nullify input.csv -o cleaned.csv
+`)
+}
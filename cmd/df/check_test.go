@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		code    int
+		want    string
+	}{
+		{name: "clean", content: "id,name\n1,Ann\n", code: 0, want: "OK: no structural problems found"},
+		{name: "jagged", content: "id,name\n1\n", code: 1, want: "Jagged rows: 1 (e.g. row 1 has 1 fields)"},
+		{name: "duplicate headers", content: "id,ID\n1,2\n", code: 1, want: "Duplicate headers: 1 (ID)"},
+		{name: "empty header", content: "id,\n1,2\n", code: 1, want: "Empty headers: 1 (at position 1)"},
+		{name: "multiline", content: "id,note\n1,\"a\nb\"\n", code: 1, want: "Multiline cells: 1 (in rows 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			if code := run([]string{"df", "check", writeCSV(t, tt.content)}, &out, &errOut); code != tt.code {
+				t.Fatalf("expected exit code %d, got %d; stderr=%s", tt.code, code, errOut.String())
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Fatalf("output missing %q:\n%s", tt.want, out.String())
+			}
+		})
+	}
+}
+
+func TestCheck_Unparseable(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "check", writeCSV(t, "id\n\"bad\"quote\n")}, &out, &errOut); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "is not parseable as CSV") {
+		t.Fatalf("unexpected stderr: %s", errOut.String())
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// completionShells lists the shells "df completion" can generate scripts
+// for, in the order shown in help.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// runCompletion implements "df completion <shell>".
+//
+// It prints a shell completion script to out, or with --install writes it
+// where that shell looks for completions. Like "help", it is dispatched by
+// run rather than listed in the commands registry, because the scripts are
+// generated from the registry.
+func runCompletion(args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-install":  false,
+		"--install": false,
+	})
+
+	fs := flag.NewFlagSet("completion", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	fs.Usage = func() { completionUsage(errOut) }
+
+	install := fs.Bool("install", false, "Write the script to the shell's completion directory")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "completion requires exactly one argument: <shell>")
+		return 2
+	}
+
+	shell := fs.Arg(0)
+	script, ok := completionScript(shell)
+	if !ok {
+		fmt.Fprintf(errOut, "unsupported shell %q (want %s)\n", shell, strings.Join(completionShells, ", "))
+		return 2
+	}
+
+	if !*install {
+		fmt.Fprint(out, script)
+		return 0
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	path := completionPath(home, shell)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "wrote %s\n", path)
+	switch shell {
+	case "bash":
+		fmt.Fprintf(out, "Add this line to ~/.bashrc, then start a new shell:\n  source %s\n", path)
+	case "zsh":
+		fmt.Fprintf(out, "Add these lines to ~/.zshrc before compinit, then start a new shell:\n  fpath=(%s $fpath)\n", filepath.Dir(path))
+	case "fish":
+		fmt.Fprintln(out, "fish loads it automatically in new shells.")
+	}
+	return 0
+}
+
+// completionPath returns where --install writes the script for shell.
+func completionPath(home, shell string) string {
+	switch shell {
+	case "bash":
+		return filepath.Join(home, ".bash_completion.d", "df")
+	case "zsh":
+		return filepath.Join(home, ".zsh", "completions", "_df")
+	default:
+		return filepath.Join(home, ".config", "fish", "completions", "df.fish")
+	}
+}
+
+// completionWords returns every word valid as the first argument: the
+// registered commands plus the ones run dispatches itself.
+func completionWords() []string {
+	words := make([]string, 0, len(commands)+2)
+	for _, c := range commands {
+		words = append(words, c.Name)
+	}
+	return append(words, "help", "completion")
+}
+
+// commandFlags returns the flags of c, sorted by name. It runs c with -h and a hook that captures the FlagSet; every
+// command parses its flags before doing anything else, so this has no
+// other effect.
+func commandFlags(c Command) []*flag.Flag {
+	var fs *flag.FlagSet
+	g := &globalOptions{log: io.Discard, onFlagSet: func(s *flag.FlagSet) { fs = s }}
+	c.Run(g, []string{"-h"}, io.Discard, io.Discard)
+	if fs == nil {
+		return nil
+	}
+
+	var flags []*flag.Flag
+	fs.VisitAll(func(f *flag.Flag) { flags = append(flags, f) })
+	return flags
+}
+
+// flagWord returns how help spells a flag: "-o" for single letters,
+// "--key" otherwise. Go's flag package accepts either dash count.
+func flagWord(f *flag.Flag) string {
+	if len(f.Name) == 1 {
+		return "-" + f.Name
+	}
+	return "--" + f.Name
+}
+
+// flagWords returns the flagWord of every flag of c, space-separated.
+func flagWords(c Command) string {
+	var words []string
+	for _, f := range commandFlags(c) {
+		words = append(words, flagWord(f))
+	}
+	return strings.Join(words, " ")
+}
+
+// completionScript returns the completion script for shell. The scripts
+// complete the subcommand name in the first position and, after it, the
+// command's flags for a word starting with "-" and file paths otherwise;
+// "df help" completes command names and "df completion" shell names.
+func completionScript(shell string) (string, bool) {
+	names := strings.Join(completionWords(), " ")
+	cmdNames := strings.Join(completionWords()[:len(commands)], " ")
+	shells := strings.Join(completionShells, " ")
+
+	switch shell {
+	case "bash":
+		var cases strings.Builder
+		for _, c := range commands {
+			fmt.Fprintf(&cases, "    %s) flags=\"%s\" ;;\n", c.Name, flagWords(c))
+		}
+		return fmt.Sprintf(`# bash completion for df
+_df() {
+    local cur=${COMP_WORDS[COMP_CWORD]} flags=
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=($(compgen -W "%s" -- "$cur"))
+        return
+    fi
+    case "${COMP_WORDS[1]}" in
+    help)
+        COMPREPLY=($(compgen -W "%s" -- "$cur"))
+        return
+        ;;
+    completion)
+        COMPREPLY=($(compgen -W "%s --install" -- "$cur"))
+        return
+        ;;
+%s    esac
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+    else
+        COMPREPLY=($(compgen -f -- "$cur"))
+    fi
+}
+complete -o filenames -F _df df
+`, names, cmdNames, shells, cases.String()), true
+
+	case "zsh":
+		var cases strings.Builder
+		for _, c := range commands {
+			fmt.Fprintf(&cases, "    %s) flags=(%s) ;;\n", c.Name, flagWords(c))
+		}
+		return fmt.Sprintf(`#compdef df
+# zsh completion for df
+_df() {
+    local -a flags
+    if (( CURRENT == 2 )); then
+        compadd -- %s
+        return
+    fi
+    case "$words[2]" in
+    help) compadd -- %s; return ;;
+    completion) compadd -- %s --install; return ;;
+%s    esac
+    if [[ $PREFIX == -* ]]; then
+        compadd -- $flags
+    else
+        _files
+    fi
+}
+
+if [ "$funcstack[1]" = "_df" ]; then
+    _df "$@"
+else
+    compdef _df df
+fi
+`, names, cmdNames, shells, cases.String()), true
+
+	case "fish":
+		var b strings.Builder
+		b.WriteString("# fish completion for df\n")
+		b.WriteString("complete -c df -f\n")
+		for _, c := range commands {
+			fmt.Fprintf(&b, "complete -c df -n __fish_use_subcommand -a %s -d %s\n", c.Name, fishQuote(c.Summary))
+		}
+		b.WriteString("complete -c df -n __fish_use_subcommand -a help -d 'Show help for a command'\n")
+		b.WriteString("complete -c df -n __fish_use_subcommand -a completion -d 'Print a shell completion script'\n")
+		fmt.Fprintf(&b, "complete -c df -n '__fish_seen_subcommand_from help' -a '%s'\n", cmdNames)
+		fmt.Fprintf(&b, "complete -c df -n '__fish_seen_subcommand_from completion' -a '%s' -l install\n", shells)
+		for _, c := range commands {
+			for _, f := range commandFlags(c) {
+				opt := "-l " + f.Name
+				if len(f.Name) == 1 {
+					opt = "-s " + f.Name
+				}
+				fmt.Fprintf(&b, "complete -c df -n '__fish_seen_subcommand_from %s' %s -d %s\n", c.Name, opt, fishQuote(f.Usage))
+			}
+		}
+		b.WriteString("complete -c df -n 'not __fish_use_subcommand; and not __fish_seen_subcommand_from help completion' -F\n")
+		return b.String(), true
+	}
+
+	return "", false
+}
+
+// fishQuote single-quotes s for a fish script. Inside single quotes fish
+// only treats backslash and the quote itself specially.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
+
+// completionUsage prints help for "df completion".
+func completionUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df completion <bash|zsh|fish> [flags]
+
+Print a shell completion script for df. The script completes command names
+and, after the command, its flags (for a word starting with "-") and file
+paths.
+
+Flags:
+  --install            Write the script to the shell's completion directory
+                       instead of printing it:
+                         bash  ~/.bash_completion.d/df
+                         zsh   ~/.zsh/completions/_df
+                         fish  ~/.config/fish/completions/df.fish
+
+Examples:
+  source <(df completion bash)
+  df completion zsh --install
+  df completion fish > ~/.config/fish/completions/df.fish
+`)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompletion_Scripts(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			if code := run([]string{"df", "completion", shell}, &out, &errOut); code != 0 {
+				t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+			}
+			for _, c := range commands {
+				if !strings.Contains(out.String(), c.Name) {
+					t.Fatalf("script missing command %q:\n%s", c.Name, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCompletion_BashUsesFilenames(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "completion", "bash"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), "complete -o filenames -F _df df") {
+		t.Fatalf("bash script missing complete line:\n%s", out.String())
+	}
+}
+
+func TestCompletion_Flags(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{shell: "bash", want: `concat) flags="--add-source --ignore-schema -o --source-column" ;;`},
+		{shell: "zsh", want: `concat) flags=(--add-source --ignore-schema -o --source-column) ;;`},
+		{shell: "fish", want: `complete -c df -n '__fish_seen_subcommand_from concat' -s o -d 'Output CSV path (default stdout)'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			script, _ := completionScript(tt.shell)
+			if !strings.Contains(script, tt.want) {
+				t.Fatalf("script missing %q:\n%s", tt.want, script)
+			}
+		})
+	}
+}
+
+func TestCompletion_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "no shell", args: nil},
+		{name: "unknown shell", args: []string{"tcsh"}},
+		{name: "extra args", args: []string{"bash", "zsh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			if code := run(append([]string{"df", "completion"}, tt.args...), &out, &errOut); code != 2 {
+				t.Fatalf("expected exit code 2, got %d", code)
+			}
+		})
+	}
+}
+
+func TestCompletion_Install(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "completion", "bash", "--install"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	path := filepath.Join(home, ".bash_completion.d", "df")
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read installed script: %v", err)
+	}
+	want, _ := completionScript("bash")
+	if string(got) != want {
+		t.Fatalf("installed script differs from printed script")
+	}
+	if !strings.Contains(out.String(), "source "+path) {
+		t.Fatalf("expected source instruction; got %q", out.String())
+	}
+}
+
+func TestHelp_Completion(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "help", "completion"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(out.String(), "--install") {
+		t.Fatalf("expected completion usage; got\n%s", out.String())
+	}
+}
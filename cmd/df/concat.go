@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runConcat implements the "concat" subcommand.
+//
+// Output goes to -o, or stdout when -o is omitted; per-file row counts go to
+// stderr.
+func runConcat(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":              true,
+		"-add-source":     false,
+		"--add-source":    false,
+		"-source-column":  true,
+		"--source-column": true,
+		"-ignore-schema":  false,
+		"--ignore-schema": false,
+	})
+
+	fs := g.newFlagSet("concat", errOut, concatUsage)
+
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+	addSource := fs.Bool("add-source", false, "Prepend a _source column")
+	sourceColumn := fs.String("source-column", csvio.SourceFileColumn, "Header of the --add-source column")
+	ignoreSchema := fs.Bool("ignore-schema", false, "Skip header checks; pad/truncate to the first file")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	paths, err := expandFileList(fs.Args())
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	if len(paths) < 2 {
+		fmt.Fprintln(errOut, "concat requires at least two arguments: <a.csv> <b.csv> [...]")
+		return 2
+	}
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	fileOpts := g.fileInputOptions(paths...)
+	prog := g.newProgress(errOut)
+	stats, err := csvio.ConcatToWriter(paths, w, csvio.ConcatOptions{
+		Options:          fileOpts[0],
+		FileOptions:      fileOpts,
+		AddSourceColumn:  *addSource,
+		SourceColumnName: *sourceColumn,
+		IgnoreSchema:     *ignoreSchema,
+		Progress:         prog,
+	})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+	prog.Done(stats.RowsRead())
+
+	for _, f := range stats.Files {
+		fmt.Fprintf(errOut, "%s: %d rows\n", f.Path, f.RowsWritten)
+	}
+	fmt.Fprintf(errOut, "Rows written: %d\n", stats.RowsWritten())
+
+	return 0
+}
+
+// concatUsage prints help for the "concat" subcommand.
+func concatUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df concat <a.csv> <b.csv> [more.csv ...] [flags]
+
+Stack the data rows of several files. The first file's header is written
+once; every other file must have the same header unless --ignore-schema.
+
+An argument "@list.txt" is replaced by the paths listed in list.txt, one
+per line ("#" starts a comment line). Use "@@name.csv" for a file whose
+name starts with "@".
+
+Flags:
+  --add-source         Prepend a _source column with each row's file name
+                       (without its directory)
+  --source-column NAME
+                       Header of the --add-source column (default _source)
+  --ignore-schema      Skip the header check; pad or truncate rows to the
+                       first file's width
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df concat jan.csv feb.csv mar.csv -o q1.csv
+  df concat jan.csv feb.csv --add-source
+  df concat 2024-*.csv --add-source --source-column month -o year.csv
+  df concat @files.txt -o all.csv
+`)
+}
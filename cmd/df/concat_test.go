@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "concat", test_mail_data, test_mail_data}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if lines := nonEmptyLines(out.String()); len(lines) != 21 {
+		t.Fatalf("expected header plus 20 rows, got %d lines", len(lines))
+	}
+}
+
+func TestConcat_RequiresTwoFiles(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	if code := run([]string{"df", "concat", test_mail_data}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
+
+func TestConcat_SourceColumn(t *testing.T) {
+	a := writeNamed(t, "jan.csv", "id\n1\n")
+	b := writeNamed(t, "feb.csv", "id\n2\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "concat", a, b, "--add-source"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "_source,id\njan.csv,1\nfeb.csv,2\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	code = run([]string{"df", "concat", a, b, "--add-source", "--source-column", "src"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "src,id\njan.csv,1\nfeb.csv,2\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestConcat_FileList(t *testing.T) {
+	a := writeCSV(t, "id\n1\n")
+	b := writeCSV(t, "id\n2\n")
+	list := writeCSV(t, "# inputs\n"+a+"\n\n  "+b+"  \n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "concat", "@" + list}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "id\n1\n2\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestConcat_FileListEscape(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile("@a.csv", []byte("id\n1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b := writeCSV(t, "id\n2\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "concat", "@@a.csv", b}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "id\n1\n2\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestConcat_FileListMissing(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	if code := run([]string{"df", "concat", "@" + t.TempDir() + "/missing.txt"}, &out, &errOut); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+}
+
+func TestConcat_MixedCSVAndTSV(t *testing.T) {
+	a := writeNamed(t, "a.csv", "id,v\n1,x\n")
+	b := writeNamed(t, "b.tsv", "id\tv\n2\ty z\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "concat", a, b}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "id,v\n1,x\n2,y z\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+
+	// Output follows the first file's dialect; each input is read with its own.
+	out.Reset()
+	code = run([]string{"df", "concat", b, a}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "id\tv\n2\ty z\n1\tx\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
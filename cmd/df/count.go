@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runCount implements the "count" subcommand.
+//
+// It prints the number of data rows as a bare integer so the result can be
+// used directly in scripts, e.g. rows=$(df count file.csv).
+func runCount(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-header":  false,
+		"--header": false,
+	})
+
+	fs := g.newFlagSet("count", errOut, countUsage)
+
+	withHeader := fs.Bool("header", false, "Include the header row in the count")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "count requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	n, err := csvio.CountRows(fs.Arg(0), g.inputOptions(fs.Arg(0)))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	if *withHeader {
+		n++
+	}
+
+	fmt.Fprintln(out, n)
+	return 0
+}
+
+// countUsage prints help for the "count" subcommand.
+func countUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df count <file.csv> [flags]
+
+Print the number of data rows (excluding the header) as a plain integer.
+Records are counted, not lines, so quoted multi-line cells count once.
+
+Flags:
+  --header             Include the header row in the count
+
+Examples:
+  df count input.csv
+  df count input.csv --header
+`)
+}
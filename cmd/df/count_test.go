@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	headerOnly := filepath.Join(t.TempDir(), "header.csv")
+	if err := os.WriteFile(headerOnly, []byte("a,b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "fixture", args: []string{test_mail_data}, want: "10\n"},
+		{name: "with header", args: []string{test_mail_data, "--header"}, want: "11\n"},
+		{name: "header only", args: []string{headerOnly}, want: "0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			code := run(append([]string{"df", "count"}, tt.args...), &out, &errOut)
+			if code != 0 {
+				t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+			}
+			if out.String() != tt.want {
+				t.Fatalf("got %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runDedup implements the "dedup" subcommand.
+//
+// The first row for each key is kept. Output goes to -o, or stdout when -o
+// is omitted; the summary goes to stderr.
+func runDedup(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":    true,
+		"-key":  true,
+		"--key": true,
+	})
+
+	fs := g.newFlagSet("dedup", errOut, dedupUsage)
+
+	var keys stringList
+	fs.Var(&keys, "key", "Key column name (repeatable; default all columns)")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "dedup requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	stats, err := csvio.DeduplicateReader(in, w, keys, g.inputOptions(fs.Arg(0)))
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	fmt.Fprintf(errOut, "Rows read: %d\n", stats.RowsRead)
+	fmt.Fprintf(errOut, "Rows kept: %d\n", stats.RowsKept)
+	fmt.Fprintf(errOut, "Rows dropped: %d\n", stats.RowsDropped)
+
+	return 0
+}
+
+// dedupUsage prints help for the "dedup" subcommand.
+func dedupUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df dedup <file.csv> [--key <name> ...] [-o out.csv]
+
+Remove duplicate rows, keeping the first occurrence of each key. Without
+--key, whole rows are compared. Values are compared exactly.
+
+Every distinct key is held in memory, so memory grows with the number of
+unique keys.
+
+Flags:
+  --key NAME           Key column (case-insensitive); repeat for a compound key
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df dedup input.csv --key email
+  df dedup input.csv --key first_name --key last_name -o unique.csv
+`)
+}
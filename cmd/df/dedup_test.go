@@ -0,0 +1,23 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDedup_ByKey(t *testing.T) {
+	in := writeCSV(t, "name,email\nAnn,a@x.com\nAnnie,a@x.com\nBob,b@x.com\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "dedup", in, "--key", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name,email\nAnn,a@x.com\nBob,b@x.com\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if !strings.Contains(errOut.String(), "Rows dropped: 1") {
+		t.Fatalf("expected summary on stderr; got %q", errOut.String())
+	}
+}
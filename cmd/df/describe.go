@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/nulls"
+	"github.com/bensabler/go-mail/internal/render"
+)
+
+// runDescribe implements the "describe" subcommand.
+//
+// It prints the output of cols, head and stats for one file under banners.
+// The file is read once with csvio.ReadAll and every section is derived from
+// the rows in memory, which matters on slow network mounts; the trade-off is
+// that memory use grows with the file.
+func runDescribe(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-n":  true,
+		"--n": true,
+	})
+
+	fs := g.newFlagSet("describe", errOut, describeUsage)
+
+	n := fs.Int("n", 5, "Number of rows in the preview")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "describe requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if *n < 0 {
+		fmt.Fprintln(errOut, "-n must be >= 0")
+		return 2
+	}
+
+	path := fs.Arg(0)
+	headers, rows, err := csvio.ReadAll(path, g.inputOptions(path))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "=== SCHEMA (%d columns, %d rows) ===\n", len(headers), len(rows))
+	for i, h := range headers {
+		fmt.Fprintf(out, "%d\t%s\n", i, h)
+	}
+
+	preview := rows[:min(*n, len(rows))]
+	fmt.Fprintf(out, "\n=== PREVIEW (%d rows) ===\n", len(preview))
+	if err := render.PrintTable(out, headers, preview, render.TableOptions{ShowRowIndex: true}); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	// Same default null policy as "stats".
+	statHeaders, statRows := statsTable(csvio.StatsFromRows(headers, rows, nulls.Policy{TreatBlanks: true}))
+	fmt.Fprint(out, "\n=== STATISTICS ===\n")
+	if err := render.PrintTable(out, statHeaders, statRows, render.TableOptions{MaxCellWidth: 32}); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// describeUsage prints help for the "describe" subcommand.
+func describeUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df describe <file.csv> [flags]
+
+Print a first look at a file in three sections: the columns (as "df cols"),
+the first rows (as "df head") and per-column statistics (as "df stats").
+The file is read once and held in memory.
+
+Flags:
+  -n N                 Number of rows in the preview (default 5)
+
+Examples:
+  df describe input.csv
+  df describe input.csv -n 10
+`)
+}
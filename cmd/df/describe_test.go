@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	in := writeCSV(t, "name,age\nAnn,4\nBob,9\nCy,2\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "describe", in, "--n", "2"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"=== SCHEMA (2 columns, 3 rows) ===\n0\tname\n1\tage\n",
+		"=== PREVIEW (2 rows) ===",
+		"=== STATISTICS ===",
+		"age     3      0      3         2    9    2        9        5.00  3.61",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("output missing %q:\n%s", want, got)
+		}
+	}
+	// The preview stops at --n rows.
+	preview := got[strings.Index(got, "=== PREVIEW"):strings.Index(got, "=== STATISTICS")]
+	if strings.Contains(preview, "Cy") {
+		t.Fatalf("preview shows more than 2 rows:\n%s", preview)
+	}
+}
+
+func TestDescribe_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{name: "no file", args: nil, code: 2},
+		{name: "negative n", args: []string{test_mail_data, "-n", "-1"}, code: 2},
+		{name: "missing file", args: []string{"does-not-exist.csv"}, code: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			if code := run(append([]string{"df", "describe"}, tt.args...), &out, &errOut); code != tt.code {
+				t.Fatalf("expected exit code %d, got %d", tt.code, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/render"
+)
+
+// runDiff implements the "diff" subcommand.
+//
+// Rows are matched on --key and reported as added, removed, or changed. The
+// exit code is 0 whether or not differences are found; use --format json to
+// inspect the result from scripts.
+func runDiff(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-key":          true,
+		"--key":         true,
+		"-ignore-cols":  true,
+		"--ignore-cols": true,
+		"-format":       true,
+		"--format":      true,
+	})
+
+	fs := g.newFlagSet("diff", errOut, diffUsage)
+
+	key := fs.String("key", "", "Column used to match rows (required)")
+	var ignore stringList
+	fs.Var(&ignore, "ignore-cols", "Comma-separated columns to leave out of the comparison (repeatable)")
+	format := fs.String("format", "table", "Output format: table or json")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	paths, err := expandFileList(fs.Args())
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	if len(paths) != 2 {
+		fmt.Fprintln(errOut, "diff requires exactly two arguments: <before.csv> <after.csv>")
+		return 2
+	}
+	if *key == "" {
+		fmt.Fprintln(errOut, "diff requires --key <column>")
+		return 2
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(errOut, "unknown format %q (want table or json)\n", *format)
+		return 2
+	}
+
+	res, err := csvio.DiffFiles(paths[0], paths[1], *key, csvio.DiffOptions{
+		FileOptions: g.fileInputOptions(paths[0], paths[1]),
+		IgnoreCols:  splitList(ignore),
+	})
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	if *format == "json" {
+		b, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			fmt.Fprintln(errOut, "error:", err)
+			return 1
+		}
+		fmt.Fprintln(out, string(b))
+		return 0
+	}
+
+	var rows [][]string
+	for _, group := range [][]csvio.DiffRow{res.Removed, res.Added, res.Changed} {
+		for _, d := range group {
+			rows = append(rows, []string{string(d.Kind), d.Key, strings.Join(d.Columns, ", ")})
+		}
+	}
+	if err := render.PrintTable(out, []string{"change", "key", "columns"}, rows, render.TableOptions{MaxCellWidth: 48}); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	fmt.Fprintf(errOut, "Added: %d, removed: %d, changed: %d, unchanged: %d\n",
+		len(res.Added), len(res.Removed), len(res.Changed), res.Unchanged)
+
+	return 0
+}
+
+// diffUsage prints help for the "diff" subcommand.
+func diffUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df diff <before.csv> <after.csv> --key <column> [flags]
+
+Compare two files by a key column and list rows that were added, removed,
+or changed (with the columns that differ). Columns are matched by name, so
+reordering them is not a change. The first file is held in memory.
+
+An argument "@list.txt" is replaced by the paths listed in list.txt, one
+per line; together the arguments must name two files.
+
+Flags:
+  --key NAME           Column used to match rows (required)
+  --ignore-cols LIST   Comma-separated columns to skip when comparing
+  --format FORMAT      table (default) or json
+
+Examples:
+  df diff before.csv after.csv --key email
+  df diff before.csv after.csv --key id --ignore-cols updated_at --format json
+`)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDiff_Table(t *testing.T) {
+	a := writeCSV(t, "id,name\n1,Ann\n2,Bob\n")
+	b := writeCSV(t, "id,name\n1,Ann\n2,Bobby\n3,Cy\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "diff", a, b, "--key", "id"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got := out.String()
+	if !strings.Contains(got, "added") || !strings.Contains(got, "changed") {
+		t.Fatalf("expected added and changed rows; got\n%s", got)
+	}
+	if !strings.Contains(errOut.String(), "unchanged: 1") {
+		t.Fatalf("expected summary on stderr; got %q", errOut.String())
+	}
+}
+
+func TestDiff_RequiresKey(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	if code := run([]string{"df", "diff", test_mail_data, test_mail_data}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runDrop implements the "drop" subcommand, the inverse of "select".
+//
+// Unknown columns are ignored unless --strict is set. Output goes to -o, or
+// stdout when -o is omitted.
+func runDrop(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":       true,
+		"-col":     true,
+		"--col":    true,
+		"-strict":  false,
+		"--strict": false,
+	})
+
+	fs := g.newFlagSet("drop", errOut, dropUsage)
+
+	var cols stringList
+	fs.Var(&cols, "col", "Column name to remove (repeatable)")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+	strict := fs.Bool("strict", false, "Fail if a column does not exist")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "drop requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if len(cols) == 0 {
+		fmt.Fprintln(errOut, "drop requires at least one --col <name>")
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	stats, err := csvio.DropReader(in, w, cols, csvio.DropOptions{Options: g.inputOptions(fs.Arg(0)), Strict: *strict})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	if stats.ColsDropped < len(cols) {
+		fmt.Fprintf(errOut, "warning: dropped %d of %d requested columns\n", stats.ColsDropped, len(cols))
+	}
+
+	return 0
+}
+
+// dropUsage prints help for the "drop" subcommand.
+func dropUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df drop <file.csv> --col <name> [--col <name> ...] [flags]
+
+Write every column except the ones named. Remaining columns keep their
+original order. Unknown names are skipped with a warning unless --strict.
+
+Flags:
+  --col NAME           Column to remove (case-insensitive); repeat for more
+  --strict             Fail (exit 2) if a named column does not exist
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df drop input.csv --col email --col phone
+  df drop input.csv --col ssn --strict -o clean.csv
+`)
+}
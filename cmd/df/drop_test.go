@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDrop(t *testing.T) {
+	in := writeCSV(t, "name,email,phone\nAnn,a@x.com,555\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "drop", in, "--col", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name,phone\nAnn,555\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestDrop_StrictUnknownColumn(t *testing.T) {
+	in := writeCSV(t, "name\nAnn\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "drop", in, "--col", "nope"}, &out, &errOut); code != 0 {
+		t.Fatalf("lenient: expected exit code 0, got %d", code)
+	}
+
+	out.Reset()
+	errOut.Reset()
+	if code := run([]string{"df", "drop", in, "--col", "nope", "--strict"}, &out, &errOut); code != 2 {
+		t.Fatalf("strict: expected exit code 2, got %d", code)
+	}
+}
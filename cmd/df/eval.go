@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/expr"
+)
+
+// runEval implements the "eval" subcommand.
+//
+// Rows whose expression cannot be evaluated get an empty cell; they are
+// counted in the summary, with the first error, and do not fail the run.
+// Output goes to -o, or stdout when -o is omitted; the summary goes to
+// stderr.
+func runEval(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":     true,
+		"-expr":  true,
+		"--expr": true,
+	})
+
+	fs := g.newFlagSet("eval", errOut, evalUsage)
+
+	exprFlag := fs.String("expr", "", "Computed column as NAME=EXPRESSION")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "eval requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	name, src, ok := strings.Cut(*exprFlag, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		fmt.Fprintln(errOut, "eval requires --expr NAME=EXPRESSION")
+		return 2
+	}
+	e, err := expr.Parse(src)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	stats, err := csvio.EvalColumnReader(in, w, csvio.EvalOptions{Options: g.inputOptions(fs.Arg(0)), Column: name, Expr: e})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	fmt.Fprintf(errOut, "Rows read: %d\n", stats.RowsRead)
+	if stats.EvalErrors > 0 {
+		fmt.Fprintf(errOut, "Rows left empty: %d (first: %v)\n", stats.EvalErrors, stats.FirstError)
+	}
+
+	return 0
+}
+
+// evalUsage prints help for the "eval" subcommand.
+func evalUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df eval <file.csv> --expr 'NAME=EXPRESSION' [flags]
+
+Add a computed column, or replace an existing one of the same name.
+
+An expression combines column names, numbers and quoted strings with + - * /
+and parentheses. Write `+"`column name`"+` in backquotes when a name is not a
+plain identifier. "+" adds two numbers and concatenates when either side is
+a quoted string or non-numeric text; the other operators need numbers. There
+are no functions or conditionals.
+
+Rows where the expression fails (division by zero, arithmetic on text or on
+an empty cell) get an empty cell and are counted in the summary.
+
+Flags:
+  --expr NAME=EXPR     Column name and expression (required)
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df eval people.csv --expr 'full_name=first_name + " " + last_name'
+  df eval ads.csv --expr 'ctr=clicks / impressions * 100' -o ads_ctr.csv
+`)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEval_Expr(t *testing.T) {
+	in := writeCSV(t, "first_name,last_name\nAnn,Lee\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "eval", in, "--expr", `full_name=first_name + " " + last_name`}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "first_name,last_name,full_name\nAnn,Lee,Ann Lee\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestEval_BadExpr(t *testing.T) {
+	in := writeCSV(t, "a\n1\n")
+
+	for _, arg := range []string{"a + 1", "b=a +"} {
+		var out, errOut bytes.Buffer
+		if code := run([]string{"df", "eval", in, "--expr", arg}, &out, &errOut); code != 2 {
+			t.Fatalf("--expr %q: expected exit code 2, got %d", arg, code)
+		}
+	}
+}
+
+func TestEval_RowErrors(t *testing.T) {
+	in := writeCSV(t, "a,b\n1,0\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "eval", in, "--expr", "c=a/b"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "Rows left empty: 1 (first: row 1: cannot evaluate: division by zero)") {
+		t.Fatalf("stderr = %q", errOut.String())
+	}
+}
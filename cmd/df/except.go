@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runExcept implements the "except" subcommand.
+//
+// Output goes to -o, or stdout when -o is omitted; row counts go to stderr.
+func runExcept(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":    true,
+		"-key":  true,
+		"--key": true,
+	})
+
+	fs := g.newFlagSet("except", errOut, exceptUsage)
+
+	key := fs.String("key", "", "Key column present in both files (required)")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	paths, err := expandFileList(fs.Args())
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	if len(paths) != 2 {
+		fmt.Fprintln(errOut, "except requires exactly two arguments: <a.csv> <b.csv>")
+		return 2
+	}
+	if *key == "" {
+		fmt.Fprintln(errOut, "except requires --key <name>")
+		return 2
+	}
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	fileOpts := g.fileInputOptions(paths[0], paths[1])
+	stats, err := csvio.ExceptToWriter(paths[0], paths[1], w, csvio.SetOptions{Options: fileOpts[0], FileOptions: fileOpts, Key: *key})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	fmt.Fprintf(errOut, "Rows in %s: %d\n", paths[0], stats.RowsInA)
+	fmt.Fprintf(errOut, "Rows in %s: %d\n", paths[1], stats.RowsInB)
+	fmt.Fprintf(errOut, "Rows kept: %d\n", stats.RowsKept)
+
+	return 0
+}
+
+// exceptUsage prints help for the "except" subcommand.
+func exceptUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df except <a.csv> <b.csv> --key NAME [-o out.csv]
+
+Write the rows of a.csv whose key does not appear in b.csv, in a.csv's
+order and with its header; the complement of "df intersect". Keys are
+compared exactly. The keys of b.csv are held in memory; a.csv is streamed.
+
+An argument "@list.txt" is replaced by the paths listed in list.txt, one
+per line; together the arguments must name two files.
+
+Flags:
+  --key NAME           Key column present in both files (required)
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df except master.csv unsubscribed.csv --key email
+  df except master.csv unsubscribed.csv --key email -o sendable.csv
+`)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExcept_Key(t *testing.T) {
+	a := writeCSV(t, "email,name\nann@x.com,Ann\nbob@x.com,Bob\n")
+	b := writeCSV(t, "email\nbob@x.com\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "except", a, b, "--key", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "email,name\nann@x.com,Ann\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if !strings.Contains(errOut.String(), "Rows kept: 1") {
+		t.Fatalf("stderr = %q", errOut.String())
+	}
+}
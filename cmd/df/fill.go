@@ -0,0 +1,154 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+// runFill implements the "fill" subcommand.
+//
+// Exactly one of --value, --ffill, and --bfill selects the strategy. The null
+// flags match "nullify". Output goes to -o, or stdout when -o is omitted;
+// per-column fill counts go to stderr.
+func runFill(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":             true,
+		"-col":           true,
+		"--col":          true,
+		"-value":         true,
+		"--value":        true,
+		"-ffill":         false,
+		"--ffill":        false,
+		"-bfill":         false,
+		"--bfill":        false,
+		"-blanks":        false,
+		"--blanks":       false,
+		"-na":            false,
+		"--na":           false,
+		"-null-literal":  false,
+		"--null-literal": false,
+	})
+
+	fs := g.newFlagSet("fill", errOut, fillUsage)
+
+	var cols stringList
+	fs.Var(&cols, "col", "Column to fill (repeatable)")
+	value := fs.String("value", "", "Replace NULL cells with this literal")
+	ffill := fs.Bool("ffill", false, "Carry the previous non-null value down")
+	bfill := fs.Bool("bfill", false, "Carry the next non-null value up (buffers the file)")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+	blanks := fs.Bool("blanks", true, "Treat empty/whitespace-only cells as NULL")
+	na := fs.Bool("na", false, "Treat NA and N/A as NULL (case-insensitive)")
+	nullLiteral := fs.Bool("null-literal", false, "Treat NULL as NULL (case-insensitive)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "fill requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if len(cols) == 0 {
+		fmt.Fprintln(errOut, "fill requires at least one --col <name>")
+		return 2
+	}
+
+	// --value is detected by presence, so "--value ''" is a valid fill.
+	hasValue := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "value" {
+			hasValue = true
+		}
+	})
+
+	opts := csvio.FillOptions{
+		Options: g.inputOptions(fs.Arg(0)),
+		Columns: cols,
+		Value:   *value,
+		Policy: nulls.Policy{
+			TreatBlanks:      *blanks,
+			TreatNA:          *na,
+			TreatNULLLiteral: *nullLiteral,
+		},
+	}
+	modes := 0
+	if hasValue {
+		opts.Strategy = csvio.FillValue
+		modes++
+	}
+	if *ffill {
+		opts.Strategy = csvio.FillForward
+		modes++
+	}
+	if *bfill {
+		opts.Strategy = csvio.FillBackward
+		modes++
+	}
+	if modes != 1 {
+		fmt.Fprintln(errOut, "fill requires exactly one of --value, --ffill, or --bfill")
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	stats, err := csvio.FillReader(in, w, opts)
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	names := make([]string, 0, len(stats.CellsFilled))
+	for name := range stats.CellsFilled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(errOut, "Cells filled in %s: %d\n", name, stats.CellsFilled[name])
+	}
+
+	return 0
+}
+
+// fillUsage prints help for the "fill" subcommand.
+func fillUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df fill <file.csv> --col <name> (--value <v> | --ffill | --bfill) [flags]
+
+Replace NULL cells in the chosen columns. --ffill carries the previous
+non-null value down; --bfill carries the next one up and holds the whole
+file in memory. NULL cells with nothing to carry are left as-is.
+
+Flags:
+  --col NAME           Column to fill (case-insensitive); repeat for more
+  --value V            Fill with a constant
+  --ffill              Forward fill
+  --bfill              Backward fill
+  --blanks             Treat empty/whitespace-only cells as NULL (default true)
+  --na                 Treat NA and N/A as NULL (case-insensitive)
+  --null-literal       Treat NULL as NULL (case-insensitive)
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df fill input.csv --col state --value NY
+  df fill input.csv --col city --col state --ffill -o filled.csv
+`)
+}
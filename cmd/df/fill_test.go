@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFill_Forward(t *testing.T) {
+	in := writeCSV(t, "city,state\nAlbany,NY\nTroy,\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "fill", in, "--col", "state", "--ffill"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "city,state\nAlbany,NY\nTroy,NY\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestFill_RequiresOneStrategy(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "fill", test_mail_data, "--col", "state", "--ffill", "--bfill"}, &out, &errOut)
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFilter_Stdout(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "filter", test_mail_data, "--col", "STATE", "--eq", "IL"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "Mike,Brown") {
+		t.Fatalf("expected header plus Mike Brown; got\n%s", out.String())
+	}
+	if !strings.Contains(errOut.String(), "Rows matched: 1") {
+		t.Fatalf("expected summary on stderr; got %q", errOut.String())
+	}
+}
+
+func TestFilter_UnknownColumn(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "filter", test_mail_data, "--col", "county", "--eq", "x"}, &out, &errOut)
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d; stderr=%s", code, errOut.String())
+	}
+	if !strings.Contains(errOut.String(), "first_name") {
+		t.Fatalf("expected available columns in message; got %q", errOut.String())
+	}
+}
+
+func TestFilter_AtSignValue(t *testing.T) {
+	in := writeCSV(t, "name,handle\nAnn,@ann\nBob,@bob\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "filter", in, "--col", "handle", "--eq", "@ann"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name,handle\nAnn,@ann\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
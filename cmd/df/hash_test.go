@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHash_Col(t *testing.T) {
+	in := writeCSV(t, "name,phone\nAnn,5551234\nBob,\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "hash", in, "--col", "phone", "--salt", "s3cret"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	want := "name,phone\nAnn,e4500aa5cb4a0dfbcde3318feac081083c3e7d89807d94ee2f3ed6611615b5ba\nBob,\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if strings.Contains(errOut.String(), "warning") {
+		t.Fatalf("unexpected warning with --salt: %s", errOut.String())
+	}
+}
+
+func TestHash_WarnsWithoutSalt(t *testing.T) {
+	in := writeCSV(t, "phone\n5551234\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "hash", in, "--col", "phone"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "without --salt") {
+		t.Fatalf("stderr = %q", errOut.String())
+	}
+}
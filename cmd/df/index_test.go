@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIndex_Flags(t *testing.T) {
+	in := writeCSV(t, "name\nAnn\nBob\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "index", in, "--col-name", "id", "--start", "1"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "id,name\n1,Ann\n2,Bob\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
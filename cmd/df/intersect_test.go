@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIntersect_Key(t *testing.T) {
+	a := writeCSV(t, "email,name\nann@x.com,Ann\nbob@x.com,Bob\n")
+	b := writeCSV(t, "email\nbob@x.com\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "intersect", a, b, "--key", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "email,name\nbob@x.com,Bob\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestIntersect_MixedCSVAndTSV(t *testing.T) {
+	a := writeNamed(t, "a.csv", "email,name\nann@x.com,Ann\nbob@x.com,Bob\n")
+	b := writeNamed(t, "b.tsv", "email\tname\nbob@x.com\tRobert\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "intersect", a, b, "--key", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "email,name\nbob@x.com,Bob\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestJoin_OnDifferentNames(t *testing.T) {
+	left := writeCSV(t, "id,name\n1,Ann\n2,Bob\n")
+	right := writeCSV(t, "customer_id,total\n2,15\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "join", "--left", left, "--right", right, "--on", "id=customer_id"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "id,name,total\n2,Bob,15\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestJoin_BadType(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "join", "--left", test_mail_data, "--right", test_mail_data, "--type", "outer"}, &out, &errOut)
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
@@ -3,10 +3,8 @@
 // The goal is to provide fast, dependable inspection and light transformation
 // operations for tabular data commonly encountered in mailing/automation work.
 //
-// Current commands:
-//   - cols: print header names
-//   - head: show the first N rows (like pandas .head())
-//   - nullify: normalize empty/placeholder values to NULL (empty fields in CSV)
+// Commands are registered in the commands table (see Command). Run "df help"
+// for the list and "df help <command>" for per-command usage.
 //
 // Design notes:
 //
@@ -20,13 +18,24 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/bensabler/go-mail/internal/csvio"
 	"github.com/bensabler/go-mail/internal/nulls"
+	"github.com/bensabler/go-mail/internal/progress"
 	"github.com/bensabler/go-mail/internal/render"
 )
 
@@ -36,6 +45,76 @@ func main() {
 	os.Exit(run(os.Args, os.Stdout, os.Stderr))
 }
 
+// Command describes one df subcommand.
+//
+// Commands are listed in the commands registry, which drives dispatch, the
+// top-level help listing, and "df help <command>".
+type Command struct {
+	// Name is the subcommand name typed by users (e.g. "head").
+	Name string
+
+	// Summary is a one-line description shown in the top-level usage.
+	Summary string
+
+	// Run executes the command with the global options and the arguments
+	// after the subcommand name, and returns a process exit code.
+	Run func(g *globalOptions, args []string, out, errOut io.Writer) int
+
+	// Usage prints the command's full help: synopsis, flags, and examples.
+	Usage func(w io.Writer)
+}
+
+// commands is the registry of subcommands, in the order shown in help.
+var commands = []Command{
+	{Name: "cols", Summary: "Print column headers (optionally with samples)", Run: runCols, Usage: colsUsage},
+	{Name: "head", Summary: "Print the first N rows (default 5)", Run: runHead, Usage: headUsage},
+	{Name: "tail", Summary: "Print the last N rows (default 5)", Run: runTail, Usage: tailUsage},
+	{Name: "slice", Summary: "Write data rows [start, end) as CSV", Run: runSlice, Usage: sliceUsage},
+	{Name: "count", Summary: "Print the number of data rows", Run: runCount, Usage: countUsage},
+	{Name: "check", Summary: "Report structural problems (jagged rows, bad headers)", Run: runCheck, Usage: checkUsage},
+	{Name: "repair", Summary: "Fix structural problems (headers, empty rows, widths)", Run: runRepair, Usage: repairUsage},
+	{Name: "validate", Summary: "Check rows against a JSON schema of column rules", Run: runValidate, Usage: validateUsage},
+	{Name: "filter", Summary: "Keep rows where a column equals a value", Run: runFilter, Usage: filterUsage},
+	{Name: "sort", Summary: "Sort rows by one or more columns", Run: runSort, Usage: sortUsage},
+	{Name: "select", Summary: "Write only the chosen columns, in order", Run: runSelect, Usage: selectUsage},
+	{Name: "drop", Summary: "Remove the chosen columns", Run: runDrop, Usage: dropUsage},
+	{Name: "reorder", Summary: "Move columns to the front, keeping the rest", Run: runReorder, Usage: reorderUsage},
+	{Name: "index", Summary: "Prepend a row-number column", Run: runIndex, Usage: indexUsage},
+	{Name: "rename", Summary: "Rename header columns", Run: runRename, Usage: renameUsage},
+	{Name: "eval", Summary: "Add a column computed from an expression", Run: runEval, Usage: evalUsage},
+	{Name: "dedup", Summary: "Remove duplicate rows by key", Run: runDedup, Usage: dedupUsage},
+	{Name: "concat", Summary: "Stack files with the same columns", Run: runConcat, Usage: concatUsage},
+	{Name: "sample", Summary: "Write a reproducible random subset of rows", Run: runSample, Usage: sampleUsage},
+	{Name: "stats", Summary: "Print per-column summary statistics", Run: runStats, Usage: statsUsage},
+	{Name: "describe", Summary: "Print columns, first rows and statistics together", Run: runDescribe, Usage: describeUsage},
+	{Name: "pivot", Summary: "Cross-tabulate one column against another", Run: runPivot, Usage: pivotUsage},
+	{Name: "melt", Summary: "Unpivot columns into variable/value rows", Run: runMelt, Usage: meltUsage},
+	{Name: "transpose", Summary: "Swap rows and columns", Run: runTranspose, Usage: transposeUsage},
+	{Name: "schema", Summary: "Infer column types", Run: runSchema, Usage: schemaUsage},
+	{Name: "diff", Summary: "Compare two files by a key column", Run: runDiff, Usage: diffUsage},
+	{Name: "join", Summary: "Join two files on a key column", Run: runJoin, Usage: joinUsage},
+	{Name: "intersect", Summary: "Keep rows whose key also appears in a second file", Run: runIntersect, Usage: intersectUsage},
+	{Name: "except", Summary: "Keep rows whose key does not appear in a second file", Run: runExcept, Usage: exceptUsage},
+	{Name: "union", Summary: "Combine two files, dropping rows with a key already seen", Run: runUnion, Usage: unionUsage},
+	{Name: "fill", Summary: "Replace NULL cells with a value or a neighbor", Run: runFill, Usage: fillUsage},
+	{Name: "split", Summary: "Split into one file per value or per chunk", Run: runSplit, Usage: splitUsage},
+	{Name: "trim", Summary: "Strip surrounding whitespace from cells", Run: runTrim, Usage: trimUsage},
+	{Name: "unique", Summary: "Count distinct values in a column", Run: runUnique, Usage: uniqueUsage},
+	{Name: "nullify", Summary: "Convert empty/NA/NULL markers to NULL", Run: runNullify, Usage: nullifyUsage},
+	{Name: "hash", Summary: "Replace cells with salted SHA-256 digests", Run: runHash, Usage: hashUsage},
+	{Name: "mask", Summary: "Partially redact cells, e.g. show the last 4 characters", Run: runMask, Usage: maskUsage},
+}
+
+// lookupCommand returns the registered command with the given name.
+func lookupCommand(name string) (Command, bool) {
+	for _, c := range commands {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Command{}, false
+}
+
 // run is the top-level command dispatcher.
 //
 // argv is expected to look like os.Args (argv[0] is the program name).
@@ -54,23 +133,326 @@ func run(argv []string, out, errOut io.Writer) int {
 		return 2
 	}
 
-	// argv[1] is the subcommand (cols/head/nullify/etc).
-	switch argv[1] {
-	case "cols":
-		return runCols(argv[2:], out, errOut)
-	case "head":
-		return runHead(argv[2:], out, errOut)
-	case "nullify":
-		return runNullify(argv[2:], out, errOut)
-	case "-h", "--help", "help":
+	// Global flags come before the subcommand name; parsing stops at the
+	// first non-flag argument, which is the subcommand.
+	g, args, code := parseGlobalFlags(argv[1:], out, errOut)
+	if args == nil {
+		return code
+	}
+
+	// args[0] is the subcommand (cols/head/nullify/etc).
+	switch args[0] {
+	case "help":
+		return runHelp(args[1:], out, errOut)
+	case "completion":
+		return runCompletion(args[1:], out, errOut)
+	}
+
+	cmd, ok := lookupCommand(args[0])
+	if !ok {
+		// For unknown commands, return usage error and show help.
+		fmt.Fprintf(errOut, "unknown command: %q\n\n", args[0])
+		usage(errOut)
+		return 2
+	}
+
+	return cmd.Run(g, args[1:], out, errOut)
+}
+
+// globalOptions holds the global flags, which precede the subcommand name.
+// run parses them on every call and passes them to the command, so nothing
+// carries over between calls. Each field is zero when its flag was not
+// given.
+type globalOptions struct {
+	delim     rune // --delim, or 0 for the per-file default
+	delimAuto bool // --delim auto: detect each input's delimiter
+
+	// Input options: --comment-char, --skip-lines, --no-header, --encoding,
+	// --quote-char, --lazy-quotes and --http-timeout.
+	comment     rune
+	skipLines   int
+	noHeader    bool
+	encoding    string
+	quoteChar   rune
+	lazyQuotes  bool
+	httpTimeout time.Duration
+
+	// lf is set by --lf: openOutput then strips carriage returns from
+	// command output.
+	lf bool
+
+	// progressEvery is the progress interval in rows with --verbose, or 0
+	// when progress is off.
+	progressEvery int
+
+	// log is the command's stderr, where --delim auto reports what it
+	// detected.
+	log io.Writer
+
+	// onFlagSet, when non-nil, receives each FlagSet made by newFlagSet.
+	// "df completion" uses it to list a command's flags.
+	onFlagSet func(*flag.FlagSet)
+}
+
+// newFlagSet returns the FlagSet for the named subcommand, reporting parse
+// errors and usage to errOut.
+func (g *globalOptions) newFlagSet(name string, errOut io.Writer, usage func(io.Writer)) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	fs.Usage = func() { usage(errOut) }
+	if g.onFlagSet != nil {
+		g.onFlagSet(fs)
+	}
+	return fs
+}
+
+// newProgress returns a reporter for long-running commands, or nil (which
+// reports nothing) without --verbose. Progress goes to errOut and overwrites
+// itself when errOut is a terminal.
+func (g *globalOptions) newProgress(errOut io.Writer) *progress.ProgressReporter {
+	if g.progressEvery == 0 {
+		return nil
+	}
+	return progress.New(errOut, g.progressEvery, render.IsTerminal(errOut))
+}
+
+// parseGlobalFlags parses the flags that precede the subcommand name. It
+// returns them with the remaining arguments, starting with the subcommand. A
+// nil slice means run should stop and return code: help was printed or the
+// flags were invalid.
+func parseGlobalFlags(args []string, out, errOut io.Writer) (*globalOptions, []string, int) {
+	g := &globalOptions{log: errOut}
+
+	fs := flag.NewFlagSet("df", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	fs.Usage = func() {}
+
+	var delim string
+	fs.StringVar(&delim, "delim", "", "Field delimiter for input and output")
+	fs.StringVar(&delim, "sep", "", "Alias for --delim")
+	comment := fs.String("comment-char", "", "Skip input lines starting with this character")
+	skipLines := fs.Int("skip-lines", 0, "Skip this many lines at the start of each input")
+	encoding := fs.String("encoding", "", "Input character encoding: utf-8, latin1, windows-1252, ...")
+	quoteChar := fs.String("quote-char", "", "Character that quotes fields (default \")")
+	lazyQuotes := fs.Bool("lazy-quotes", false, "Accept stray quotes in fields")
+	lf := fs.Bool("lf", false, "Strip carriage returns from output")
+	noHeader := fs.Bool("no-header", false, "Inputs have no header row; name columns col_0, col_1, ...")
+	httpTimeout := fs.Duration("http-timeout", csvio.DefaultHTTPTimeout, "Time limit for each http(s) input")
+
+	verbose := fs.Bool("verbose", false, "Print progress to stderr during long operations (-v is --version)")
+	every := fs.Int("progress-every", progress.DefaultEvery, "Rows between --verbose progress lines")
+
+	var showVersion bool
+	fs.BoolVar(&showVersion, "version", false, "Print the df version and exit")
+	fs.BoolVar(&showVersion, "v", false, "Alias for --version")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			usage(out)
+			return nil, nil, 0
+		}
+		usage(errOut)
+		return nil, nil, 2
+	}
+
+	if showVersion {
+		fmt.Fprintf(out, "df %s\n", version())
+		return nil, nil, 0
+	}
+
+	if *every <= 0 {
+		fmt.Fprintln(errOut, "--progress-every must be positive")
+		return nil, nil, 2
+	}
+	if *verbose {
+		g.progressEvery = *every
+	}
+
+	if strings.EqualFold(delim, "auto") {
+		g.delimAuto = true
+	} else if delim != "" {
+		r, err := parseDelimiter(delim)
+		if err != nil {
+			fmt.Fprintln(errOut, err)
+			return nil, nil, 2
+		}
+		g.delim = r
+	}
+
+	if *comment != "" {
+		r, err := parseCommentChar(*comment, g.delim)
+		if err != nil {
+			fmt.Fprintln(errOut, err)
+			return nil, nil, 2
+		}
+		g.comment = r
+	}
+	if *httpTimeout <= 0 {
+		fmt.Fprintln(errOut, "--http-timeout must be positive")
+		return nil, nil, 2
+	}
+	g.httpTimeout = *httpTimeout
+
+	if *skipLines < 0 {
+		fmt.Fprintln(errOut, "--skip-lines must be >= 0")
+		return nil, nil, 2
+	}
+	g.skipLines = *skipLines
+	g.noHeader = *noHeader
+	g.lazyQuotes = *lazyQuotes
+	g.lf = *lf
+
+	if *quoteChar != "" {
+		r, err := parseQuoteChar(*quoteChar, g.delim)
+		if err != nil {
+			fmt.Fprintln(errOut, err)
+			return nil, nil, 2
+		}
+		g.quoteChar = r
+	}
+
+	enc, err := csvio.ParseEncoding(*encoding)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return nil, nil, 2
+	}
+	g.encoding = enc
+
+	if fs.NArg() == 0 {
+		usage(errOut)
+		return nil, nil, 2
+	}
+	return g, fs.Args(), 0
+}
+
+// devVersion is reported when the binary carries no module version, e.g. a
+// local "go build" or "go run".
+const devVersion = "(development)"
+
+// version returns the module version recorded by the Go toolchain at build
+// time, so "go install ...@v1.2.3" binaries report v1.2.3.
+func version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" || info.Main.Version == "(devel)" {
+		return devVersion
+	}
+	return info.Main.Version
+}
+
+// parseDelimiter converts a --delim value into a rune. Besides a single
+// character it accepts the escape "\t" and the word "tab", since a literal
+// tab is awkward to type in most shells.
+func parseDelimiter(s string) (rune, error) {
+	switch s {
+	case `\t`, "tab":
+		return '\t', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError {
+		return 0, fmt.Errorf("--delim must be a single character, got %q", s)
+	}
+	if r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("--delim cannot be %q", r)
+	}
+	return r, nil
+}
+
+// parseCommentChar converts a --comment-char value into a rune. It must be a
+// single character that the CSV parser can tell apart from data: not a
+// quote, line break or the delimiter (delim, or ',' when delim is 0).
+func parseCommentChar(s string, delim rune) (rune, error) {
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError {
+		return 0, fmt.Errorf("--comment-char must be a single character, got %q", s)
+	}
+	if delim == 0 {
+		delim = ','
+	}
+	if r == '"' || r == '\r' || r == '\n' || r == delim {
+		return 0, fmt.Errorf("--comment-char cannot be %q", r)
+	}
+	return r, nil
+}
+
+// parseQuoteChar converts a --quote-char value into a rune. Like
+// --comment-char it must be a single character other than a line break or
+// the delimiter (delim, or ',' when delim is 0).
+func parseQuoteChar(s string, delim rune) (rune, error) {
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError {
+		return 0, fmt.Errorf("--quote-char must be a single character, got %q", s)
+	}
+	if delim == 0 {
+		delim = ','
+	}
+	if r == '\r' || r == '\n' || r == delim {
+		return 0, fmt.Errorf("--quote-char cannot be %q", r)
+	}
+	return r, nil
+}
+
+// inputOptions returns the csvio.Options for reading path (and for writing
+// output derived from it): the global --delim if given, otherwise tab for
+// ".tsv" (or ".tsv.gz") files and comma for everything else, plus the global
+// input options (--comment-char, --encoding, ...). With "--delim auto" the delimiter is
+// detected from the file's content instead (see detectDelimiter).
+func (g *globalOptions) inputOptions(path string) csvio.Options {
+	opts := csvio.Options{
+		Delimiter:   g.delim,
+		Comment:     g.comment,
+		SkipLines:   g.skipLines,
+		NoHeader:    g.noHeader,
+		Encoding:    g.encoding,
+		QuoteChar:   g.quoteChar,
+		LazyQuotes:  g.lazyQuotes,
+		HTTPTimeout: g.httpTimeout,
+	}
+	if g.delimAuto {
+		opts.Delimiter = g.detectDelimiter(path)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".gz") {
+		path = path[:len(path)-len(".gz")]
+	}
+	if opts.Delimiter == 0 && strings.EqualFold(filepath.Ext(path), ".tsv") {
+		opts.Delimiter = '\t'
+	}
+	return opts
+}
+
+// fileInputOptions returns inputOptions for each of several input paths, so
+// inputs in different dialects (a.csv and b.tsv, or each file sniffed by
+// --delim auto) are read correctly. Commands use the first entry for their
+// output.
+func (g *globalOptions) fileInputOptions(paths ...string) []csvio.Options {
+	opts := make([]csvio.Options, len(paths))
+	for i, p := range paths {
+		opts[i] = g.inputOptions(p)
+	}
+	return opts
+}
+
+// runHelp implements "df help [command]". Without an argument it prints the
+// top-level usage; with one it prints that command's full usage.
+func runHelp(args []string, out, errOut io.Writer) int {
+	if len(args) == 0 {
 		usage(out)
 		return 0
-	default:
-		// For unknown commands, return usage error and show help.
-		fmt.Fprintf(errOut, "unknown command: %q\n\n", argv[1])
+	}
+
+	if args[0] == "completion" {
+		completionUsage(out)
+		return 0
+	}
+
+	cmd, ok := lookupCommand(args[0])
+	if !ok {
+		fmt.Fprintf(errOut, "unknown command: %q\n\n", args[0])
 		usage(errOut)
 		return 2
 	}
+	cmd.Usage(out)
+	return 0
 }
 
 // usage prints help text. It intentionally writes to an io.Writer so callers
@@ -79,30 +461,222 @@ func usage(w io.Writer) {
 	fmt.Fprint(w, `df - a tiny CSV-first dataframe-ish CLI
 
 Usage:
-  df <command> [args]
+  df [--delim C] <command> [args]
+  df help <command>
+  df completion <bash|zsh|fish>
+  df --version
 
 Commands:
-  cols <file.csv>                         Print column headers
-  head <file.csv> [-n N]                  Print the first N rows (default 5)
-  nullify <file.csv> -o out.csv [flags]   Convert empty/NA/NULL markers to NULL
+`)
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.Name, c.Summary)
+	}
+	fmt.Fprint(w, `
+Examples:
+  df cols input.csv
+  df head input.csv -n 10
+  df nullify input.csv -o cleaned.csv --blanks --na --null-literal
+  cat input.csv | df head -
+
+A file argument of "-" reads standard input.
+
+The commands that take several files (concat, union, intersect, except and
+diff) replace a file argument "@list.txt" with the paths listed in
+list.txt, one per line ("#" starts a comment line); "@@name.csv" is a file
+named "@name.csv". Flag values, including join's --left and --right, are
+never expanded.
+
+Global flags (before the command):
+  --delim C, --sep C   Field delimiter for input and output, e.g. ";" or
+                       "\t" (default: tab for .tsv files, otherwise comma);
+                       "auto" detects it from each file's first 4KB
+  --comment-char C     Skip input lines starting with C, e.g. "#", before
+                       the header or between rows
+  --skip-lines N       Skip the first N lines of each input file, e.g. a
+                       report title above the header
+  --no-header          Inputs have no header row: every line is data and
+                       columns are named col_0, col_1, ...
+  --encoding NAME      Input character encoding, transcoded to UTF-8:
+                       utf-8 (default), latin1, windows-1252, utf-16-le,
+                       utf-16-be
+  --quote-char C       Character that quotes input fields, e.g. "'" for
+                       'a, b' (default "); output always uses "
+  --lazy-quotes        Accept stray quotes inside fields instead of failing
+  --http-timeout D     Time limit for each http:// or https:// input,
+                       e.g. 2m (default 30s)
+  --lf                 Strip every carriage return from CSV output, so no
+                       "\r" survives, not even inside quoted values
+  --verbose            Print progress to stderr while nullify, filter and
+                       concat run (no -v short form: -v is --version)
+  --progress-every N   Rows between progress lines (default 100000)
+  --version, -v        Print the df version and exit
+
+Files ending in .gz are read and written gzip-compressed; compressed input
+is also recognized by its content whatever the name.
+
+A file argument starting with http:// or https:// is fetched with a GET,
+e.g. a signed S3 URL or a raw GitHub file.
+
+Run "df help <command>" for a command's flags and more examples.
+`)
+}
+
+// colsUsage prints help for the "cols" subcommand.
+func colsUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df cols <file.csv> [flags]
+
+Print column headers, one per line, with a zero-based index.
+
+Flags:
+  --sample N           Show up to N non-blank example values per column
+  --diff FILE          Compare the headers with FILE's: list the columns
+                       removed, added, common to both, and moved (positions
+                       are zero-based; a column only counts as moved if its
+                       order relative to the other common columns changed)
+  --detect-encoding    Detect the input encoding and transcode to UTF-8
 
 Examples:
   df cols input.csv
+  df cols input.csv --sample 3
+  df cols old_feed.csv --diff new_feed.csv
+`)
+}
+
+// headUsage prints help for the "head" subcommand.
+func headUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df head <file.csv> [flags]
+
+Print the first N data rows as a table. Flags may appear before or after
+the file.
+
+Flags:
+  -n N                 Number of rows to display (default 5)
+  --offset M           Skip the first M data rows; the row index keeps
+                       counting from the start of the file
+  -w N                 Max width per cell in table output (default: share
+                       the terminal width among columns, or 32 when stdout
+                       is not a terminal)
+  --width N            Fit the table to N columns instead of the terminal
+  --col-width NAME=N   Pin column NAME to N runes, still capped by -w
+                       (repeatable)
+  --auto-align         Right-align columns whose first-row value is a number
+  --no-index           Hide the leading row index column
+  --format F           Output format: table, box, json, jsonl, csv, tsv,
+                       markdown, html, confluence, vertical (default table)
+  --summary            Append a per-column summary row (table format)
+  --detect-encoding    Detect the input encoding and transcode to UTF-8
+  --color              Bold header, cyan separator and dimmed empty cells
+                       (table format; ignored when stdout is not a terminal)
+  --record-separator S Line between records in vertical format (default blank)
+  --strict             Fail on a row with more or fewer fields than the
+                       header instead of padding or truncating it
+
+Examples:
   df head input.csv -n 10
   df head -n 5 input.csv
-  df head input.csv -n 5
-  df nullify input.csv -o cleaned.csv --blanks --na --null-literal
+  df head input.csv --offset 100 -n 10
+  df head input.csv --format json
+  df head wide_export.csv -n 2 --format vertical
+  df head input.csv -n 20 --summary
+  df head legacy_export.csv --detect-encoding
+  df head input.csv --strict
+`)
+}
+
+// nullifyUsage prints help for the "nullify" subcommand.
+func nullifyUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df nullify <file.csv> -o <out.csv> [flags]
+
+Write a copy of the file with NULL-like values replaced by empty fields
+(CSV's NULL). A summary is printed to stderr.
+
+The output is written to a temporary file next to it and renamed into place
+at the end, so a failed run leaves an existing output file untouched.
+
+Flags:
+  -o PATH              Output CSV path (required; "-" for stdout)
+  --blanks             Treat empty/whitespace-only cells as NULL (default true)
+  --na                 Treat NA and N/A as NULL (case-insensitive)
+  --null-literal       Treat NULL as NULL (case-insensitive)
+  --dash               Treat a bare - or -- as NULL (not values like -5)
+  --none               Treat None (Python's str(None)) as NULL
+                       (case-insensitive; independent of --null-literal)
+  --sentinel VALUE     Also treat VALUE as NULL (case-insensitive; repeatable)
+  --policy-file PATH   Load the policy from a JSON file, e.g.
+                         {"treat_blanks": true, "treat_na": true,
+                          "custom_sentinels": ["TBD"]}
+                       Flags given explicitly override the file
+  --col-policy COL:RULES
+                       Use RULES instead of the flags above for column COL;
+                       RULES is a comma list of blanks, na, null-literal,
+                       dash, none (repeatable; "COL:" disables nulling)
+  --explain            Also write <out>.explain.csv (row, col, original_value,
+                       reason) listing each nullified cell and the rule matched
+  --detect-encoding    Detect the input encoding and transcode to UTF-8
+  --no-atomic          Write the output in place (e.g. when its directory is
+                       not writable, or it is a symlink or named pipe)
+  --preview            Print a table of the cells that would change (row,
+                       column, original value, rule) instead of writing
+                       output; -o is not needed
+  --preview-limit N    Number of changed cells --preview shows (default 20)
+  --stats-format F     Summary format on stderr: text (default) or json, a
+                       single object with rows_read, cells_checked,
+                       cells_nullified, ... and per_column counts; not
+                       with --preview or --dry-run
+  --stats-file PATH    Write the json summary to PATH instead of stderr
+  --dry-run            Run the whole transformation without writing anything
+                       and report the rows and cells that would change; -o
+                       is not needed and is never touched
+  --report PATH        Also write per-column statistics to a CSV (column_name,
+                       cells_checked, cells_nullified, null_rate_pct), most
+                       nullified first
+  --max-errors N       Skip malformed rows (e.g. a stray quote) with a
+                       warning, aborting at the Nth; 0 never aborts
+                       (default 1: the first one aborts)
+
+Examples:
+  df nullify input.csv -o cleaned.csv --na --null-literal
+  df nullify input.csv --na --preview
+  df nullify input.csv -o cleaned.csv --na --dry-run
+  df nullify input.csv -o cleaned.csv --stats-format json --stats-file stats.json
+  df nullify input.csv -o cleaned.csv --blanks=false --na
+  df nullify input.csv -o cleaned.csv --sentinel TBD --sentinel UNKNOWN
+  df nullify input.csv -o cleaned.csv --col-policy phone:dash --col-policy email:na,blanks
+  df nullify input.csv -o cleaned.csv --policy-file nullpolicy.json --na=false
+  cat input.csv | df nullify - -o - --na > cleaned.csv
 `)
 }
 
+// colsSampleScanRows bounds how many data rows "cols --sample" scans when
+// looking for non-null example values.
+const colsSampleScanRows = 100
+
 // runCols implements the "cols" subcommand.
 //
 // It reads only the header row and prints one header per line, prefixed with
 // a zero-based column index for quick reference in spreadsheets and scripts.
-func runCols(args []string, out, errOut io.Writer) int {
+//
+// With --sample N, it instead prints a table of column names alongside up to N
+// non-blank example values taken from the start of the file.
+func runCols(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-sample":           true,
+		"--sample":          true,
+		"-detect-encoding":  false,
+		"--detect-encoding": false,
+		"-diff":             true,
+		"--diff":            true,
+	})
+
 	// Each command uses its own FlagSet so parsing is isolated by subcommand.
-	fs := flag.NewFlagSet("cols", flag.ContinueOnError)
-	fs.SetOutput(errOut)
+	fs := g.newFlagSet("cols", errOut, colsUsage)
+
+	sample := fs.Int("sample", 0, "Show up to N example values per column (e.g. 3)")
+	detect := fs.Bool("detect-encoding", false, "Detect the input encoding and transcode to UTF-8")
+	diffPath := fs.String("diff", "", "Compare the headers with those of this second file")
 
 	// Parse command args; on parse error, treat as usage error.
 	if err := fs.Parse(args); err != nil {
@@ -115,13 +689,42 @@ func runCols(args []string, out, errOut io.Writer) int {
 		return 2
 	}
 
+	if *sample < 0 {
+		fmt.Fprintln(errOut, "--sample must be >= 0")
+		return 2
+	}
+
 	path := fs.Arg(0)
-	headers, err := csvio.ReadHeaders(path)
+	opts := g.inputOptions(path)
+	if *detect {
+		enc, err := g.detectInputEncoding(path, errOut)
+		if err != nil {
+			fmt.Fprintln(errOut, "error:", err)
+			return 1
+		}
+		opts.Encoding = enc
+	}
+
+	if *diffPath != "" && *sample > 0 {
+		fmt.Fprintln(errOut, "--diff cannot be combined with --sample")
+		return 2
+	}
+	if *diffPath != "" {
+		return printHeaderDiff(path, *diffPath, opts, out, errOut)
+	}
+	if *sample > 0 {
+		return printColumnSamples(path, *sample, opts, out, errOut)
+	}
+
+	headers, err := csvio.ReadHeaders(path, opts)
 	if err != nil {
 		fmt.Fprintln(errOut, "error:", err)
 		return 1
 	}
 
+	if opts.NoHeader {
+		fmt.Fprintln(errOut, "(no header)")
+	}
 	for i, h := range headers {
 		fmt.Fprintf(out, "%d\t%s\n", i, h)
 	}
@@ -129,25 +732,114 @@ func runCols(args []string, out, errOut io.Writer) int {
 	return 0
 }
 
+// printHeaderDiff renders "cols --diff": the columns only in pathA, only in
+// pathB, in both, and those that changed position, one section each.
+func printHeaderDiff(pathA, pathB string, opts csvio.Options, out, errOut io.Writer) int {
+	d, err := csvio.DiffHeaders(pathA, pathB, opts)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	section := func(title string, names []string) {
+		fmt.Fprintf(out, "%s (%d):\n", title, len(names))
+		for _, n := range names {
+			fmt.Fprintf(out, "  %s\n", n)
+		}
+	}
+	section("Removed (only in "+pathA+")", d.Removed)
+	section("Added (only in "+pathB+")", d.Added)
+	section("Common", d.Common)
+
+	fmt.Fprintf(out, "Moved (%d):\n", len(d.Moved))
+	for _, m := range d.Moved {
+		fmt.Fprintf(out, "  %s: %d -> %d\n", m.Name, m.From, m.To)
+	}
+
+	return 0
+}
+
+// printColumnSamples renders the "cols --sample" table: one row per column with
+// its name and up to n pipe-separated example values.
+func printColumnSamples(path string, n int, opts csvio.Options, out, errOut io.Writer) int {
+	// Scan a window larger than n so sparse columns still get samples.
+	headers, rows, err := csvio.ReadHead(path, max(n, colsSampleScanRows), csvio.ReadOptions{Options: opts})
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	samples := csvio.SampleValuesPerColumn(headers, rows, n, nulls.Policy{TreatBlanks: true})
+
+	table := make([][]string, len(headers))
+	for i, h := range headers {
+		table[i] = []string{h, strings.Join(samples[h], " | ")}
+	}
+
+	err = render.PrintTable(out, []string{"column", "samples"}, table, render.TableOptions{
+		MaxCellWidth: 60,
+		ShowRowIndex: true,
+	})
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
 // runHead implements the "head" subcommand (similar to pandas DataFrame.head()).
 //
 // Users often expect to be able to place flags after positional arguments,
 // e.g. "df head file.csv -n 5". The standard library flag package does not
 // support that by default, so we reorder a small whitelist of flags.
-func runHead(args []string, out, errOut io.Writer) int {
+func runHead(g *globalOptions, args []string, out, errOut io.Writer) int {
 	// Allow: df head file.csv -n 5
 	// (stdlib flag normally stops parsing flags once it sees a positional arg)
 	args = reorderFlagsToFront(args, map[string]bool{
-		"-n": true,
-		"-w": true,
+		"-n":                 true,
+		"-w":                 true,
+		"-offset":            true,
+		"--offset":           true,
+		"-no-index":          false,
+		"--no-index":         false,
+		"-format":            true,
+		"--format":           true,
+		"-summary":           false,
+		"--summary":          false,
+		"-detect-encoding":   false,
+		"--detect-encoding":  false,
+		"-color":             false,
+		"--color":            false,
+		"-record-separator":  true,
+		"--record-separator": true,
+		"-width":             true,
+		"--width":            true,
+		"-col-width":         true,
+		"--col-width":        true,
+		"-auto-align":        false,
+		"--auto-align":       false,
+		"-strict":            false,
+		"--strict":           false,
 	})
 
-	fs := flag.NewFlagSet("head", flag.ContinueOnError)
-	fs.SetOutput(errOut)
+	fs := g.newFlagSet("head", errOut, headUsage)
 
 	// -n controls how many rows are printed; -w caps printed cell width.
 	n := fs.Int("n", 5, "Number of rows to display")
-	maxWidth := fs.Int("w", 32, "Max width per cell when printing")
+	offset := fs.Int("offset", 0, "Skip this many data rows before the first one displayed")
+	maxWidth := fs.Int("w", 0, "Max width per cell when printing (0 = fit the terminal, or 32)")
+	termWidth := fs.Int("width", 0, "Table width to fit instead of the detected terminal width")
+	var colWidths stringList
+	fs.Var(&colWidths, "col-width", "Pin a column's width as NAME=N (repeatable)")
+	autoAlign := fs.Bool("auto-align", false, "Right-align columns whose first-row value is a number")
+	noIndex := fs.Bool("no-index", false, "Hide the leading row index column")
+	format := fs.String("format", "table", "Output format: table, box, json, jsonl, csv, tsv, markdown, html, confluence, vertical")
+	summary := fs.Bool("summary", false, "Append a per-column summary row (table format)")
+	detect := fs.Bool("detect-encoding", false, "Detect the input encoding and transcode to UTF-8")
+	color := fs.Bool("color", false, "Color the table header, separator and empty cells (terminal only)")
+	recordSep := fs.String("record-separator", "", "Line printed between records in vertical format (default blank)")
+	strict := fs.Bool("strict", false, "Fail on the first row whose field count differs from the header's")
 
 	if err := fs.Parse(args); err != nil {
 		return 2
@@ -162,19 +854,63 @@ func runHead(args []string, out, errOut io.Writer) int {
 		fmt.Fprintln(errOut, "-n must be >= 0")
 		return 2
 	}
+	if *offset < 0 {
+		fmt.Fprintln(errOut, "--offset must be >= 0")
+		return 2
+	}
+
+	outFormat, err := render.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 2
+	}
+
+	widths, err := parseColumnWidths(colWidths)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 2
+	}
 
 	path := fs.Arg(0)
-	headers, rows, err := csvio.ReadHead(path, *n)
+	readOpts := g.inputOptions(path)
+	if *detect {
+		enc, err := g.detectInputEncoding(path, errOut)
+		if err != nil {
+			fmt.Fprintln(errOut, "error:", err)
+			return 1
+		}
+		readOpts.Encoding = enc
+	}
+
+	headers, rows, err := csvio.ReadSlice(path, *offset, *n, csvio.ReadOptions{Options: readOpts, Strict: *strict})
 	if err != nil {
 		fmt.Fprintln(errOut, "error:", err)
 		return 1
 	}
 
-	// Print a simple fixed-width table suitable for terminal viewing and copy/paste.
-	render.PrintTable(out, headers, rows, render.TableOptions{
-		MaxCellWidth: *maxWidth,
-		ShowRowIndex: true,
-	})
+	opts := render.TableOptions{
+		MaxCellWidth:    *maxWidth,
+		TerminalWidth:   *termWidth,
+		ColumnWidths:    widths,
+		ShowRowIndex:    !*noIndex,
+		RowIndexStart:   *offset,
+		Format:          outFormat,
+		RecordSeparator: *recordSep,
+		ColorEnabled:    *color && render.IsTerminal(out),
+	}
+	if *summary {
+		opts.FooterRow = render.ComputeSummaryRow(headers, rows)
+	}
+	if *autoAlign {
+		opts.NumericColumns = numericColumns(headers, rows)
+	}
+
+	// The default is a simple fixed-width table suitable for terminal viewing
+	// and copy/paste; --format selects machine- or document-friendly output.
+	if err := render.PrintTable(out, headers, rows, opts); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
 
 	return 0
 }
@@ -187,19 +923,72 @@ func runHead(args []string, out, errOut io.Writer) int {
 // The null policy is configurable via flags. In addition to empty/whitespace-only
 // values, callers may opt into treating NA/N/A or the literal string "NULL"
 // (case-insensitive) as NULL.
-func runNullify(args []string, out, errOut io.Writer) int {
-	// out is reserved for future “preview” output; the command summary is written
-	// to errOut so stdout can remain machine-readable if needed later.
-	_ = out
+func runNullify(g *globalOptions, args []string, out, errOut io.Writer) int {
+	// Allow the documented "df nullify input.csv -o out.csv" order.
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":                true,
+		"-blanks":           false,
+		"--blanks":          false,
+		"-na":               false,
+		"--na":              false,
+		"-null-literal":     false,
+		"--null-literal":    false,
+		"-dash":             false,
+		"--dash":            false,
+		"-none":             false,
+		"--none":            false,
+		"-sentinel":         true,
+		"--sentinel":        true,
+		"-policy-file":      true,
+		"--policy-file":     true,
+		"-col-policy":       true,
+		"--col-policy":      true,
+		"-explain":          false,
+		"--explain":         false,
+		"-detect-encoding":  false,
+		"--detect-encoding": false,
+		"-no-atomic":        false,
+		"--no-atomic":       false,
+		"-preview":          false,
+		"--preview":         false,
+		"-preview-limit":    true,
+		"--preview-limit":   true,
+		"-dry-run":          false,
+		"--dry-run":         false,
+		"-stats-format":     true,
+		"--stats-format":    true,
+		"-stats-file":       true,
+		"--stats-file":      true,
+		"-report":           true,
+		"--report":          true,
+		"-max-errors":       true,
+		"--max-errors":      true,
+	})
 
-	fs := flag.NewFlagSet("nullify", flag.ContinueOnError)
-	fs.SetOutput(errOut)
+	fs := g.newFlagSet("nullify", errOut, nullifyUsage)
 
 	// -o is required; other flags control which sentinel values count as NULL.
-	outPath := fs.String("o", "", "Output CSV path (required)")
+	outPath := fs.String("o", "", "Output CSV path (required; - for stdout)")
 	blanks := fs.Bool("blanks", true, "Treat empty/whitespace-only cells as NULL")
 	na := fs.Bool("na", false, "Treat NA and N/A as NULL (case-insensitive)")
 	nullLiteral := fs.Bool("null-literal", false, "Treat NULL as NULL (case-insensitive)")
+	dash := fs.Bool("dash", false, "Treat a bare - or -- as NULL")
+	none := fs.Bool("none", false, "Treat None as NULL (case-insensitive)")
+	var sentinels stringList
+	fs.Var(&sentinels, "sentinel", "Additional value to treat as NULL (case-insensitive; repeatable)")
+	policyFile := fs.String("policy-file", "", "Load the null policy from a JSON file; flags given override it")
+	var colPolicies stringList
+	fs.Var(&colPolicies, "col-policy", "Per-column policy as COL:RULES, e.g. phone:dash (repeatable)")
+	explain := fs.Bool("explain", false, "Write <out>.explain.csv listing each nullified cell and why")
+	detect := fs.Bool("detect-encoding", false, "Detect the input encoding and transcode to UTF-8")
+	noAtomic := fs.Bool("no-atomic", false, "Write the output in place instead of via a temporary file")
+	preview := fs.Bool("preview", false, "Print the cells that would change instead of writing output")
+	previewLimit := fs.Int("preview-limit", csvio.DefaultPreviewLimit, "Number of changed cells --preview shows")
+	dryRun := fs.Bool("dry-run", false, "Run the full nullify and report what would change, writing nothing")
+	statsFormat := fs.String("stats-format", "text", "Summary format: text or json")
+	statsFile := fs.String("stats-file", "", "Write the json summary to this path instead of stderr")
+	reportPath := fs.String("report", "", "Write per-column null statistics to this CSV path")
+	maxErrors := fs.Int("max-errors", 1, "Abort at this many malformed rows, skipping the ones before (0 = never abort)")
 
 	if err := fs.Parse(args); err != nil {
 		return 2
@@ -209,35 +998,535 @@ func runNullify(args []string, out, errOut io.Writer) int {
 		fmt.Fprintln(errOut, "nullify requires exactly one argument: <file.csv>")
 		return 2
 	}
-	if *outPath == "" {
+	if *outPath == "" && !*preview && !*dryRun {
 		fmt.Fprintln(errOut, "nullify requires -o <output.csv>")
 		return 2
 	}
+	if *preview && *explain {
+		fmt.Fprintln(errOut, "--explain cannot be combined with --preview")
+		return 2
+	}
+	if *dryRun && (*preview || *explain || *reportPath != "") {
+		fmt.Fprintln(errOut, "--dry-run cannot be combined with --preview, --explain or --report")
+		return 2
+	}
+	if *previewLimit <= 0 {
+		fmt.Fprintln(errOut, "--preview-limit must be positive")
+		return 2
+	}
+	if *maxErrors < 0 {
+		fmt.Fprintln(errOut, "--max-errors must be >= 0")
+		return 2
+	}
+	if *statsFormat != "text" && *statsFormat != "json" {
+		fmt.Fprintf(errOut, "unknown stats format %q (want text or json)\n", *statsFormat)
+		return 2
+	}
+	if *statsFile != "" && *statsFormat != "json" {
+		fmt.Fprintln(errOut, "--stats-file requires --stats-format json")
+		return 2
+	}
+	if *statsFormat == "json" && (*preview || *dryRun) {
+		fmt.Fprintln(errOut, "--stats-format json cannot be combined with --preview or --dry-run")
+		return 2
+	}
 
 	inPath := fs.Arg(0)
 
-	stats, err := csvio.NullifyFile(inPath, *outPath, nulls.Policy{
+	if *explain && *outPath == csvio.StdioPath {
+		fmt.Fprintln(errOut, "--explain requires -o <output.csv>, not stdout")
+		return 2
+	}
+
+	opts := csvio.NullifyOptions{
+		Options:        g.inputOptions(inPath),
+		Explain:        *explain,
+		NoAtomic:       *noAtomic,
+		Progress:       g.newProgress(errOut),
+		MaxParseErrors: *maxErrors,
+		OnParseError: func(err error) {
+			fmt.Fprintln(errOut, "warning: skipped malformed row:", err)
+		},
+	}
+	// --max-errors 0 means "never abort"; for csvio that is any negative
+	// value, since its zero value keeps the abort-on-first default.
+	if *maxErrors == 0 {
+		opts.MaxParseErrors = -1
+	}
+	for _, spec := range colPolicies {
+		col, p, err := parseColumnPolicy(spec)
+		if err != nil {
+			fmt.Fprintln(errOut, err)
+			return 2
+		}
+		if opts.ColumnPolicies == nil {
+			opts.ColumnPolicies = make(map[string]nulls.Policy)
+		}
+		opts.ColumnPolicies[col] = p
+	}
+	if *detect {
+		enc, err := g.detectInputEncoding(inPath, errOut)
+		if err != nil {
+			fmt.Fprintln(errOut, "error:", err)
+			return 1
+		}
+		opts.Encoding = enc
+	}
+
+	// Without --policy-file the policy comes straight from the flags (and
+	// their defaults). With it, the file is the base and only flags given on
+	// the command line override it; sentinels are added to the file's.
+	policy := nulls.Policy{
 		TreatBlanks:      *blanks,
 		TreatNA:          *na,
 		TreatNULLLiteral: *nullLiteral,
-	})
+		TreatDash:        *dash,
+		TreatNone:        *none,
+		CustomSentinels:  sentinels,
+	}
+	if *policyFile != "" {
+		base, err := nulls.LoadPolicyFile(*policyFile)
+		if err != nil {
+			fmt.Fprintln(errOut, "error:", err)
+			return 1
+		}
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "blanks":
+				base.TreatBlanks = *blanks
+			case "na":
+				base.TreatNA = *na
+			case "null-literal":
+				base.TreatNULLLiteral = *nullLiteral
+			case "dash":
+				base.TreatDash = *dash
+			case "none":
+				base.TreatNone = *none
+			}
+		})
+		base.CustomSentinels = append(base.CustomSentinels, sentinels...)
+		policy = base
+	}
+
+	if *preview {
+		return previewNullify(inPath, policy, opts, *previewLimit, out, errOut)
+	}
+	if *dryRun {
+		return dryRunNullify(inPath, *outPath, policy, opts, errOut)
+	}
+
+	var stats csvio.NullifyStats
+	var err error
+	if *outPath == csvio.StdioPath {
+		stats, err = nullifyToStdout(g, inPath, policy, opts, out)
+	} else {
+		stats, err = csvio.NullifyFile(inPath, *outPath, policy, opts)
+	}
 	if err != nil {
-		fmt.Fprintln(errOut, "error:", err)
-		return 1
+		return reportError(errOut, err)
+	}
+	opts.Progress.Done(stats.RowsRead,
+		progress.Counter{Name: "cells checked", Value: stats.CellsChecked},
+		progress.Counter{Name: "cells nullified", Value: stats.CellsNullified})
+
+	if *reportPath != "" {
+		if err := writeNullReport(*reportPath, stats); err != nil {
+			fmt.Fprintln(errOut, "error:", err)
+			return 1
+		}
+	}
+
+	// The JSON summary replaces the text one entirely so a script can parse
+	// stderr (or the --stats-file) as a single document.
+	if *statsFormat == "json" {
+		if err := writeStatsJSON(*statsFile, stats, errOut); err != nil {
+			fmt.Fprintln(errOut, "error:", err)
+			return 1
+		}
+		return 0
 	}
 
 	// Summary is written to stderr to keep stdout free for future "data output" modes.
 	fmt.Fprintf(errOut, "Rows read: %d\n", stats.RowsRead)
 	fmt.Fprintf(errOut, "Cells checked: %d\n", stats.CellsChecked)
 	fmt.Fprintf(errOut, "Cells nullified (changed): %d\n", stats.CellsNullified)
+	if stats.ParseErrors > 0 {
+		fmt.Fprintf(errOut, "Malformed rows skipped: %d\n", stats.ParseErrors)
+	}
 	fmt.Fprintf(errOut, "Wrote: %s\n", *outPath)
+	if *explain {
+		fmt.Fprintf(errOut, "Wrote: %s\n", *outPath+csvio.ExplainSuffix)
+	}
+	if *reportPath != "" {
+		fmt.Fprintf(errOut, "Wrote: %s\n", *reportPath)
+	}
 
 	return 0
 }
 
+// previewNullify implements "nullify --preview": it prints a table of the
+// first limit cells the policy would change and a summary, writing no files.
+// Changes are the point of a preview, so finding some still exits 0.
+func previewNullify(inPath string, policy nulls.Policy, opts csvio.NullifyOptions, limit int, out, errOut io.Writer) int {
+	in, err := csvio.OpenInputWithOptions(inPath, opts.Options)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	cells, stats, err := csvio.PreviewNullify(in, policy, opts, limit)
+	if err != nil {
+		return reportError(errOut, err)
+	}
+	opts.Progress.Done(stats.RowsRead,
+		progress.Counter{Name: "cells checked", Value: stats.CellsChecked},
+		progress.Counter{Name: "cells nullified", Value: stats.CellsNullified})
+
+	rows := make([][]string, len(cells))
+	for i, c := range cells {
+		rows[i] = []string{strconv.Itoa(c.Row), c.Col, c.OriginalValue, c.Reason}
+	}
+	if err := render.PrintTable(out, []string{"row", "column", "original_value", "reason"}, rows, render.TableOptions{MaxCellWidth: 48}); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	fmt.Fprintf(errOut, "Rows read: %d\n", stats.RowsRead)
+	fmt.Fprintf(errOut, "Cells checked: %d\n", stats.CellsChecked)
+	fmt.Fprintf(errOut, "Cells that would be nullified: %d (showing %d)\n", stats.CellsNullified, len(cells))
+	return 0
+}
+
+// dryRunNullify implements "nullify --dry-run": it runs the complete
+// transformation, discarding the output, and reports how many rows and cells
+// would change. Unlike --preview it lists no cells, and it counts every
+// change, including truncations. outPath, if given, is only named in the
+// summary; nothing is written. Finding changes still exits 0.
+func dryRunNullify(inPath, outPath string, policy nulls.Policy, opts csvio.NullifyOptions, errOut io.Writer) int {
+	in, err := csvio.OpenInputWithOptions(inPath, opts.Options)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	var rowsChanged, cellsChanged int
+	opts.OnRow = func(_ int, original, transformed []string) {
+		changed := 0
+		for i := range transformed {
+			if i >= len(original) || original[i] != transformed[i] {
+				changed++
+			}
+		}
+		if changed > 0 {
+			rowsChanged++
+			cellsChanged += changed
+		}
+	}
+
+	stats, err := csvio.NullifyReader(in, io.Discard, policy, opts)
+	if err != nil {
+		return reportError(errOut, err)
+	}
+	opts.Progress.Done(stats.RowsRead,
+		progress.Counter{Name: "cells checked", Value: stats.CellsChecked},
+		progress.Counter{Name: "cells nullified", Value: stats.CellsNullified})
+
+	fmt.Fprintf(errOut, "Rows read: %d\n", stats.RowsRead)
+	fmt.Fprintf(errOut, "Rows that would change: %d\n", rowsChanged)
+	fmt.Fprintf(errOut, "Total cell changes: %d\n", cellsChanged)
+	if stats.ParseErrors > 0 {
+		fmt.Fprintf(errOut, "Malformed rows skipped: %d\n", stats.ParseErrors)
+	}
+	if outPath != "" {
+		fmt.Fprintf(errOut, "Dry run: %s was not written\n", outPath)
+	}
+	return 0
+}
+
+// nullifyToStdout is NullifyFile for "-o -": it writes to the command's out
+// through openOutput, like every other command's stdout output, so --lf
+// applies and tests can capture it.
+func nullifyToStdout(g *globalOptions, inPath string, policy nulls.Policy, opts csvio.NullifyOptions, out io.Writer) (csvio.NullifyStats, error) {
+	in, err := g.openInput(inPath)
+	if err != nil {
+		return csvio.NullifyStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(csvio.StdioPath, out)
+	if err != nil {
+		return csvio.NullifyStats{}, err
+	}
+	stats, err := csvio.NullifyReader(in, w, policy, opts)
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	return stats, err
+}
+
+// writeStatsJSON writes stats as indented JSON to the file at path, or to
+// errOut when path is empty.
+func writeStatsJSON(path string, stats csvio.NullifyStats, errOut io.Writer) error {
+	b, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	if path == "" {
+		_, err = errOut.Write(b)
+		return err
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("write stats file: %w", err)
+	}
+	return nil
+}
+
+// writeNullReport writes the "nullify --report" CSV: one line per column with
+// its checked and nullified cell counts and null rate, the most-nullified
+// columns first (ties by name).
+func writeNullReport(path string, stats csvio.NullifyStats) error {
+	names := make([]string, 0, len(stats.PerColumn))
+	for name := range stats.PerColumn {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := stats.PerColumn[names[i]], stats.PerColumn[names[j]]
+		if a.CellsNullified != b.CellsNullified {
+			return a.CellsNullified > b.CellsNullified
+		}
+		return names[i] < names[j]
+	})
+
+	rows := make([][]string, len(names))
+	for i, name := range names {
+		c := stats.PerColumn[name]
+		rows[i] = []string{
+			name,
+			strconv.Itoa(c.CellsChecked),
+			strconv.Itoa(c.CellsNullified),
+			strconv.FormatFloat(c.NullRatePct(), 'f', 2, 64),
+		}
+	}
+
+	headers := []string{"column_name", "cells_checked", "cells_nullified", "null_rate_pct"}
+	if err := csvio.WriteRows(path, headers, rows, csvio.Options{}); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
+
+// parseColumnPolicy parses a --col-policy value "COL:RULES", where RULES is a
+// comma-separated list of blanks, na, null-literal, dash and none. The result
+// replaces the global policy for that column, so "zip:" disables nulling in
+// zip entirely.
+func parseColumnPolicy(spec string) (string, nulls.Policy, error) {
+	i := strings.LastIndex(spec, ":")
+	if i <= 0 {
+		return "", nulls.Policy{}, fmt.Errorf("invalid --col-policy %q: want COL:RULES, e.g. phone:dash", spec)
+	}
+	col, rules := spec[:i], spec[i+1:]
+
+	var p nulls.Policy
+	for _, rule := range splitList([]string{rules}) {
+		switch strings.ToLower(rule) {
+		case "blanks":
+			p.TreatBlanks = true
+		case "na":
+			p.TreatNA = true
+		case "null-literal":
+			p.TreatNULLLiteral = true
+		case "dash":
+			p.TreatDash = true
+		case "none":
+			p.TreatNone = true
+		default:
+			return "", nulls.Policy{}, fmt.Errorf("invalid --col-policy %q: unknown rule %q (want blanks, na, null-literal, dash, none)", spec, rule)
+		}
+	}
+	return col, p, nil
+}
+
+// numericColumns guesses which columns hold numbers for --auto-align: those
+// whose value in the first row is non-empty and parses as a float. A single
+// row keeps the guess cheap; a wrong guess only affects alignment.
+func numericColumns(headers []string, rows [][]string) map[string]bool {
+	if len(rows) == 0 {
+		return nil
+	}
+	numeric := make(map[string]bool)
+	for i, h := range headers {
+		if i >= len(rows[0]) {
+			break
+		}
+		v := strings.TrimSpace(rows[0][i])
+		if _, err := strconv.ParseFloat(v, 64); v != "" && err == nil {
+			numeric[h] = true
+		}
+	}
+	return numeric
+}
+
+// parseColumnWidths parses --col-width values of the form "NAME=N" into a
+// map for render.TableOptions.ColumnWidths. It returns nil when specs is
+// empty.
+func parseColumnWidths(specs []string) (map[string]int, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	widths := make(map[string]int, len(specs))
+	for _, spec := range specs {
+		i := strings.LastIndex(spec, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid --col-width %q: want NAME=N, e.g. email=60", spec)
+		}
+		n, err := strconv.Atoi(spec[i+1:])
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid --col-width %q: width must be a positive integer", spec)
+		}
+		widths[spec[:i]] = n
+	}
+	return widths, nil
+}
+
+// reportError prints err to errOut and returns the exit code for it: 2 when
+// the user asked for a column that does not exist (a usage problem), 1 for
+// everything else.
+func reportError(errOut io.Writer, err error) int {
+	fmt.Fprintln(errOut, "error:", err)
+	if errors.Is(err, csvio.ErrColumnNotFound) {
+		return 2
+	}
+	return 1
+}
+
+// openInput is csvio.OpenInput with the global --http-timeout applied to
+// URL inputs.
+func (g *globalOptions) openInput(path string) (io.ReadCloser, error) {
+	return csvio.OpenInputWithOptions(path, csvio.Options{HTTPTimeout: g.httpTimeout})
+}
+
+// openOutput returns the destination for a command's optional -o flag: the
+// named file (gzip-compressed for ".gz"), or out (stdout) when path is empty
+// or "-". With the global --lf flag, carriage returns are stripped on the
+// way. The returned close function must always be called; it reports the
+// file's close error, if any.
+func (g *globalOptions) openOutput(path string, out io.Writer) (io.Writer, func() error, error) {
+	if path == "" || path == csvio.StdioPath {
+		return g.lfOutput(out), func() error { return nil }, nil
+	}
+	f, err := csvio.CreateOutput(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create output csv: %w", err)
+	}
+	return g.lfOutput(f), f.Close, nil
+}
+
+// lfOutput wraps w in a render.LFWriter when --lf was given.
+func (g *globalOptions) lfOutput(w io.Writer) io.Writer {
+	if !g.lf {
+		return w
+	}
+	return render.NewLFWriter(w)
+}
+
+// stringList is a flag.Value for repeatable string flags such as
+// "--col a --col b". Each occurrence appends one value.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// splitList flattens repeatable, comma-separated flag values such as
+// "--cols a,b --cols c" into individual names, dropping empty entries.
+func splitList(vals []string) []string {
+	var out []string
+	for _, v := range vals {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// detectInputEncoding implements --detect-encoding: it sniffs the encoding of
+// path, reports it on errOut, and returns the value to use for
+// csvio.Options.Encoding. An undetectable encoding falls back to UTF-8 with a
+// warning rather than failing.
+func (g *globalOptions) detectInputEncoding(path string, errOut io.Writer) (string, error) {
+	// Sniffing reads the input once before parsing reads it again, which a
+	// pipe does not allow.
+	if path == csvio.StdioPath {
+		return "", errors.New("--detect-encoding cannot be used with stdin")
+	}
+
+	f, err := g.openInput(path)
+	if err != nil {
+		return "", fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	enc, err := csvio.DetectEncodingReader(f)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(errOut, "detected encoding: %s\n", enc)
+	if enc == csvio.EncodingUnknown {
+		fmt.Fprintln(errOut, "warning: could not detect encoding; reading as utf-8")
+		return "", nil
+	}
+	return enc, nil
+}
+
+// detectDelimiter sniffs the delimiter of path with csvio.DetectDialect and
+// reports it to g.log. It returns 0 (the extension-based default) when the
+// input cannot be sniffed: stdin, which would have to be read twice, or a
+// file that cannot be opened, which the command itself reports.
+func (g *globalOptions) detectDelimiter(path string) rune {
+	if path == csvio.StdioPath {
+		fmt.Fprintln(g.log, "warning: --delim auto cannot be used with stdin; using the default delimiter")
+		return 0
+	}
+
+	// OpenInput decompresses .gz input, so sniff a buffered sample rather
+	// than the file itself.
+	f, err := g.openInput(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	buf := make([]byte, 4096)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return 0
+	}
+
+	d, err := csvio.DetectDialect(bytes.NewReader(buf[:n]))
+	if err != nil {
+		return 0
+	}
+	fmt.Fprintf(g.log, "detected delimiter for %s: %q\n", path, d.Delimiter)
+	return d.Delimiter
+}
+
 // reorderFlagsToFront moves a limited set of flags (defined by allowed) in front
 // of positional arguments.
 //
+// The value stored in allowed reports whether the flag takes a value: true for
+// flags like "-n 5", false for boolean flags like "--no-index" that must not
+// swallow the following argument.
+//
 // This exists to support the common CLI expectation that users may place flags
 // after the file argument:
 //
@@ -250,6 +1539,7 @@ func runNullify(args []string, out, errOut io.Writer) int {
 // Supported forms:
 //   - "-n 5" / "-w 20"
 //   - "-n=5" / "-w=20"
+//   - "--no-index" (boolean flags)
 //
 // Unknown flags are treated as positional arguments and left untouched; flag.Parse
 // will error if such flags are actually intended as flags for the command.
@@ -262,18 +1552,22 @@ func reorderFlagsToFront(args []string, allowed map[string]bool) []string {
 		a := args[i]
 
 		// Handle "-n=5" style arguments.
-		if eq := indexByte(a, '='); eq > 0 {
+		if eq := strings.IndexByte(a, '='); eq > 0 {
 			name := a[:eq]
-			if allowed[name] {
+			if _, ok := allowed[name]; ok {
 				flags = append(flags, a)
 				i++
 				continue
 			}
 		}
 
-		// Handle "-n 5" style arguments.
-		if allowed[a] {
+		// Handle "-n 5" and boolean "--no-index" style arguments.
+		if takesValue, ok := allowed[a]; ok {
 			flags = append(flags, a)
+			if !takesValue {
+				i++
+				continue
+			}
 			if i+1 < len(args) {
 				flags = append(flags, args[i+1])
 				i += 2
@@ -292,13 +1586,40 @@ func reorderFlagsToFront(args []string, allowed map[string]bool) []string {
 	return append(flags, positionals...)
 }
 
-// indexByte is a tiny helper to avoid importing strings just for IndexByte.
-// It returns the index of b in s, or -1 if not present.
-func indexByte(s string, b byte) int {
-	for i := 0; i < len(s); i++ {
-		if s[i] == b {
-			return i
+// expandFileList replaces every "@path" argument with the lines of the file
+// at path, one argument per line, so "df concat @files.txt" can take more
+// inputs than the shell allows on a command line. Blank lines and lines
+// starting with "#" are skipped, and surrounding whitespace is trimmed.
+// Paths in the list are used as written, i.e. relative to the working
+// directory rather than to the list file. A bare "@" is left alone, and
+// "@@name" is the literal path "@name".
+//
+// args must be positional file arguments only: the multi-file commands
+// (concat, union, intersect, except and diff) call it after parsing their
+// flags, so flag values such as "--eq @x" are never expanded.
+func expandFileList(args []string) ([]string, error) {
+	var out []string
+	for _, a := range args {
+		if len(a) < 2 || a[0] != '@' {
+			out = append(out, a)
+			continue
+		}
+		if a[1] == '@' {
+			out = append(out, a[1:])
+			continue
+		}
+
+		data, err := os.ReadFile(a[1:])
+		if err != nil {
+			return nil, fmt.Errorf("read file list: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			out = append(out, line)
 		}
 	}
-	return -1
+	return out, nil
 }
@@ -2,8 +2,18 @@ package main
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/bensabler/go-mail/internal/csvio"
 )
 
 const test_mail_data = "../../data/test_mail_data.csv"
@@ -53,6 +63,23 @@ func TestHead_InvalidN(t *testing.T) {
 	}
 }
 
+// writeCSV writes content to a temp file and returns its path.
+func writeCSV(t *testing.T, content string) string {
+	t.Helper()
+	return writeNamed(t, "in.csv", content)
+}
+
+// writeNamed is writeCSV for a fixture that needs a particular file name,
+// e.g. a .tsv extension.
+func writeNamed(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	return path
+}
+
 func nonEmptyLines(s string) []string {
 	raw := strings.Split(s, "\n")
 	out := make([]string, 0, len(raw))
@@ -64,3 +91,1006 @@ func nonEmptyLines(s string) []string {
 	}
 	return out
 }
+
+func TestHead_NoIndex(t *testing.T) {
+	var out, errOut bytes.Buffer
+	path := test_mail_data
+
+	code := run([]string{"df", "head", path, "--no-index", "-n", "3"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if want := 2 + 3; len(lines) != want {
+		t.Fatalf("expected %d output lines, got %d\nOUTPUT:\n%s", want, len(lines), out.String())
+	}
+	if strings.HasPrefix(lines[0], "#") {
+		t.Fatalf("expected no index header; got %q", lines[0])
+	}
+	for _, ln := range lines[2:] {
+		if ln[0] >= '0' && ln[0] <= '9' {
+			t.Fatalf("expected no leading index column; got %q", ln)
+		}
+	}
+}
+
+func TestHead_FormatConfluence(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "head", test_mail_data, "-n", "2", "--format", "confluence"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if want := 1 + 2; len(lines) != want {
+		t.Fatalf("expected %d output lines, got %d\nOUTPUT:\n%s", want, len(lines), out.String())
+	}
+	if !strings.HasPrefix(lines[0], "||") {
+		t.Fatalf("expected Confluence header row; got %q", lines[0])
+	}
+}
+
+func TestCols_Sample(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "cols", test_mail_data, "--sample", "2"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	// header + separator + one line per column (10 in the fixture)
+	lines := nonEmptyLines(out.String())
+	if want := 2 + 10; len(lines) != want {
+		t.Fatalf("expected %d output lines, got %d\nOUTPUT:\n%s", want, len(lines), out.String())
+	}
+	if !strings.Contains(out.String(), "Ben | Alice") {
+		t.Fatalf("expected first_name samples; got\n%s", out.String())
+	}
+}
+
+func TestHead_Summary(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "head", test_mail_data, "-n", "3", "--summary"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	// header + separator + 3 rows + separator + footer
+	lines := nonEmptyLines(out.String())
+	if want := 2 + 3 + 2; len(lines) != want {
+		t.Fatalf("expected %d output lines, got %d\nOUTPUT:\n%s", want, len(lines), out.String())
+	}
+	footer := lines[len(lines)-1]
+	if !strings.Contains(footer, "3/3") || !strings.Contains(footer, "mean=") {
+		t.Fatalf("expected summary values in footer; got %q", footer)
+	}
+}
+
+func TestHead_DetectEncoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "latin1.csv")
+	if err := os.WriteFile(path, []byte("name\nJos\xe9\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "head", path, "--detect-encoding", "--format", "csv"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if !strings.Contains(errOut.String(), "detected encoding: latin1") {
+		t.Fatalf("expected detected encoding on stderr; got %q", errOut.String())
+	}
+	if out.String() != "name\nJosé\n" {
+		t.Fatalf("expected transcoded output; got %q", out.String())
+	}
+}
+
+func TestCols_Diff(t *testing.T) {
+	a := writeCSV(t, "id,fax,name,email\n1,x,Ann,a@x\n")
+	b := writeCSV(t, "email,id,name,mobile\na@x,1,Ann,555\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "cols", a, "--diff", b}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	want := "Removed (only in " + a + ") (1):\n  fax\n" +
+		"Added (only in " + b + ") (1):\n  mobile\n" +
+		"Common (3):\n  id\n  name\n  email\n" +
+		"Moved (1):\n  email: 3 -> 0\n"
+	if out.String() != want {
+		t.Fatalf("output =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestHelp_Command(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "help", "cols"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), "df cols") || !strings.Contains(out.String(), "--sample") {
+		t.Fatalf("expected cols usage with flags; got\n%s", out.String())
+	}
+}
+
+func TestHelp_UnknownCommand(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "help", "nope"}, &out, &errOut)
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), `unknown command: "nope"`) {
+		t.Fatalf("expected unknown command message; stderr=%s", errOut.String())
+	}
+}
+
+func TestHelp_ListsCommands(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	if code := run([]string{"df", "help"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	for _, c := range commands {
+		if !strings.Contains(out.String(), c.Name) || !strings.Contains(out.String(), c.Summary) {
+			t.Fatalf("usage missing %q; got\n%s", c.Name, out.String())
+		}
+	}
+}
+
+// withStdin replaces os.Stdin with a pipe carrying content for the duration
+// of the test.
+func withStdin(t *testing.T, content string) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	go func() {
+		_, _ = w.WriteString(content)
+		w.Close()
+	}()
+
+	orig := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = orig
+		r.Close()
+	})
+}
+
+func TestHead_Stdin(t *testing.T) {
+	withStdin(t, "name,zip\nAnn,12207\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "head", "-", "--format", "csv"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name,zip\nAnn,12207\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestNullify_StdinToStdout(t *testing.T) {
+	withStdin(t, "name,email\nAnn,NA\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "nullify", "-", "-o", "-", "--na"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name,email\nAnn,\n"; out.String() != want {
+		t.Fatalf("stdout = %q, want %q", out.String(), want)
+	}
+}
+
+func TestGlobalDelim(t *testing.T) {
+	in := writeCSV(t, "name|zip\nAnn|12207\n")
+
+	tests := []struct {
+		name string
+		argv []string
+	}{
+		{name: "delim", argv: []string{"df", "--delim", "|", "cols", in}},
+		{name: "sep alias", argv: []string{"df", "--sep=|", "cols", in}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			if code := run(tt.argv, &out, &errOut); code != 0 {
+				t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+			}
+			if want := "0\tname\n1\tzip\n"; out.String() != want {
+				t.Fatalf("output = %q, want %q", out.String(), want)
+			}
+		})
+	}
+}
+
+func TestGlobalDelim_Auto(t *testing.T) {
+	in := writeCSV(t, "name;price\nAnn;1,50\nBob;2,75\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "--delim", "auto", "select", in, "--col", "price"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	// Output keeps the detected delimiter, as with an explicit --delim.
+	if want := "price\n1,50\n2,75\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if want := `detected delimiter for ` + in + `: ';'`; !strings.Contains(errOut.String(), want) {
+		t.Fatalf("stderr = %q, want %q", errOut.String(), want)
+	}
+}
+
+func TestGlobalDelim_TSVDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "in.tsv")
+	if err := os.WriteFile(path, []byte("name\tzip\nAnn\t12207\n"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "select", path, "--col", "zip", "--col", "name"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "zip\tname\n12207\tAnn\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestGlobalDelim_Invalid(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	if code := run([]string{"df", "--delim", "ab", "cols", test_mail_data}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
+
+func TestGlobalCommentAndSkipLines(t *testing.T) {
+	in := writeCSV(t, "Contacts export\n# Generated by ExportTool v2.3\nname,zip\nAnn,12207\n# end of page\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "--skip-lines", "1", "--comment-char", "#", "select", in, "--col", "zip"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "zip\n12207\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestGlobalCommentChar_Invalid(t *testing.T) {
+	for _, c := range []string{"##", ",", `"`} {
+		var out, errOut bytes.Buffer
+		if code := run([]string{"df", "--comment-char", c, "cols", test_mail_data}, &out, &errOut); code != 2 {
+			t.Fatalf("--comment-char %q: expected exit code 2, got %d", c, code)
+		}
+	}
+}
+
+func TestGlobalNoHeader(t *testing.T) {
+	in := writeCSV(t, "ann@x.com,Ann,12207\nbob@x.com,Bob,12180\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "--no-header", "cols", in}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "0\tcol_0\n1\tcol_1\n2\tcol_2\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if !strings.Contains(errOut.String(), "(no header)") {
+		t.Fatalf("stderr = %q, want (no header) notice", errOut.String())
+	}
+
+	out.Reset()
+	if code := run([]string{"df", "--no-header", "select", in, "--col", "col_1"}, &out, &errOut); code != 0 {
+		t.Fatalf("select: expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "col_1\nAnn\nBob\n"; out.String() != want {
+		t.Fatalf("select output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestGlobalEncoding(t *testing.T) {
+	// "name\nJosé\n" in Windows-1252.
+	in := writeCSV(t, string([]byte{'n', 'a', 'm', 'e', '\n', 'J', 'o', 's', 0xE9, '\n'}))
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "--encoding", "windows-1252", "select", in, "--col", "name"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if !utf8.Valid(out.Bytes()) {
+		t.Fatalf("output is not valid UTF-8: %q", out.String())
+	}
+	if want := "name\nJosé\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+
+	if code := run([]string{"df", "--encoding", "ebcdic", "cols", in}, &out, &errOut); code != 2 {
+		t.Fatalf("unsupported encoding: expected exit code 2, got %d", code)
+	}
+}
+
+func TestGlobalQuoteChar(t *testing.T) {
+	in := writeCSV(t, "name,city\n'O''Brien, Pat',Albany\nO'Neil,'Troy, NY'\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "--quote-char", "'", "select", in, "--col", "name"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name\n\"O'Brien, Pat\"\nO'Neil\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+
+	if code := run([]string{"df", "--quote-char", ",", "cols", in}, &out, &errOut); code != 2 {
+		t.Fatalf("--quote-char same as delimiter: expected exit code 2, got %d", code)
+	}
+}
+
+func TestGlobalLazyQuotes(t *testing.T) {
+	in := writeCSV(t, "name,size\nTV,5\" screen\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "select", in, "--col", "size"}, &out, &errOut); code != 1 {
+		t.Fatalf("without --lazy-quotes: expected exit code 1, got %d", code)
+	}
+
+	out.Reset()
+	if code := run([]string{"df", "--lazy-quotes", "select", in, "--col", "size"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "size\n\"5\"\" screen\"\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestGlobalLF(t *testing.T) {
+	// A lone CR inside a quoted value survives a plain read/write round trip.
+	in := writeCSV(t, "id,note\n1,\"a\rb\"\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "select", in, "--col", "note"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), "\r") {
+		t.Fatalf("without --lf: output = %q, want a \\r", out.String())
+	}
+
+	out.Reset()
+	if code := run([]string{"df", "--lf", "select", in, "--col", "note"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "note\n\"ab\"\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestVersionFlag(t *testing.T) {
+	for _, flag := range []string{"--version", "-v"} {
+		t.Run(flag, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			if code := run([]string{"df", flag}, &out, &errOut); code != 0 {
+				t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+			}
+			// Test binaries carry no module version.
+			if want := "df " + devVersion + "\n"; out.String() != want {
+				t.Fatalf("output = %q, want %q", out.String(), want)
+			}
+		})
+	}
+}
+
+func TestVerboseProgress(t *testing.T) {
+	in := writeCSV(t, "name,email\nAnn,\nBob,b@x\nCy,NA\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	tests := []struct {
+		name string
+		argv []string
+		want []string
+	}{
+		{
+			name: "nullify",
+			argv: []string{"df", "--verbose", "--progress-every", "2", "nullify", in, "-o", out},
+			want: []string{"progress: 2 rows, 2 cells checked, 0 cells nullified", "progress: 3 rows, 6 cells checked, 0 cells nullified"},
+		},
+		{
+			name: "filter",
+			argv: []string{"df", "--verbose", "--progress-every=2", "filter", in, "--col", "name", "--eq", "Bob"},
+			want: []string{"progress: 2 rows (", "progress: 3 rows ("},
+		},
+		{
+			name: "concat",
+			argv: []string{"df", "--verbose", "--progress-every", "4", "concat", in, in},
+			want: []string{"progress: 4 rows (", "progress: 6 rows ("},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.argv, &stdout, &stderr); code != 0 {
+				t.Fatalf("expected exit code 0, got %d; stderr=%s", code, stderr.String())
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(stderr.String(), w) {
+					t.Fatalf("stderr missing %q:\n%s", w, stderr.String())
+				}
+			}
+		})
+	}
+}
+
+func TestVerboseProgress_OffByDefault(t *testing.T) {
+	in := writeCSV(t, "name\nAnn\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"df", "--progress-every", "1", "filter", in, "--col", "name", "--eq", "Ann"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, stderr.String())
+	}
+	if strings.Contains(stderr.String(), "progress:") {
+		t.Fatalf("unexpected progress without --verbose:\n%s", stderr.String())
+	}
+}
+
+func TestVerboseProgress_InvalidEvery(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "--verbose", "--progress-every", "0", "cols", test_mail_data}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
+
+func TestGzipTSVRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.tsv.gz")
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte("name\tzip\nAnn\t12207\n"))
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	if err := os.WriteFile(in, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	out := filepath.Join(dir, "out.tsv.gz")
+	var stdout, errOut bytes.Buffer
+	if code := run([]string{"df", "select", in, "--col", "zip", "-o", out}, &stdout, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("open output: %v", err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("output is not gzip: %v", err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("gunzip: %v", err)
+	}
+	if want := "zip\n12207\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullify_Sentinel(t *testing.T) {
+	in := writeCSV(t, "name,status\nAnn,TBD\nBob,unknown\nCy,active\n")
+	outPath := filepath.Join(t.TempDir(), "out.csv")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "nullify", in, "-o", outPath, "--sentinel", "TBD", "--sentinel", "UNKNOWN"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "name,status\nAnn,\nBob,\nCy,active\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullify_Explain(t *testing.T) {
+	in := writeCSV(t, "name,email\nAnn,NA\nBob,bob@example.com\n")
+	outPath := filepath.Join(t.TempDir(), "out.csv")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "nullify", in, "-o", outPath, "--na", "--explain"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got, err := os.ReadFile(outPath + ".explain.csv")
+	if err != nil {
+		t.Fatalf("read explain sidecar: %v", err)
+	}
+	if want := "row,col,original_value,reason\n1,email,NA,na\n"; string(got) != want {
+		t.Fatalf("explain = %q, want %q", got, want)
+	}
+}
+
+func TestNullify_ExplainRequiresOutputFile(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "nullify", test_mail_data, "-o", "-", "--explain"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
+
+func TestNullify_Dash(t *testing.T) {
+	in := writeCSV(t, "name,phone,balance\nAnn,-,-5\nBob,--,10\n")
+	outPath := filepath.Join(t.TempDir(), "out.csv")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "nullify", in, "-o", outPath, "--dash"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "name,phone,balance\nAnn,,-5\nBob,,10\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullify_NoAtomic(t *testing.T) {
+	in := writeCSV(t, "name\nAnn\n")
+	outPath := filepath.Join(t.TempDir(), "out.csv")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "nullify", in, "-o", outPath, "--no-atomic"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "name\nAnn\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullify_Preview(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.csv")
+	if err := os.WriteFile(in, []byte("name,email\nAnn,NA\nBob,N/A\nCy,c@x\n"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "nullify", in, "--na", "--preview", "--preview-limit", "1"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if len(lines) != 3 || !strings.Contains(lines[2], "1") || !strings.Contains(lines[2], "NA") {
+		t.Fatalf("unexpected preview table:\n%s", out.String())
+	}
+	if !strings.Contains(errOut.String(), "Cells that would be nullified: 2 (showing 1)") {
+		t.Fatalf("unexpected summary: %s", errOut.String())
+	}
+
+	// Nothing but the input is written.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("preview wrote files: %v", entries)
+	}
+}
+
+func TestNullify_Report(t *testing.T) {
+	dir := t.TempDir()
+	report := filepath.Join(dir, "report.csv")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "nullify", test_mail_data, "-o", filepath.Join(dir, "out.csv"), "--na", "--null-literal", "--report", report}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got, err := os.ReadFile(report)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	lines := nonEmptyLines(string(got))
+	if len(lines) != 11 {
+		t.Fatalf("expected header + 10 columns, got:\n%s", got)
+	}
+	if lines[0] != "column_name,cells_checked,cells_nullified,null_rate_pct" || lines[1] != "address2,10,3,30.00" {
+		t.Fatalf("unexpected report:\n%s", got)
+	}
+}
+
+func TestNullify_None(t *testing.T) {
+	in := writeCSV(t, "name,email\nAnn,None\nBob,NULL\n")
+	outPath := filepath.Join(t.TempDir(), "out.csv")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "nullify", in, "-o", outPath, "--none"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "name,email\nAnn,\nBob,NULL\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullify_MaxErrors(t *testing.T) {
+	in := writeCSV(t, "name,note\nAnn,NULL\nBob,5\" screen\nCy,NULL\n")
+	outPath := filepath.Join(t.TempDir(), "out.csv")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "nullify", in, "-o", outPath, "--null-literal"}, &out, &errOut); code != 1 {
+		t.Fatalf("default: expected exit code 1, got %d", code)
+	}
+
+	errOut.Reset()
+	if code := run([]string{"df", "nullify", in, "-o", outPath, "--null-literal", "--max-errors", "0"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "name,note\nAnn,\nCy,\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+	for _, want := range []string{"warning: skipped malformed row:", "Malformed rows skipped: 1"} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Fatalf("stderr = %q, want %q", errOut.String(), want)
+		}
+	}
+}
+
+func TestNullify_DryRun(t *testing.T) {
+	in := writeCSV(t, "name,email,phone\nAnn,NA,\nBob,bob@x.com,555\nCy,N/A,NULL\n")
+	outPath := filepath.Join(t.TempDir(), "out.csv")
+	if err := os.WriteFile(outPath, []byte("keep me\n"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "nullify", in, "-o", outPath, "--na", "--null-literal", "--dry-run"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	for _, want := range []string{"Rows read: 3\n", "Rows that would change: 2\n", "Total cell changes: 3\n"} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Fatalf("stderr = %q, want %q", errOut.String(), want)
+		}
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(got) != "keep me\n" {
+		t.Fatalf("output file was modified: %q", got)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("stdout = %q, want empty", out.String())
+	}
+}
+
+func TestNullify_StatsJSON(t *testing.T) {
+	in := writeCSV(t, "name,email\nAnn,NA\nBob,\n")
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "out.csv")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "nullify", in, "-o", outPath, "--na", "--stats-format", "json"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	var stats csvio.NullifyStats
+	if err := json.Unmarshal(errOut.Bytes(), &stats); err != nil {
+		t.Fatalf("stderr is not a JSON document: %v\n%s", err, errOut.String())
+	}
+	if stats.RowsRead != 2 || stats.CellsNullified != 1 || stats.PerColumn["email"].CellsNullified != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	statsPath := filepath.Join(dir, "stats.json")
+	errOut.Reset()
+	if code := run([]string{"df", "nullify", in, "-o", outPath, "--na", "--stats-format", "json", "--stats-file", statsPath}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if errOut.Len() != 0 {
+		t.Fatalf("stderr = %q, want empty", errOut.String())
+	}
+	b, err := os.ReadFile(statsPath)
+	if err != nil {
+		t.Fatalf("read stats file: %v", err)
+	}
+	if !strings.Contains(string(b), `"cells_nullified": 1`) {
+		t.Fatalf("stats file = %s", b)
+	}
+
+	if code := run([]string{"df", "nullify", in, "-o", outPath, "--stats-format", "xml"}, &out, &errOut); code != 2 {
+		t.Fatalf("bad format: expected exit code 2, got %d", code)
+	}
+
+	for _, mode := range []string{"--preview", "--dry-run"} {
+		if code := run([]string{"df", "nullify", in, mode, "--stats-format", "json", "--stats-file", statsPath}, &out, &errOut); code != 2 {
+			t.Fatalf("%s: expected exit code 2, got %d", mode, code)
+		}
+	}
+}
+
+func TestNullify_ColumnPolicy(t *testing.T) {
+	in := writeCSV(t, "phone,email,zip\n-,N/A,\n555,-, \n")
+	outPath := filepath.Join(t.TempDir(), "out.csv")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "nullify", in, "-o", outPath,
+		"--col-policy", "phone:dash", "--col-policy", "email:na,blanks", "--col-policy", "zip:"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	got, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "phone,email,zip\n,,\n555,-,\" \"\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullify_ColumnPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{name: "missing colon", spec: "phone"},
+		{name: "unknown rule", spec: "phone:dashes"},
+		{name: "unknown column", spec: "nope:na"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outPath := filepath.Join(t.TempDir(), "out.csv")
+			var out, errOut bytes.Buffer
+			code := run([]string{"df", "nullify", test_mail_data, "-o", outPath, "--col-policy", tt.spec}, &out, &errOut)
+			if code != 2 {
+				t.Fatalf("expected exit code 2, got %d; stderr=%s", code, errOut.String())
+			}
+		})
+	}
+}
+
+func TestNullify_PolicyFile(t *testing.T) {
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "nullpolicy.json")
+	policy := `{"treat_na": true, "treat_dash": true, "custom_sentinels": ["TBD"]}`
+	if err := os.WriteFile(policyPath, []byte(policy), 0o644); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	in := writeCSV(t, "a,b,c,d,e\nNA,-,TBD,UNKNOWN, \n")
+
+	tests := []struct {
+		name  string
+		flags []string
+		want  string
+	}{
+		// Blanks stays off: the file leaves it unset and --blanks is not given.
+		{name: "file only", want: "a,b,c,d,e\n,,,UNKNOWN,\" \"\n"},
+		{name: "flags override", flags: []string{"--dash=false", "--blanks", "--sentinel", "UNKNOWN"}, want: "a,b,c,d,e\n,-,,,\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outPath := filepath.Join(t.TempDir(), "out.csv")
+			argv := append([]string{"df", "nullify", in, "-o", outPath, "--policy-file", policyPath}, tt.flags...)
+
+			var out, errOut bytes.Buffer
+			if code := run(argv, &out, &errOut); code != 0 {
+				t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+			}
+			got, err := os.ReadFile(outPath)
+			if err != nil {
+				t.Fatalf("read output: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// closedPipe fails every write, like stdout after the reader has exited.
+type closedPipe struct{}
+
+func (closedPipe) Write([]byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestHead_WriteErrorExitsOne(t *testing.T) {
+	var errOut bytes.Buffer
+	if code := run([]string{"df", "head", test_mail_data}, closedPipe{}, &errOut); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "closed pipe") {
+		t.Fatalf("stderr = %q, want write error", errOut.String())
+	}
+}
+
+func TestHead_FormatTSV(t *testing.T) {
+	in := writeCSV(t, "name,note\nAnn,\"a\tb\"\nBob,plain\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "head", in, "--format", "tsv"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name\tnote\nAnn\t\"a\tb\"\nBob\tplain\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestHead_Offset(t *testing.T) {
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "head", test_mail_data, "--offset", "2", "-n", "3", "--format", "csv"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if len(lines) != 4 {
+		t.Fatalf("expected header + 3 rows, got:\n%s", out.String())
+	}
+	for i, prefix := range []string{"first_name,", "Bob,Smith,", ",McMahon,", "Laura,,"} {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Fatalf("line %d = %q, want prefix %q", i, lines[i], prefix)
+		}
+	}
+}
+
+func TestHead_OffsetRowIndex(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "head", test_mail_data, "--offset=2", "-n", "1", "-w", "8"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if len(lines) != 3 || !strings.HasPrefix(lines[2], "2      Bob") {
+		t.Fatalf("expected row index 2 for Bob; got:\n%s", out.String())
+	}
+}
+
+func TestHead_NegativeOffset(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "head", test_mail_data, "--offset", "-1"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
+
+func TestHead_FormatVertical(t *testing.T) {
+	in := writeCSV(t, "name,zip\nAnn,12207\nBob,12180\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "head", in, "--format", "vertical", "--no-index", "--record-separator", "--"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name: Ann\nzip : 12207\n--\nname: Bob\nzip : 12180\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestHead_WidthOverride(t *testing.T) {
+	in := writeCSV(t, "note\n"+strings.Repeat("x", 40)+"\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "head", in, "--no-index", "--width", "12"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := strings.Repeat("x", 11) + "…"; !strings.Contains(out.String(), want+"\n") {
+		t.Fatalf("expected cell clipped to 12 runes, got\n%s", out.String())
+	}
+}
+
+func TestHead_FormatBox(t *testing.T) {
+	in := writeCSV(t, "name\nAnn\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "head", in, "--format", "box", "--no-index"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "┌──────┐\n│ name │\n├──────┤\n│ Ann  │\n└──────┘\n"; out.String() != want {
+		t.Fatalf("output =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestHead_ColumnWidth(t *testing.T) {
+	in := writeCSV(t, "email,zip\nann@example.com,12207\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "head", in, "--no-index", "--col-width", "email=6"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	lines := nonEmptyLines(out.String())
+	if len(lines) != 3 || lines[2] != "ann@e…  12207" {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestHead_ColumnWidthInvalid(t *testing.T) {
+	for _, spec := range []string{"email", "email=", "email=0", "=5"} {
+		var out, errOut bytes.Buffer
+		if code := run([]string{"df", "head", test_mail_data, "--col-width", spec}, &out, &errOut); code != 2 {
+			t.Fatalf("%q: expected exit code 2, got %d", spec, code)
+		}
+	}
+}
+
+func TestHead_AutoAlign(t *testing.T) {
+	in := writeCSV(t, "name,amount,zip\nAnn,5.25,12207\nBob,1200,NY\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "head", in, "--no-index", "--auto-align"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	want := "" +
+		"name  amount    zip\n" +
+		"----  ------  -----\n" +
+		"Ann     5.25  12207\n" +
+		"Bob     1200     NY\n"
+	if out.String() != want {
+		t.Fatalf("output =\n%q\nwant\n%q", out.String(), want)
+	}
+}
+
+func TestHead_Strict(t *testing.T) {
+	path := writeCSV(t, "a,b\n1,2\n3\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "head", path, "--strict"}, &out, &errOut); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if want := "error: row 2: expected 2 fields, got 1"; !strings.Contains(errOut.String(), want) {
+		t.Fatalf("stderr = %q, want %q", errOut.String(), want)
+	}
+}
+
+func TestRun_HTTPInput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "name,email\nAnn,ann@example.com\n")
+	}))
+	defer srv.Close()
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "--http-timeout", "5s", "select", srv.URL + "/contacts.csv", "--col", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "email\nann@example.com\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestRun_HTTPTimeoutInvalid(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "--http-timeout", "0s", "cols", "x.csv"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
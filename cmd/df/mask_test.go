@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMask_ShowLast(t *testing.T) {
+	in := writeCSV(t, "name,phone\nZoë,5551234\nBob,12\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "mask", in, "--col", "name,phone", "--show-last", "2", "--mask-char", "•"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name,phone\n•oë,•••••34\n•ob,12\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestMask_BadMaskChar(t *testing.T) {
+	in := writeCSV(t, "a\n1\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "mask", in, "--col", "a", "--mask-char", "**"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
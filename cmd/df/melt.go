@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runMelt implements the "melt" subcommand.
+//
+// Output goes to -o, or stdout when -o is omitted; the summary goes to
+// stderr.
+func runMelt(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":        true,
+		"-id-cols":  true,
+		"--id-cols": true,
+	})
+
+	fs := g.newFlagSet("melt", errOut, meltUsage)
+
+	var idCols stringList
+	fs.Var(&idCols, "id-cols", "Comma-separated identifier columns (repeatable)")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "melt requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	stats, err := csvio.MeltReader(in, w, csvio.MeltOptions{Options: g.inputOptions(fs.Arg(0)), IDCols: splitList(idCols)})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	fmt.Fprintf(errOut, "Rows read: %d\n", stats.InputRows)
+	fmt.Fprintf(errOut, "Columns melted: %d\n", stats.VariableColumns)
+	fmt.Fprintf(errOut, "Rows written: %d\n", stats.OutputRows)
+
+	return 0
+}
+
+// meltUsage prints help for the "melt" subcommand.
+func meltUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df melt <file.csv> --id-cols LIST [flags]
+
+Unpivot a wide table into long format, the inverse of "df pivot". Each row
+becomes one row per non-identifier column, with the header
+<id-cols...>,variable,value.
+
+Flags:
+  --id-cols LIST       Comma-separated identifier columns kept on every
+                       row (repeatable; default none)
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df melt monthly.csv --id-cols region
+  df melt scores.csv --id-cols id --id-cols name -o long.csv
+`)
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMelt_IDCols(t *testing.T) {
+	in := writeCSV(t, "region,jan,feb\neast,1,2\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "melt", in, "--id-cols", "region"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "region,variable,value\neast,jan,1\neast,feb,2\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runPivot implements the "pivot" subcommand.
+//
+// Output goes to -o, or stdout when -o is omitted; the summary goes to
+// stderr.
+func runPivot(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":    true,
+		"-row":  true,
+		"--row": true,
+		"-col":  true,
+		"--col": true,
+		"-val":  true,
+		"--val": true,
+		"-agg":  true,
+		"--agg": true,
+	})
+
+	fs := g.newFlagSet("pivot", errOut, pivotUsage)
+
+	row := fs.String("row", "", "Column whose values become output rows")
+	col := fs.String("col", "", "Column whose values become output columns")
+	val := fs.String("val", "", "Column to aggregate (required for sum and mean)")
+	aggName := fs.String("agg", "count", "Aggregation: count, sum, or mean")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "pivot requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if *row == "" || *col == "" {
+		fmt.Fprintln(errOut, "pivot requires --row <name> and --col <name>")
+		return 2
+	}
+
+	agg, err := csvio.ParsePivotAgg(*aggName)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 2
+	}
+	if agg != csvio.PivotCount && *val == "" {
+		fmt.Fprintf(errOut, "pivot --agg %s requires --val <name>\n", agg)
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	stats, err := csvio.PivotReader(in, w, csvio.PivotOptions{
+		Options: g.inputOptions(fs.Arg(0)),
+		Row:     *row,
+		Col:     *col,
+		Val:     *val,
+		Agg:     agg,
+	})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	fmt.Fprintf(errOut, "Rows read: %d\n", stats.RowsRead)
+	fmt.Fprintf(errOut, "Pivot size: %d rows x %d columns\n", stats.RowKeys, stats.ColKeys)
+	if agg != csvio.PivotCount {
+		fmt.Fprintf(errOut, "Parse errors: %d\n", stats.ParseErrors)
+	}
+
+	return 0
+}
+
+// pivotUsage prints help for the "pivot" subcommand.
+func pivotUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df pivot <file.csv> --row COL --col COL [flags]
+
+Cross-tabulate two columns. Each distinct --row value becomes an output row
+and each distinct --col value an output column, in order of first
+appearance. Cells aggregate the rows that share both values; a cell with no
+rows is left empty.
+
+For sum and mean, non-numeric --val cells are skipped and counted as parse
+errors in the summary; blank cells are skipped silently.
+
+Flags:
+  --row NAME           Column whose values become output rows
+  --col NAME           Column whose values become output columns
+  --val NAME           Column to aggregate (required for sum and mean)
+  --agg NAME           count, sum, or mean (default count)
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df pivot sales.csv --row region --col month
+  df pivot sales.csv --row region --col month --val amount --agg sum -o pivot.csv
+`)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPivot_Sum(t *testing.T) {
+	in := writeCSV(t, "region,month,amount\neast,jan,10\nwest,feb,x\neast,jan,2.5\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "pivot", in, "--row", "region", "--col", "month", "--val", "amount", "--agg", "sum"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "region,jan,feb\neast,12.5,\nwest,,\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if !strings.Contains(errOut.String(), "Parse errors: 1") {
+		t.Fatalf("stderr = %q", errOut.String())
+	}
+}
+
+func TestPivot_SumRequiresVal(t *testing.T) {
+	in := writeCSV(t, "a,b\n1,2\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "pivot", in, "--row", "a", "--col", "b", "--agg", "sum"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runRename implements the "rename" subcommand.
+//
+// Each --from is paired with the --to at the same position. Only the header
+// row changes. Output goes to -o, or stdout when -o is omitted.
+func runRename(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":     true,
+		"-from":  true,
+		"--from": true,
+		"-to":    true,
+		"--to":   true,
+	})
+
+	fs := g.newFlagSet("rename", errOut, renameUsage)
+
+	var from, to stringList
+	fs.Var(&from, "from", "Existing column name (repeatable)")
+	fs.Var(&to, "to", "New name for the matching --from (repeatable)")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "rename requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if len(from) == 0 || len(from) != len(to) {
+		fmt.Fprintln(errOut, "rename requires matching --from <old> --to <new> pairs")
+		return 2
+	}
+
+	// Keys are folded so "Email" and "email" count as the same --from, which
+	// is how the column lookup treats them too.
+	mapping := make(map[string]string, len(from))
+	for i, f := range from {
+		key := strings.ToLower(f)
+		if _, dup := mapping[key]; dup {
+			fmt.Fprintf(errOut, "warning: --from %q given more than once; using --to %q\n", f, to[i])
+		}
+		mapping[key] = to[i]
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	err = csvio.RenameReader(in, w, mapping, g.inputOptions(fs.Arg(0)))
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	return 0
+}
+
+// renameUsage prints help for the "rename" subcommand.
+func renameUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df rename <file.csv> --from <old> --to <new> [--from <old> --to <new> ...] [-o out.csv]
+
+Rename header columns. Data rows are copied unchanged. If the same --from is
+given twice, the later --to wins.
+
+Flags:
+  --from NAME          Existing column name (case-insensitive)
+  --to NAME            New name, written verbatim
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df rename input.csv --from "First Name" --to first_name
+  df rename input.csv --from zip --to postal_code -o renamed.csv
+`)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRename_DuplicateFromLastWins(t *testing.T) {
+	in := writeCSV(t, "name,zip\nAnn,12207\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "rename", in, "--from", "zip", "--to", "postal", "--from", "ZIP", "--to", "postal_code"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name,postal_code\nAnn,12207\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if !strings.Contains(errOut.String(), "warning:") {
+		t.Fatalf("expected duplicate warning; got %q", errOut.String())
+	}
+}
+
+func TestRename_UnknownColumn(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "rename", test_mail_data, "--from", "nope", "--to", "x"}, &out, &errOut)
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
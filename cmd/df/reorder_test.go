@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReorder_First(t *testing.T) {
+	in := writeCSV(t, "a,b,email,id\n1,2,x@y.z,9\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "reorder", in, "--first", "id", "--first", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "id,email,a,b\n9,x@y.z,1,2\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestReorder_UnknownColumn(t *testing.T) {
+	in := writeCSV(t, "a\n1\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "reorder", in, "--first", "nope"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runRepair implements the "repair" subcommand.
+//
+// Each repair is opt-in through its own flag, or all of them with --all.
+// Output goes to -o, or stdout when -o is omitted; the summary goes to
+// stderr.
+func runRepair(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":                true,
+		"-all":              false,
+		"--all":             false,
+		"-trim-headers":     false,
+		"--trim-headers":    false,
+		"-dedupe-headers":   false,
+		"--dedupe-headers":  false,
+		"-drop-empty-rows":  false,
+		"--drop-empty-rows": false,
+		"-normalize":        false,
+		"--normalize":       false,
+	})
+
+	fs := g.newFlagSet("repair", errOut, repairUsage)
+
+	all := fs.Bool("all", false, "Apply every repair")
+	trimHeaders := fs.Bool("trim-headers", false, "Strip whitespace around header names")
+	dedupeHeaders := fs.Bool("dedupe-headers", false, "Rename duplicate headers with _2, _3, ... suffixes")
+	dropEmpty := fs.Bool("drop-empty-rows", false, "Remove rows whose cells are all blank")
+	normalize := fs.Bool("normalize", false, "Pad or truncate rows to the header width")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "repair requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	opts := csvio.RepairOptions{
+		Options:        g.inputOptions(fs.Arg(0)),
+		TrimHeaders:    *all || *trimHeaders,
+		DedupeHeaders:  *all || *dedupeHeaders,
+		DropEmptyRows:  *all || *dropEmpty,
+		NormalizeWidth: *all || *normalize,
+	}
+	if !opts.TrimHeaders && !opts.DedupeHeaders && !opts.DropEmptyRows && !opts.NormalizeWidth {
+		fmt.Fprintln(errOut, "repair requires at least one repair flag (or --all)")
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	stats, err := csvio.RepairReader(in, w, opts)
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	fmt.Fprintf(errOut, "Rows read: %d\n", stats.RowsRead)
+	fmt.Fprintf(errOut, "Rows written: %d\n", stats.RowsWritten)
+	if opts.TrimHeaders {
+		fmt.Fprintf(errOut, "Headers trimmed: %d\n", stats.HeadersTrimmed)
+	}
+	if opts.DedupeHeaders {
+		fmt.Fprintf(errOut, "Headers renamed: %d\n", stats.HeadersRenamed)
+	}
+	if opts.DropEmptyRows {
+		fmt.Fprintf(errOut, "Empty rows dropped: %d\n", stats.EmptyRowsDropped)
+	}
+	if opts.NormalizeWidth {
+		fmt.Fprintf(errOut, "Rows padded: %d\n", stats.RowsPadded)
+		fmt.Fprintf(errOut, "Rows truncated: %d\n", stats.RowsTruncated)
+	}
+
+	return 0
+}
+
+// repairUsage prints help for the "repair" subcommand.
+func repairUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df repair <file.csv> [flags]
+
+Fix common structural problems, such as those reported by "df check". Each
+repair is opt-in; select at least one, or --all.
+
+Flags:
+  --all                Apply every repair below
+  --trim-headers       Strip whitespace around header names
+  --dedupe-headers     Rename duplicate headers (case-insensitive) by
+                       appending _2, _3, ...
+  --drop-empty-rows    Remove rows whose cells are all blank
+  --normalize          Pad short rows and truncate long rows to the header
+                       width
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df repair input.csv --all -o fixed.csv
+  df repair input.csv --dedupe-headers --normalize
+`)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRepair_All(t *testing.T) {
+	in := writeCSV(t, " id ,name,Name\n1,Ann\n,,\n2,Bob,x,extra\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "repair", in, "--all"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "id,name,Name_2\n1,Ann,\n2,Bob,x\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	for _, want := range []string{"Rows read: 3", "Rows written: 2", "Empty rows dropped: 1", "Rows truncated: 1"} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Fatalf("summary missing %q:\n%s", want, errOut.String())
+		}
+	}
+}
+
+func TestRepair_RequiresARepair(t *testing.T) {
+	in := writeCSV(t, "a\n1\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "repair", in}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "at least one repair flag") {
+		t.Fatalf("stderr = %q", errOut.String())
+	}
+}
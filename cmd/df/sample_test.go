@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSample_Reproducible(t *testing.T) {
+	var first, second, errOut bytes.Buffer
+
+	if code := run([]string{"df", "sample", test_mail_data, "--n", "3", "--seed", "9"}, &first, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if code := run([]string{"df", "sample", test_mail_data, "--n", "3", "--seed", "9"}, &second, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	if lines := nonEmptyLines(first.String()); len(lines) != 4 {
+		t.Fatalf("expected header plus 3 rows, got %d lines", len(lines))
+	}
+	if first.String() != second.String() {
+		t.Fatalf("same seed produced different output")
+	}
+}
+
+func TestSample_RequiresOneMode(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	if code := run([]string{"df", "sample", test_mail_data, "--n", "3", "--pct", "5"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/render"
+)
+
+// runSchema implements the "schema" subcommand.
+//
+// It prints one row per column with the narrowest type that fits every
+// non-empty value, as a table or (with --json) a JSON array.
+func runSchema(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-date-format":  true,
+		"--date-format": true,
+		"-json":         false,
+		"--json":        false,
+	})
+
+	fs := g.newFlagSet("schema", errOut, schemaUsage)
+
+	var layouts stringList
+	fs.Var(&layouts, "date-format", "Go time layout for date detection (repeatable)")
+	asJSON := fs.Bool("json", false, "Print the schema as JSON")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "schema requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	cols, err := csvio.InferSchema(fs.Arg(0), csvio.SchemaOptions{Options: g.inputOptions(fs.Arg(0)), DateLayouts: layouts})
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	if *asJSON {
+		b, err := json.MarshalIndent(cols, "", "  ")
+		if err != nil {
+			fmt.Fprintln(errOut, "error:", err)
+			return 1
+		}
+		fmt.Fprintln(out, string(b))
+		return 0
+	}
+
+	headers := []string{"column", "type", "nulls", "distinct", "samples"}
+	rows := make([][]string, len(cols))
+	for i, c := range cols {
+		rows[i] = []string{
+			c.Name,
+			c.InferredType,
+			strconv.Itoa(c.NullCount),
+			strconv.Itoa(c.DistinctCount),
+			strings.Join(c.SampleValues, ", "),
+		}
+	}
+
+	if err := render.PrintTable(out, headers, rows, render.TableOptions{MaxCellWidth: 48}); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// schemaUsage prints help for the "schema" subcommand.
+func schemaUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df schema <file.csv> [flags]
+
+Infer a type for every column: int, float, bool, date, or string (the
+narrowest type every non-empty value fits). Empty cells are counted as
+nulls and do not affect the type.
+
+Flags:
+  --date-format LAYOUT Go time layout for dates; repeat for more. Default:
+                       2006-01-02, 01/02/2006, and RFC 3339
+  --json               Print JSON instead of a table
+
+Examples:
+  df schema input.csv
+  df schema input.csv --date-format 02.01.2006 --json
+`)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSchema_JSON(t *testing.T) {
+	in := writeCSV(t, "id,joined\n1,31.01.2024\n2,\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "schema", in, "--json", "--date-format", "02.01.2006"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	var cols []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &cols); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(cols) != 2 || cols[0].Type != "int" || cols[1].Type != "date" {
+		t.Fatalf("unexpected schema: %+v", cols)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSelect_Order(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "select", test_mail_data, "--col", "email", "--col", "first_name"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if len(lines) != 11 || lines[0] != "email,first_name" {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestSelect_UnknownColumn(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "select", test_mail_data, "--col", "nope"}, &out, &errOut)
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "available: first_name") {
+		t.Fatalf("expected available headers; got %q", errOut.String())
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runSlice implements the "slice" subcommand: write the header plus data rows
+// [start, end) as CSV. Unlike head and tail it writes CSV rather than a
+// table, so a slice can feed another command or become a processing chunk.
+//
+// Output goes to -o, or stdout when -o is omitted.
+func runSlice(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":      true,
+		"-start":  true,
+		"--start": true,
+		"-end":    true,
+		"--end":   true,
+	})
+
+	fs := g.newFlagSet("slice", errOut, sliceUsage)
+
+	start := fs.Int("start", 0, "First data row to write (zero-based)")
+	end := fs.Int("end", -1, "Data row to stop before (default end of file)")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "slice requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if *start < 0 {
+		fmt.Fprintln(errOut, "--start must be >= 0")
+		return 2
+	}
+
+	n := math.MaxInt
+	if *end >= 0 {
+		if *end < *start {
+			fmt.Fprintln(errOut, "--end must be >= --start")
+			return 2
+		}
+		n = *end - *start
+	}
+
+	opts := g.inputOptions(fs.Arg(0))
+	headers, rows, err := csvio.ReadSlice(fs.Arg(0), *start, n, csvio.ReadOptions{Options: opts})
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	err = csvio.WriteRowsToWriter(w, headers, rows, opts)
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// sliceUsage prints help for the "slice" subcommand.
+func sliceUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df slice <file.csv> [--start N] [--end M] [-o out.csv]
+
+Write the header plus data rows N up to, but not including, M (zero-based;
+the header is not counted). An --end past the last row writes every row
+from --start on. Use "-" as the file to read standard input.
+
+Flags:
+  --start N            First data row to write (default 0)
+  --end M              Data row to stop before (default end of file)
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df slice input.csv --start 100 --end 200
+  cat input.csv | df slice - --start 1000 -o rest.csv
+`)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	in := writeCSV(t, "id\n0\n1\n2\n3\n4\n")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "range", args: []string{"--start", "1", "--end", "3"}, want: "id\n1\n2\n"},
+		{name: "end past last row", args: []string{"--start", "3", "--end", "100"}, want: "id\n3\n4\n"},
+		{name: "no end", args: []string{"--start", "4"}, want: "id\n4\n"},
+		{name: "empty range", args: []string{"--start", "2", "--end", "2"}, want: "id\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			code := run(append([]string{"df", "slice", in}, tt.args...), &out, &errOut)
+			if code != 0 {
+				t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSlice_EndBeforeStart(t *testing.T) {
+	in := writeCSV(t, "id\n0\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "slice", in, "--start", "2", "--end", "1"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runSort implements the "sort" subcommand.
+//
+// The whole file is loaded into memory before sorting; see csvio.SortFile.
+// Output goes to -o, or stdout when -o is omitted.
+func runSort(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":        true,
+		"-col":      true,
+		"--col":     true,
+		"-desc":     false,
+		"--desc":    false,
+		"-numeric":  false,
+		"--numeric": false,
+	})
+
+	fs := g.newFlagSet("sort", errOut, sortUsage)
+
+	var cols stringList
+	fs.Var(&cols, "col", "Sort key column name or index (repeatable)")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+	desc := fs.Bool("desc", false, "Sort in descending order")
+	numeric := fs.Bool("numeric", false, "Compare values as numbers")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "sort requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if len(cols) == 0 {
+		fmt.Fprintln(errOut, "sort requires at least one --col <name>")
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	err = csvio.SortReader(in, w, csvio.SortOptions{
+		Options:    g.inputOptions(fs.Arg(0)),
+		Columns:    cols,
+		Descending: *desc,
+		Numeric:    *numeric,
+	})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	return 0
+}
+
+// sortUsage prints help for the "sort" subcommand.
+func sortUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df sort <file.csv> --col <name> [--col <name> ...] [flags]
+
+Sort data rows by one or more columns, in the order given. The sort is
+stable: rows with equal keys keep their original order. The whole file is
+held in memory.
+
+Flags:
+  --col NAME|INDEX     Sort key; repeat for secondary keys. A zero-based
+                       index is accepted when no header matches
+  --desc               Sort in descending order
+  --numeric            Compare as numbers; non-numeric values sort as
+                       text after all numbers, or before them with --desc
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df sort input.csv --col state --col last_name
+  df sort input.csv --col zip --numeric --desc -o sorted.csv
+`)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSort_NumericDescending(t *testing.T) {
+	in := writeCSV(t, "name,age\nAnn,9\nBob,10\nCy,2\n")
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "sort", in, "--col", "age", "--numeric", "--desc"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	want := "name,age\nBob,10\nAnn,9\nCy,2\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestSort_RequiresCol(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "sort", test_mail_data}, &out, &errOut)
+	if code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "--col") {
+		t.Fatalf("expected --col hint; got %q", errOut.String())
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runSplit implements the "split" subcommand.
+//
+// Exactly one of --col (one file per value) and --chunk-size (fixed-size
+// parts) selects the mode. A per-file row count is printed to stdout.
+func runSplit(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-col":         true,
+		"--col":        true,
+		"-outdir":      true,
+		"--outdir":     true,
+		"-chunk-size":  true,
+		"--chunk-size": true,
+	})
+
+	fs := g.newFlagSet("split", errOut, splitUsage)
+
+	col := fs.String("col", "", "Write one file per distinct value of this column")
+	chunkSize := fs.Int("chunk-size", 0, "Write sequential files of at most this many rows")
+	outDir := fs.String("outdir", ".", "Directory for output files (created if missing)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "split requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if (*col == "") == (*chunkSize == 0) {
+		fmt.Fprintln(errOut, "split requires exactly one of --col or --chunk-size")
+		return 2
+	}
+	if *chunkSize < 0 {
+		fmt.Fprintln(errOut, "--chunk-size must be > 0")
+		return 2
+	}
+
+	var stats csvio.SplitStats
+	var err error
+	if *col != "" {
+		stats, err = csvio.SplitByColumn(fs.Arg(0), *outDir, *col, g.inputOptions(fs.Arg(0)))
+	} else {
+		stats, err = csvio.SplitIntoChunks(fs.Arg(0), *outDir, *chunkSize, g.inputOptions(fs.Arg(0)))
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	keys := make([]string, 0, len(stats.Counts))
+	for k := range stats.Counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(out, "%s\t%d\n", k, stats.Counts[k])
+	}
+
+	return 0
+}
+
+// splitUsage prints help for the "split" subcommand.
+func splitUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df split <file.csv> (--col <name> | --chunk-size <n>) [--outdir <dir>]
+
+Split a file into several, each with the header row.
+
+With --col, rows go to <value>.csv by their value in that column (unsafe
+characters in the value become "_"; empty values go to _empty.csv). With
+--chunk-size, rows go to part_000.csv, part_001.csv, ... in order.
+
+Prints each split key and its row count.
+
+Flags:
+  --col NAME           Split by distinct values of this column
+  --chunk-size N       Split into parts of at most N rows
+  --outdir DIR         Output directory (default ".")
+
+Examples:
+  df split input.csv --col state --outdir by_state
+  df split input.csv --chunk-size 5000 --outdir parts
+`)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSplit_ByColumn(t *testing.T) {
+	dir := t.TempDir()
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "split", test_mail_data, "--col", "state", "--outdir", dir}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "IL\t1\nNY\t9\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "IL.csv")); err != nil {
+		t.Fatalf("expected IL.csv: %v", err)
+	}
+}
+
+func TestSplit_RequiresOneMode(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	if code := run([]string{"df", "split", test_mail_data}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/nulls"
+	"github.com/bensabler/go-mail/internal/render"
+)
+
+// runStats implements the "stats" subcommand.
+//
+// The result is transposed: one output row per input column. The null flags
+// match "nullify" so both commands agree on what counts as NULL.
+func runStats(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-format":        true,
+		"--format":       true,
+		"-blanks":        false,
+		"--blanks":       false,
+		"-na":            false,
+		"--na":           false,
+		"-null-literal":  false,
+		"--null-literal": false,
+	})
+
+	fs := g.newFlagSet("stats", errOut, statsUsage)
+
+	format := fs.String("format", "table", "Output format: table, box, json, jsonl, csv, tsv, markdown, html, confluence, vertical")
+	blanks := fs.Bool("blanks", true, "Treat empty/whitespace-only cells as NULL")
+	na := fs.Bool("na", false, "Treat NA and N/A as NULL (case-insensitive)")
+	nullLiteral := fs.Bool("null-literal", false, "Treat NULL as NULL (case-insensitive)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "stats requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	outFormat, err := render.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 2
+	}
+
+	policy := nulls.Policy{
+		TreatBlanks:      *blanks,
+		TreatNA:          *na,
+		TreatNULLLiteral: *nullLiteral,
+	}
+
+	stats, err := csvio.ComputeStats(fs.Arg(0), policy, g.inputOptions(fs.Arg(0)))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	headers, rows := statsTable(stats)
+	if err := render.PrintTable(out, headers, rows, render.TableOptions{MaxCellWidth: 32, Format: outFormat}); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// statsTable lays out stats as a table with one row per input column.
+// Numeric columns are blank for non-numeric input columns.
+func statsTable(stats []csvio.ColumnStats) ([]string, [][]string) {
+	headers := []string{"column", "count", "nulls", "non_null", "min", "max", "num_min", "num_max", "mean", "stddev"}
+	rows := make([][]string, len(stats))
+	for i, s := range stats {
+		row := []string{
+			s.Name,
+			strconv.Itoa(s.Count),
+			strconv.Itoa(s.NullCount),
+			strconv.Itoa(s.NonNullCount),
+			s.Min,
+			s.Max,
+			"", "", "", "",
+		}
+		if s.Numeric {
+			row[6] = strconv.FormatFloat(s.NumericMin, 'g', -1, 64)
+			row[7] = strconv.FormatFloat(s.NumericMax, 'g', -1, 64)
+			row[8] = strconv.FormatFloat(s.Mean, 'f', 2, 64)
+			row[9] = strconv.FormatFloat(s.StdDev, 'f', 2, 64)
+		}
+		rows[i] = row
+	}
+	return headers, rows
+}
+
+// statsUsage prints help for the "stats" subcommand.
+func statsUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df stats <file.csv> [flags]
+
+Print summary statistics for every column: row count, null count, and
+lexicographic min/max. Columns where every non-null value is a number also
+get numeric min/max, mean, and sample standard deviation.
+
+Flags:
+  --format FORMAT      Output format: table (default), box, json, jsonl, csv,
+                       tsv, markdown, html, confluence, vertical
+  --blanks             Treat empty/whitespace-only cells as NULL (default true)
+  --na                 Treat NA and N/A as NULL (case-insensitive)
+  --null-literal       Treat NULL as NULL (case-insensitive)
+
+Examples:
+  df stats input.csv
+  df stats input.csv --na --null-literal --format json
+`)
+}
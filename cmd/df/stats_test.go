@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStats_CSVFormat(t *testing.T) {
+	in := writeCSV(t, "name,age\nAnn,30\nBob,NA\nCy,40\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "stats", in, "--na", "--format", "csv"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if len(lines) != 3 {
+		t.Fatalf("expected header plus one row per column; got\n%s", out.String())
+	}
+	if want := "age,3,1,2,30,40,30,40,35.00,7.07"; lines[2] != want {
+		t.Fatalf("age row = %q, want %q", lines[2], want)
+	}
+	if !strings.HasPrefix(lines[1], "name,3,0,3,Ann,Cy,,") {
+		t.Fatalf("name row = %q", lines[1])
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/render"
+)
+
+// runTail implements the "tail" subcommand: the counterpart of head that shows
+// the last N rows, which is handy for spotting truncated exports or trailing
+// junk rows.
+//
+// Like head, flags may appear before or after the file argument.
+func runTail(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-n":         true,
+		"-w":         true,
+		"-no-index":  false,
+		"--no-index": false,
+		"-format":    true,
+		"--format":   true,
+		"-color":     false,
+		"--color":    false,
+	})
+
+	fs := g.newFlagSet("tail", errOut, tailUsage)
+
+	n := fs.Int("n", 5, "Number of rows to display")
+	maxWidth := fs.Int("w", 0, "Max width per cell when printing (0 = fit the terminal, or 32)")
+	noIndex := fs.Bool("no-index", false, "Hide the leading row index column")
+	format := fs.String("format", "table", "Output format: table, box, json, jsonl, csv, tsv, markdown, html, confluence, vertical")
+	color := fs.Bool("color", false, "Color the table header, separator and empty cells (terminal only)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "tail requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if *n < 0 {
+		fmt.Fprintln(errOut, "-n must be >= 0")
+		return 2
+	}
+
+	outFormat, err := render.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 2
+	}
+
+	headers, rows, total, err := csvio.ReadTail(fs.Arg(0), *n, g.inputOptions(fs.Arg(0)))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	err = render.PrintTable(out, headers, rows, render.TableOptions{
+		MaxCellWidth: *maxWidth,
+		ShowRowIndex: !*noIndex,
+		// Index rows by their position in the file, as head does.
+		RowIndexStart: total - len(rows),
+		Format:        outFormat,
+		ColorEnabled:  *color && render.IsTerminal(out),
+	})
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// tailUsage prints help for the "tail" subcommand.
+func tailUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df tail <file.csv> [flags]
+
+Print the last N data rows as a table. The file is streamed, so this works
+on files of any size.
+
+Flags:
+  -n N                 Number of rows to display (default 5)
+  -w N                 Max width per cell in table output (default: share
+                       the terminal width among columns, or 32 when stdout
+                       is not a terminal)
+  --no-index           Hide the leading row index column (each row's
+                       zero-based position in the file)
+  --format F           Output format: table, box, json, jsonl, csv, tsv,
+                       markdown, html, confluence, vertical (default table)
+  --color              Bold header, cyan separator and dimmed empty cells
+                       (table format; ignored when stdout is not a terminal)
+
+Examples:
+  df tail input.csv
+  df tail input.csv -n 20
+  df tail input.csv --format json | jq '.[].email'
+`)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTail_FileBeforeFlag(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "tail", test_mail_data, "-n", "2"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	lines := nonEmptyLines(out.String())
+	if want := 2 + 2; len(lines) != want {
+		t.Fatalf("expected %d output lines, got %d\nOUTPUT:\n%s", want, len(lines), out.String())
+	}
+	// The fixture's last two rows are Sarah Lee and Mike Brown.
+	if !strings.Contains(lines[2], "Sarah") || !strings.Contains(lines[3], "Mike") {
+		t.Fatalf("expected last rows of fixture; got\n%s", out.String())
+	}
+}
+
+func TestTail_RowIndex(t *testing.T) {
+	in := writeCSV(t, "name\nAnn\nBob\nCy\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "tail", in, "-n", "1"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+
+	// Rows keep their position in the file, as with head --offset.
+	lines := nonEmptyLines(out.String())
+	if len(lines) != 3 || !strings.HasPrefix(lines[2], "2      Cy") {
+		t.Fatalf("expected row index 2 for Cy; got:\n%s", out.String())
+	}
+}
+
+func TestTail_ZeroRows(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "tail", "-n", "0", test_mail_data}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if lines := nonEmptyLines(out.String()); len(lines) != 2 {
+		t.Fatalf("expected header and separator only; got\n%s", out.String())
+	}
+}
+
+func TestTail_FormatJSON(t *testing.T) {
+	in := writeCSV(t, "name,zip\nAnn,12207\nBob,12180\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "tail", in, "-n", "1", "--format", "json"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "[\n  {\"name\":\"Bob\",\"zip\":\"12180\"}\n]\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestTail_UnknownFormat(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "tail", test_mail_data, "--format", "yaml"}, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit code 2, got %d", code)
+	}
+}
+
+func TestTail_ColorDisabledWhenNotTerminal(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "tail", test_mail_data, "--color"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if strings.Contains(out.String(), "\x1b[") {
+		t.Fatalf("unexpected escape codes in non-terminal output:\n%q", out.String())
+	}
+}
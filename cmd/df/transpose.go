@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runTranspose implements the "transpose" subcommand.
+//
+// The whole file is loaded into memory; see csvio.TransposeFile. Output goes
+// to -o, or stdout when -o is omitted.
+func runTranspose(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o": true,
+	})
+
+	fs := g.newFlagSet("transpose", errOut, transposeUsage)
+
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "transpose requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	err = csvio.TransposeReader(in, w, g.inputOptions(fs.Arg(0)))
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	return 0
+}
+
+// transposeUsage prints help for the "transpose" subcommand.
+func transposeUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df transpose <file.csv> [-o out.csv]
+
+Swap rows and columns: the first column becomes the header and every other
+column becomes a row. Short rows are padded with empty cells. The whole file
+is held in memory.
+
+Flags:
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df transpose settings.csv
+  df transpose settings.csv -o settings_wide.csv
+`)
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTranspose_KeyValue(t *testing.T) {
+	in := writeCSV(t, "key,value\nhost,db1\nport,5432\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "transpose", in}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "key,host,port\nvalue,db1,5432\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
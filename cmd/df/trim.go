@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runTrim implements the "trim" subcommand.
+//
+// Output goes to -o, or stdout when -o is omitted; the summary goes to
+// stderr.
+func runTrim(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":        true,
+		"-cols":     true,
+		"--cols":    true,
+		"-headers":  false,
+		"--headers": false,
+	})
+
+	fs := g.newFlagSet("trim", errOut, trimUsage)
+
+	var cols stringList
+	fs.Var(&cols, "cols", "Comma-separated columns to trim (default all)")
+	headers := fs.Bool("headers", false, "Also trim the header row")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "trim requires exactly one argument: <file.csv>")
+		return 2
+	}
+
+	in, err := g.openInput(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	defer in.Close()
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	stats, err := csvio.TrimReader(in, w, csvio.TrimOptions{Options: g.inputOptions(fs.Arg(0)), Headers: *headers, Columns: splitList(cols)})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	fmt.Fprintf(errOut, "Rows read: %d\n", stats.RowsRead)
+	fmt.Fprintf(errOut, "Cells trimmed: %d\n", stats.CellsTrimmed)
+
+	return 0
+}
+
+// trimUsage prints help for the "trim" subcommand.
+func trimUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df trim <file.csv> [flags]
+
+Strip leading and trailing whitespace from every cell. The header row is
+left as-is unless --headers is given.
+
+Flags:
+  --cols LIST          Comma-separated columns to trim (default all)
+  --headers            Also trim the header row
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df trim input.csv -o trimmed.csv
+  df trim input.csv --cols first_name,last_name --headers
+`)
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTrim_Cols(t *testing.T) {
+	in := writeCSV(t, "name,city\nAnn  ,Albany  \n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "trim", in, "--cols", "city"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "name,city\nAnn  ,Albany\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+)
+
+// runUnion implements the "union" subcommand.
+//
+// Output goes to -o, or stdout when -o is omitted; row counts go to stderr.
+func runUnion(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-o":              true,
+		"-key":            true,
+		"--key":           true,
+		"-ignore-schema":  false,
+		"--ignore-schema": false,
+	})
+
+	fs := g.newFlagSet("union", errOut, unionUsage)
+
+	var keys stringList
+	fs.Var(&keys, "key", "Key column (repeatable, or comma-separated; default all columns)")
+	ignoreSchema := fs.Bool("ignore-schema", false, "Allow different headers; use the first file's")
+	outPath := fs.String("o", "", "Output CSV path (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	paths, err := expandFileList(fs.Args())
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+	if len(paths) != 2 {
+		fmt.Fprintln(errOut, "union requires exactly two arguments: <a.csv> <b.csv>")
+		return 2
+	}
+
+	w, closeOut, err := g.openOutput(*outPath, out)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	fileOpts := g.fileInputOptions(paths[0], paths[1])
+	stats, err := csvio.UnionToWriter(paths[0], paths[1], w, splitList(keys), csvio.UnionOptions{
+		Options:      fileOpts[0],
+		FileOptions:  fileOpts,
+		IgnoreSchema: *ignoreSchema,
+	})
+	if cerr := closeOut(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	fmt.Fprintf(errOut, "Rows from %s: %d\n", paths[0], stats.RowsFromA)
+	fmt.Fprintf(errOut, "Rows from %s: %d\n", paths[1], stats.RowsFromB)
+	fmt.Fprintf(errOut, "Duplicates removed: %d\n", stats.RowsDeduplicated)
+
+	return 0
+}
+
+// unionUsage prints help for the "union" subcommand.
+func unionUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df union <a.csv> <b.csv> [--key NAME] [-o out.csv]
+
+Write the rows of a.csv followed by the rows of b.csv, dropping any row whose
+key was already written, so the first occurrence wins. Without --key the
+whole row is the key and only exact duplicates are dropped. Both files must
+have the same header unless --ignore-schema is given, in which case b.csv's
+rows are padded or truncated to a.csv's columns. Every key is held in memory.
+
+An argument "@list.txt" is replaced by the paths listed in list.txt, one
+per line; together the arguments must name two files.
+
+Flags:
+  --key NAME           Key column (repeatable, or comma-separated)
+  --ignore-schema      Allow a different header in b.csv; keep a.csv's
+  -o PATH              Output CSV path (default stdout)
+
+Examples:
+  df union january.csv february.csv --key email
+  df union old.csv new.csv -o combined.csv
+`)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestUnion_Key(t *testing.T) {
+	a := writeCSV(t, "email,name\nann@x.com,Ann\nbob@x.com,Bob\n")
+	b := writeCSV(t, "email,name\nbob@x.com,Robert\ncy@x.com,Cy\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "union", a, b, "--key", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "email,name\nann@x.com,Ann\nbob@x.com,Bob\ncy@x.com,Cy\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if !strings.Contains(errOut.String(), "Duplicates removed: 1") {
+		t.Fatalf("stderr = %q", errOut.String())
+	}
+}
+
+func TestUnion_HeaderMismatch(t *testing.T) {
+	a := writeCSV(t, "email\nann@x.com\n")
+	b := writeCSV(t, "mail\nbob@x.com\n")
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "union", a, b}, &out, &errOut); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("stdout = %q, want nothing on a header mismatch", out.String())
+	}
+	if code := run([]string{"df", "union", a, b, "--ignore-schema"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "email\nann@x.com\nbob@x.com\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestUnion_FileList(t *testing.T) {
+	a := writeCSV(t, "email\nann@x.com\n")
+	b := writeCSV(t, "email\nann@x.com\nbob@x.com\n")
+	list := writeCSV(t, a+"\n"+b+"\n")
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "union", "@" + list, "--key", "email"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "email\nann@x.com\nbob@x.com\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
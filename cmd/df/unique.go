@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/nulls"
+	"github.com/bensabler/go-mail/internal/render"
+)
+
+// runUnique implements the "unique" subcommand.
+//
+// It prints a value/count frequency table for one column. NULL cells (per
+// the same flags as "nullify") are skipped unless --include-nulls is set.
+func runUnique(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-col":            true,
+		"--col":           true,
+		"-sort-by":        true,
+		"--sort-by":       true,
+		"-limit":          true,
+		"--limit":         true,
+		"-format":         true,
+		"--format":        true,
+		"-include-nulls":  false,
+		"--include-nulls": false,
+		"-blanks":         false,
+		"--blanks":        false,
+		"-na":             false,
+		"--na":            false,
+		"-null-literal":   false,
+		"--null-literal":  false,
+	})
+
+	fs := g.newFlagSet("unique", errOut, uniqueUsage)
+
+	col := fs.String("col", "", "Column to count (required)")
+	sortBy := fs.String("sort-by", csvio.SortByCount, "Sort order: count (descending) or value")
+	limit := fs.Int("limit", 0, "Show at most this many values (0 = all)")
+	format := fs.String("format", "table", "Output format: table, box, json, jsonl, csv, tsv, markdown, html, confluence, vertical")
+	includeNulls := fs.Bool("include-nulls", false, "Count NULL-like values too")
+	blanks := fs.Bool("blanks", true, "Treat empty/whitespace-only cells as NULL")
+	na := fs.Bool("na", false, "Treat NA and N/A as NULL (case-insensitive)")
+	nullLiteral := fs.Bool("null-literal", false, "Treat NULL as NULL (case-insensitive)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "unique requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if *col == "" {
+		fmt.Fprintln(errOut, "unique requires --col <name>")
+		return 2
+	}
+	if *sortBy != csvio.SortByCount && *sortBy != csvio.SortByValue {
+		fmt.Fprintf(errOut, "unknown --sort-by %q (want count or value)\n", *sortBy)
+		return 2
+	}
+	if *limit < 0 {
+		fmt.Fprintln(errOut, "--limit must be >= 0")
+		return 2
+	}
+
+	outFormat, err := render.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 2
+	}
+
+	values, err := csvio.UniqueValues(fs.Arg(0), *col, csvio.UniqueOptions{
+		Options:      g.inputOptions(fs.Arg(0)),
+		SortBy:       *sortBy,
+		IncludeNulls: *includeNulls,
+		Policy: nulls.Policy{
+			TreatBlanks:      *blanks,
+			TreatNA:          *na,
+			TreatNULLLiteral: *nullLiteral,
+		},
+	})
+	if err != nil {
+		return reportError(errOut, err)
+	}
+
+	if *limit > 0 && len(values) > *limit {
+		values = values[:*limit]
+	}
+
+	rows := make([][]string, len(values))
+	for i, v := range values {
+		rows[i] = []string{v.Value, strconv.Itoa(v.Count)}
+	}
+	if err := render.PrintTable(out, []string{"value", "count"}, rows, render.TableOptions{MaxCellWidth: 48, Format: outFormat}); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// uniqueUsage prints help for the "unique" subcommand.
+func uniqueUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df unique <file.csv> --col <name> [flags]
+
+Print each distinct value of a column with its row count. Fields are parsed
+as CSV, so quoted commas are handled correctly.
+
+Flags:
+  --col NAME           Column to count (case-insensitive, required)
+  --sort-by ORDER      count (default, most frequent first) or value
+  --limit N            Show at most N values
+  --format FORMAT      Output format: table (default), box, json, jsonl, csv,
+                       tsv, markdown, html, confluence, vertical
+  --include-nulls      Count NULL-like values too
+  --blanks             Treat empty/whitespace-only cells as NULL (default true)
+  --na                 Treat NA and N/A as NULL (case-insensitive)
+  --null-literal       Treat NULL as NULL (case-insensitive)
+
+Examples:
+  df unique input.csv --col state
+  df unique input.csv --col city --sort-by value --limit 20
+`)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUnique_CSV(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "unique", test_mail_data, "--col", "state", "--format", "csv"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "value,count\nNY,9\nIL,1\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestUnique_Limit(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	code := run([]string{"df", "unique", test_mail_data, "--col", "state", "--format", "csv", "--limit", "1"}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stderr=%s", code, errOut.String())
+	}
+	if want := "value,count\nNY,9\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+	"github.com/bensabler/go-mail/internal/render"
+	"github.com/bensabler/go-mail/internal/schema"
+)
+
+// runValidate implements the "validate" subcommand.
+//
+// It prints the row and violation counts and, when there are violations, a
+// table with one line per column and rule. The exit code is 0 when every
+// row is valid and 1 when any rule is violated (or the file cannot be read),
+// so scripts can gate a mailing on it.
+func runValidate(g *globalOptions, args []string, out, errOut io.Writer) int {
+	args = reorderFlagsToFront(args, map[string]bool{
+		"-schema-file":   true,
+		"--schema-file":  true,
+		"-format":        true,
+		"--format":       true,
+		"-blanks":        false,
+		"--blanks":       false,
+		"-na":            false,
+		"--na":           false,
+		"-null-literal":  false,
+		"--null-literal": false,
+	})
+
+	fs := g.newFlagSet("validate", errOut, validateUsage)
+
+	schemaFile := fs.String("schema-file", "", "JSON schema to validate against (required)")
+	format := fs.String("format", "table", "Output format: table, box, json, jsonl, csv, tsv, markdown, html, confluence, vertical")
+	blanks := fs.Bool("blanks", true, "Treat empty/whitespace-only cells as NULL")
+	na := fs.Bool("na", false, "Treat NA and N/A as NULL (case-insensitive)")
+	nullLiteral := fs.Bool("null-literal", false, "Treat NULL as NULL (case-insensitive)")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(errOut, "validate requires exactly one argument: <file.csv>")
+		return 2
+	}
+	if *schemaFile == "" {
+		fmt.Fprintln(errOut, "validate requires --schema-file <path>")
+		return 2
+	}
+
+	outFormat, err := render.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(errOut, err)
+		return 2
+	}
+
+	s, err := schema.LoadFile(*schemaFile)
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 2
+	}
+
+	policy := nulls.Policy{
+		TreatBlanks:      *blanks,
+		TreatNA:          *na,
+		TreatNULLLiteral: *nullLiteral,
+	}
+
+	rep, err := schema.ValidateFile(fs.Arg(0), s, policy, g.inputOptions(fs.Arg(0)))
+	if err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "Rows: %d\n", rep.Rows)
+	fmt.Fprintf(out, "Violations: %d\n", rep.Violations)
+	if rep.OK() {
+		return 0
+	}
+
+	headers := []string{"column", "rule", "violations", "first_row", "example"}
+	rows := make([][]string, len(rep.Counts))
+	for i, c := range rep.Counts {
+		firstRow := strconv.Itoa(c.FirstRow)
+		if c.Rule == schema.RuleRequired {
+			firstRow = "header"
+		}
+		rows[i] = []string{c.Col, c.Rule, strconv.Itoa(c.Count), firstRow, c.Example}
+	}
+	fmt.Fprintln(out)
+	if err := render.PrintTable(out, headers, rows, render.TableOptions{MaxCellWidth: 32, Format: outFormat}); err != nil {
+		fmt.Fprintln(errOut, "error:", err)
+	}
+
+	return 1
+}
+
+// validateUsage prints help for the "validate" subcommand.
+func validateUsage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  df validate <file.csv> --schema-file schema.json [flags]
+
+Check every row against a schema of column rules and summarize the
+violations per column and rule. Exits 0 when the file is valid and 1 when
+any rule is violated.
+
+The schema is JSON; every key except "name" is optional:
+
+  {
+    "columns": [
+      {"name": "email", "required": true, "not_null": true,
+       "regex": "[^@\\s]+@[^@\\s]+\\.[^@\\s]+"},
+      {"name": "phone", "regex": "\\+?[0-9 ()-]{7,}"}
+    ]
+  }
+
+  required     the column must exist
+  not_null     no cell may be NULL (see the null flags below)
+  regex        every non-NULL cell must match in full (RE2 syntax)
+
+Flags:
+  --schema-file PATH   JSON schema to validate against (required)
+  --format NAME        Output format for the violations table (default table)
+  --blanks             Treat empty/whitespace-only cells as NULL (default true)
+  --na                 Treat NA and N/A as NULL (case-insensitive)
+  --null-literal       Treat NULL as NULL (case-insensitive)
+
+Examples:
+  df validate mailing.csv --schema-file mailing.schema.json
+  df validate mailing.csv --schema-file mailing.schema.json --na --format markdown
+`)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	in := writeCSV(t, "email,name\nann@example.com,Ann\n,Bob\nbad,Cy\n")
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	content := `{"columns": [{"name": "email", "not_null": true, "regex": "\\S+@\\S+"}]}`
+	if err := os.WriteFile(schemaPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	code := run([]string{"df", "validate", in, "--schema-file", schemaPath, "--format", "csv"}, &out, &errOut)
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d; stderr=%s", code, errOut.String())
+	}
+	for _, want := range []string{"Rows: 3", "Violations: 2", "email,not_null,1,2,", "email,regex,1,3,bad"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	in := writeCSV(t, "email\nann@example.com\n")
+	schemaPath := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"columns": [{"name": "email", "required": true}]}`), 0o644); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	if code := run([]string{"df", "validate", in, "--schema-file", schemaPath}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit code 0, got %d; stdout=%s stderr=%s", code, out.String(), errOut.String())
+	}
+}
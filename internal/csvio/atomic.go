@@ -0,0 +1,91 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements atomic output: the data is written to a temporary
+// file next to the destination and renamed over it only once complete. A
+// rename within one directory is atomic on POSIX filesystems, so a run that
+// fails or is killed part-way leaves any existing output untouched instead
+// of truncated.
+package csvio
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// atomicFile is a temporary file that replaces dest when closed.
+//
+// Close commits: it closes the temporary file and renames it to dest. Abort
+// discards the temporary file instead; after Abort, Close does nothing.
+type atomicFile struct {
+	*os.File
+	dest string
+	done bool
+}
+
+// createAtomic creates a temporary file in dest's directory. The file gets
+// dest's current permissions, or 0644 when dest does not exist yet.
+func createAtomic(dest string) (*atomicFile, error) {
+	f, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+
+	mode := os.FileMode(0o644)
+	if fi, err := os.Stat(dest); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	return &atomicFile{File: f, dest: dest}, nil
+}
+
+// Close closes the temporary file and renames it to dest.
+func (a *atomicFile) Close() error {
+	if a.done {
+		return nil
+	}
+	a.done = true
+
+	if err := a.File.Close(); err != nil {
+		os.Remove(a.Name())
+		return err
+	}
+	if err := os.Rename(a.Name(), a.dest); err != nil {
+		os.Remove(a.Name())
+		return err
+	}
+	return nil
+}
+
+// Abort closes and removes the temporary file, leaving dest untouched.
+func (a *atomicFile) Abort() {
+	if a.done {
+		return
+	}
+	a.done = true
+
+	a.File.Close()
+	os.Remove(a.Name())
+}
+
+// createAtomicOutput is createOutput for a file that is replaced atomically.
+// Closing the returned writer commits the output; calling abort first
+// discards it. Stdout cannot be replaced, so for StdioPath this is
+// createOutput and abort does nothing.
+func createAtomicOutput(path string, tap func(io.Writer) io.Writer) (io.WriteCloser, func(), error) {
+	if path == StdioPath {
+		out, err := createOutput(path, tap)
+		return out, func() {}, err
+	}
+
+	f, err := createAtomic(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wrapOutput(path, f, tap), f.Abort, nil
+}
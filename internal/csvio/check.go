@@ -0,0 +1,121 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file checks a file for structural problems that transforms would
+// silently paper over: rows of the wrong width, unusable header names, and
+// cells spanning several lines. The file is streamed; only a few example row
+// numbers are kept per problem.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// checkExampleLimit caps the example row numbers kept per problem in a
+// CheckReport.
+const checkExampleLimit = 5
+
+// CheckReport is the outcome of CheckFile. Row numbers are 1-based data row
+// numbers (the header is not counted); header positions are zero-based, as
+// "df cols" prints them.
+type CheckReport struct {
+	// Rows is the number of data rows and Columns the header width.
+	Rows    int
+	Columns int
+
+	// JaggedRows counts rows whose field count differs from the header's.
+	// JaggedExamples holds the first few of them.
+	JaggedRows     int
+	JaggedExamples []JaggedRow
+
+	// EmptyHeaders lists the positions of blank header names.
+	EmptyHeaders []int
+
+	// DuplicateHeaders lists header names that occur more than once,
+	// compared case-insensitively as column lookups are.
+	DuplicateHeaders []string
+
+	// MultilineCells counts cells containing a line break, which many tools
+	// that read CSV line by line mishandle. MultilineRows holds the first few
+	// rows with one.
+	MultilineCells int
+	MultilineRows  []int
+}
+
+// JaggedRow is a data row whose field count differs from the header's.
+type JaggedRow struct {
+	Row    int
+	Fields int
+}
+
+// OK reports whether the check found no problems.
+func (r CheckReport) OK() bool {
+	return r.JaggedRows == 0 && len(r.EmptyHeaders) == 0 && len(r.DuplicateHeaders) == 0 && r.MultilineCells == 0
+}
+
+// CheckFile reads the whole file at path and reports its structural
+// problems. An error means the file could not be parsed as CSV; the report
+// then covers the rows read before the failure.
+func CheckFile(path string, opts Options) (CheckReport, error) {
+	f, err := openCSV(path, opts)
+	if err != nil {
+		return CheckReport{}, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, r, err := readHeader(newReader(f, opts), opts)
+	if err != nil {
+		return CheckReport{}, err
+	}
+
+	rep := CheckReport{Columns: len(headers)}
+	if !r.headerless {
+		seen := make(map[string]int, len(headers))
+		for i, h := range headers {
+			if strings.TrimSpace(h) == "" {
+				rep.EmptyHeaders = append(rep.EmptyHeaders, i)
+				continue
+			}
+			key := strings.ToLower(h)
+			seen[key]++
+			if seen[key] == 2 {
+				rep.DuplicateHeaders = append(rep.DuplicateHeaders, h)
+			}
+			if strings.ContainsAny(h, "\r\n") {
+				rep.MultilineCells++
+			}
+		}
+	}
+
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rep, fmt.Errorf("read row: %w", err)
+		}
+		rep.Rows++
+
+		if len(rec) != len(headers) {
+			rep.JaggedRows++
+			if len(rep.JaggedExamples) < checkExampleLimit {
+				rep.JaggedExamples = append(rep.JaggedExamples, JaggedRow{Row: rep.Rows, Fields: len(rec)})
+			}
+		}
+
+		multiline := false
+		for _, v := range rec {
+			if strings.ContainsAny(v, "\r\n") {
+				rep.MultilineCells++
+				multiline = true
+			}
+		}
+		if multiline && len(rep.MultilineRows) < checkExampleLimit {
+			rep.MultilineRows = append(rep.MultilineRows, rep.Rows)
+		}
+	}
+
+	return rep, nil
+}
@@ -0,0 +1,67 @@
+package csvio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    CheckReport
+	}{
+		{
+			name:    "clean",
+			content: "id,name\n1,Ann\n2,Bob\n",
+			want:    CheckReport{Rows: 2, Columns: 2},
+		},
+		{
+			name:    "jagged rows",
+			content: "id,name\n1\n2,Bob\n3,Cy,extra\n",
+			want: CheckReport{
+				Rows: 3, Columns: 2, JaggedRows: 2,
+				JaggedExamples: []JaggedRow{{Row: 1, Fields: 1}, {Row: 3, Fields: 3}},
+			},
+		},
+		{
+			name:    "header problems",
+			content: "id, ,Name,name,NAME\n1,2,3,4,5\n",
+			want: CheckReport{
+				Rows: 1, Columns: 5,
+				EmptyHeaders:     []int{1},
+				DuplicateHeaders: []string{"name"},
+			},
+		},
+		{
+			name:    "embedded newlines",
+			content: "id,note\n1,\"two\nlines\"\n2,ok\n3,\"a\r\nb\"\n",
+			want:    CheckReport{Rows: 3, Columns: 2, MultilineCells: 2, MultilineRows: []int{1, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckFile(writeTemp(t, "in.csv", tt.content), Options{})
+			if err != nil {
+				t.Fatalf("CheckFile: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("CheckFile =\n %+v\nwant\n %+v", got, tt.want)
+			}
+			if got.OK() != (tt.name == "clean") {
+				t.Fatalf("OK() = %v", got.OK())
+			}
+		})
+	}
+}
+
+func TestCheckFile_ParseError(t *testing.T) {
+	rep, err := CheckFile(writeTemp(t, "in.csv", "id,name\n1,Ann\n2,\"bad\"quote\n"), Options{})
+	if err == nil {
+		t.Fatalf("expected parse error")
+	}
+	if rep.Rows != 1 {
+		t.Fatalf("Rows = %d, want the 1 row read before the error", rep.Rows)
+	}
+}
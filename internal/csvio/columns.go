@@ -0,0 +1,184 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file contains single-column inspection helpers. They stream the input
+// and keep only per-column state in memory, so they are safe to run against
+// large files.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+// ColumnUniques returns the distinct values of column colName in first-seen
+// order.
+//
+// The header row is never included in the result. Column lookup is
+// case-insensitive; if the column does not exist, an error listing the
+// available columns is returned.
+//
+// maxUniques caps how many distinct values are collected, which bounds memory
+// on high-cardinality columns (IDs, emails). When a value beyond the cap is
+// encountered, scanning stops and truncated is true. A maxUniques <= 0 means
+// "no cap".
+func ColumnUniques(path string, colName string, maxUniques int, opts Options) (values []string, truncated bool, err error) {
+	f, err := openCSV(path, opts)
+	if err != nil {
+		return nil, false, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, r, err := readHeader(newReader(f, opts), opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	col, err := ColumnIndex(headers, colName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	seen := make(map[string]struct{})
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("read row: %w", err)
+		}
+
+		// Normalization pads short rows, so a missing cell reads as "".
+		v := normalizeRow(rec, len(headers))[col]
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		if maxUniques > 0 && len(values) >= maxUniques {
+			return values, true, nil
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+
+	return values, false, nil
+}
+
+// SampleValuesPerColumn collects up to n non-null example values per column
+// from rows, in row order.
+//
+// Values matching policy are skipped so samples show what real data looks
+// like. Columns with fewer than n non-null values get whatever is available
+// (possibly none). The result is keyed by header name; if headers contain
+// duplicate names, the samples of those columns are merged under one key.
+func SampleValuesPerColumn(headers []string, rows [][]string, n int, policy nulls.Policy) map[string][]string {
+	samples := make(map[string][]string, len(headers))
+	for _, h := range headers {
+		samples[h] = nil
+	}
+
+	for _, row := range rows {
+		for i, h := range headers {
+			if i >= len(row) || len(samples[h]) >= n {
+				continue
+			}
+			if policy.IsNull(row[i]) {
+				continue
+			}
+			samples[h] = append(samples[h], row[i])
+		}
+	}
+
+	return samples
+}
+
+// Sort orders for UniqueOptions.SortBy.
+const (
+	SortByCount = "count"
+	SortByValue = "value"
+)
+
+// UniqueOptions controls UniqueValues.
+type UniqueOptions struct {
+	// Options controls how the input is parsed.
+	Options
+
+	// SortBy is SortByCount (most frequent first, the default) or
+	// SortByValue (ascending). Ties in count are broken by value.
+	SortBy string
+
+	// IncludeNulls counts cells that Policy treats as NULL. By default they
+	// are skipped so the table shows real values only.
+	IncludeNulls bool
+
+	// Policy decides which cells are NULL.
+	Policy nulls.Policy
+}
+
+// ValueCount is one distinct value of a column and how many rows hold it.
+type ValueCount struct {
+	Value string
+	Count int
+}
+
+// UniqueValues returns every distinct value of column col with its frequency,
+// sorted per opts.SortBy. It is the CSV-aware equivalent of
+// "cut | sort | uniq -c | sort -rn".
+//
+// Unlike ColumnUniques there is no cap: a count is kept per distinct value,
+// so memory grows with the column's cardinality.
+func UniqueValues(path, col string, opts UniqueOptions) ([]ValueCount, error) {
+	switch opts.SortBy {
+	case "", SortByCount, SortByValue:
+	default:
+		return nil, fmt.Errorf("unknown sort order %q (want %s or %s)", opts.SortBy, SortByCount, SortByValue)
+	}
+
+	f, err := openCSV(path, opts.Options)
+	if err != nil {
+		return nil, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, r, err := readHeader(newReader(f, opts.Options), opts.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := ColumnIndex(headers, col)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read row: %w", err)
+		}
+
+		v := normalizeRow(rec, len(headers))[idx]
+		if !opts.IncludeNulls && opts.Policy.IsNull(v) {
+			continue
+		}
+		counts[v]++
+	}
+
+	out := make([]ValueCount, 0, len(counts))
+	for v, n := range counts {
+		out = append(out, ValueCount{Value: v, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if opts.SortBy != SortByValue && out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Value < out[j].Value
+	})
+
+	return out, nil
+}
@@ -0,0 +1,131 @@
+package csvio
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+const uniquesFixture = "name,state\n" +
+	"Ann,NY\n" +
+	"Bob,CA\n" +
+	"Cy,NY\n" +
+	"Di,TX\n" +
+	"Ed,CA\n"
+
+func TestColumnUniques_FirstSeenOrder(t *testing.T) {
+	path := writeTemp(t, "in.csv", uniquesFixture)
+
+	got, truncated, err := ColumnUniques(path, "state", 10, Options{})
+	if err != nil {
+		t.Fatalf("ColumnUniques: %v", err)
+	}
+	if truncated {
+		t.Fatalf("expected truncated=false")
+	}
+	if want := []string{"NY", "CA", "TX"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestColumnUniques_Truncates(t *testing.T) {
+	path := writeTemp(t, "in.csv", uniquesFixture)
+
+	got, truncated, err := ColumnUniques(path, "state", 2, Options{})
+	if err != nil {
+		t.Fatalf("ColumnUniques: %v", err)
+	}
+	if !truncated {
+		t.Fatalf("expected truncated=true")
+	}
+	if want := []string{"NY", "CA"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestColumnUniques_ExcludesHeader(t *testing.T) {
+	path := writeTemp(t, "in.csv", "state\nstate\nNY\n")
+
+	got, _, err := ColumnUniques(path, "state", 0, Options{})
+	if err != nil {
+		t.Fatalf("ColumnUniques: %v", err)
+	}
+	// "state" appears once as data; the header must not add a second entry.
+	if want := []string{"state", "NY"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	path = writeTemp(t, "in2.csv", uniquesFixture)
+	got, _, err = ColumnUniques(path, "name", 0, Options{})
+	if err != nil {
+		t.Fatalf("ColumnUniques: %v", err)
+	}
+	for _, v := range got {
+		if v == "name" {
+			t.Fatalf("header value leaked into results: %v", got)
+		}
+	}
+}
+
+func TestColumnUniques_MissingColumn(t *testing.T) {
+	path := writeTemp(t, "in.csv", uniquesFixture)
+
+	if _, _, err := ColumnUniques(path, "zip", 0, Options{}); err == nil {
+		t.Fatalf("expected error for missing column")
+	}
+}
+
+func TestSampleValuesPerColumn(t *testing.T) {
+	headers := []string{"name", "email"}
+	rows := [][]string{
+		{"Ann", ""},
+		{"Bob", "NA"},
+		{"Cy", "cy@example.com"},
+		{"Di", "di@example.com"},
+	}
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true}
+
+	got := SampleValuesPerColumn(headers, rows, 3, policy)
+
+	want := map[string][]string{
+		"name":  {"Ann", "Bob", "Cy"},
+		"email": {"cy@example.com", "di@example.com"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestUniqueValues(t *testing.T) {
+	path := writeTemp(t, "in.csv", uniquesFixture+"Fay,\nGus,NA\n")
+
+	tests := []struct {
+		name string
+		opts UniqueOptions
+		want []ValueCount
+	}{
+		{
+			name: "by count",
+			opts: UniqueOptions{Policy: nulls.Policy{TreatBlanks: true, TreatNA: true}},
+			want: []ValueCount{{"CA", 2}, {"NY", 2}, {"TX", 1}},
+		},
+		{
+			name: "by value with nulls",
+			opts: UniqueOptions{SortBy: SortByValue, IncludeNulls: true, Policy: nulls.Policy{TreatBlanks: true}},
+			want: []ValueCount{{"", 1}, {"CA", 2}, {"NA", 1}, {"NY", 2}, {"TX", 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UniqueValues(path, "State", tt.opts)
+			if err != nil {
+				t.Fatalf("UniqueValues: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
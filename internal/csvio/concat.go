@@ -0,0 +1,205 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements vertical concatenation of files that share a schema,
+// optionally tagging each row with the file it came from. Each input may use
+// its own dialect (e.g. a comma-delimited CRM export and a tab-delimited
+// database export), while the output uses a single dialect.
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/bensabler/go-mail/internal/progress"
+)
+
+// SourceFileColumn is the default header of the column added by
+// ConcatOptions.AddSourceColumn.
+const SourceFileColumn = "_source"
+
+// ConcatOptions controls ConcatFiles.
+type ConcatOptions struct {
+	// Options controls the output delimiter, and how inputs are parsed when
+	// FileOptions is empty.
+	Options
+
+	// FileOptions holds per-input read options, matched to input paths by
+	// position. When it is shorter than the input list, the last entry is
+	// reused for the remaining files; when empty, every file uses Options.
+	FileOptions []Options
+
+	// AddSourceColumn prepends a column holding, for each row, the base
+	// name of the input it was read from, e.g. "jan.csv" for
+	// "exports/jan.csv".
+	AddSourceColumn bool
+
+	// SourceColumnName is the header of that column. Empty means
+	// SourceFileColumn.
+	SourceColumnName string
+
+	// IgnoreSchema skips the header check for the second and later files.
+	// Their rows are padded or truncated to the first file's width, and
+	// their headers are discarded.
+	IgnoreSchema bool
+
+	// Progress, when non-nil, is updated after every row is read with the
+	// running row count across all inputs.
+	Progress *progress.ProgressReporter
+}
+
+// ConcatFileStats counts the rows of a single input.
+type ConcatFileStats struct {
+	Path        string
+	RowsRead    int
+	RowsWritten int
+}
+
+// ConcatStats summarizes a ConcatFiles run, with one entry per input file in
+// the order given.
+type ConcatStats struct {
+	Files []ConcatFileStats
+}
+
+// RowsRead returns the total number of data rows read across all inputs.
+func (s ConcatStats) RowsRead() int {
+	n := 0
+	for _, f := range s.Files {
+		n += f.RowsRead
+	}
+	return n
+}
+
+// RowsWritten returns the total number of data rows written across all
+// inputs.
+func (s ConcatStats) RowsWritten() int {
+	n := 0
+	for _, f := range s.Files {
+		n += f.RowsWritten
+	}
+	return n
+}
+
+// ConcatFiles stacks the data rows of inputPaths into a single file at
+// outputPath.
+//
+// The first file's header is the schema and is written once. Unless
+// opts.IgnoreSchema is set, every other file must have exactly the same
+// header; otherwise an error naming the offending file is returned. Rows are
+// streamed, so memory use does not grow with file size.
+func ConcatFiles(inputPaths []string, outputPath string, opts ConcatOptions) (ConcatStats, error) {
+	if len(inputPaths) == 0 {
+		return ConcatStats{}, errors.New("concat: no input files")
+	}
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return ConcatStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return ConcatToWriter(inputPaths, out, opts)
+}
+
+// ConcatToWriter is ConcatFiles writing to an already-open output, e.g.
+// stdout.
+func ConcatToWriter(inputPaths []string, w io.Writer, opts ConcatOptions) (ConcatStats, error) {
+	if len(inputPaths) == 0 {
+		return ConcatStats{}, errors.New("concat: no input files")
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	stats := ConcatStats{}
+	var schema []string
+
+	for i, path := range inputPaths {
+		fs, headers, err := concatOne(path, fileOptions(opts.Options, opts.FileOptions, i), opts, schema, cw, stats.RowsRead())
+		stats.Files = append(stats.Files, fs)
+		if err != nil {
+			return stats, err
+		}
+		if schema == nil {
+			schema = headers
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
+
+// concatOne streams the data rows of a single input, parsed with ropts, to w
+// and returns its counts and headers. prior is the number of rows read from
+// earlier inputs, for progress reporting.
+//
+// A nil schema means this is the first file: its header is written and
+// becomes the schema. Otherwise the header must equal schema unless
+// opts.IgnoreSchema is set.
+func concatOne(path string, ropts Options, opts ConcatOptions, schema []string, w *csv.Writer, prior int) (ConcatFileStats, []string, error) {
+	stats := ConcatFileStats{Path: path}
+	source := filepath.Base(path)
+
+	f, err := openCSV(path, ropts)
+	if err != nil {
+		return stats, nil, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, r, err := readHeader(newReader(f, ropts), ropts)
+	if err != nil {
+		return stats, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	width := len(headers)
+	switch {
+	case schema == nil:
+		out := headers
+		if opts.AddSourceColumn {
+			name := opts.SourceColumnName
+			if name == "" {
+				name = SourceFileColumn
+			}
+			out = append([]string{name}, headers...)
+		}
+		if err := w.Write(out); err != nil {
+			return stats, nil, fmt.Errorf("write headers: %w", err)
+		}
+	case opts.IgnoreSchema:
+		width = len(schema)
+	case !slices.Equal(headers, schema):
+		return stats, nil, fmt.Errorf("%s: header mismatch (got %q, want %q)", path, headers, schema)
+	}
+
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, nil, fmt.Errorf("%s: read row: %w", path, err)
+		}
+		stats.RowsRead++
+		opts.Progress.Update(prior + stats.RowsRead)
+
+		rec = normalizeRow(rec, width)
+		if opts.AddSourceColumn {
+			rec = append([]string{source}, rec...)
+		}
+		if err := w.Write(rec); err != nil {
+			return stats, nil, fmt.Errorf("write row: %w", err)
+		}
+		stats.RowsWritten++
+	}
+
+	return stats, headers, nil
+}
@@ -0,0 +1,122 @@
+package csvio
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConcatToWriter(t *testing.T) {
+	a := writeTemp(t, "a.csv", "name,zip\nAnn,1\n")
+	b := writeTemp(t, "b.csv", "name,zip\nBob,2\nCy,3\n")
+
+	var out bytes.Buffer
+	stats, err := ConcatToWriter([]string{a, b}, &out, ConcatOptions{AddSourceColumn: true})
+	if err != nil {
+		t.Fatalf("ConcatToWriter: %v", err)
+	}
+
+	want := "_source,name,zip\na.csv,Ann,1\nb.csv,Bob,2\nb.csv,Cy,3\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if len(stats.Files) != 2 || stats.Files[1].RowsRead != 2 || stats.RowsWritten() != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+func TestConcatToWriter_SourceColumnName(t *testing.T) {
+	jan := writeTemp(t, "jan.csv", "id\n1\n")
+	feb := writeTemp(t, "feb.csv", "id\n2\n")
+
+	var out bytes.Buffer
+	if _, err := ConcatToWriter([]string{jan, feb}, &out, ConcatOptions{AddSourceColumn: true, SourceColumnName: "month_file"}); err != nil {
+		t.Fatalf("ConcatToWriter: %v", err)
+	}
+
+	want := "month_file,id\njan.csv,1\nfeb.csv,2\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestConcatToWriter_Schema(t *testing.T) {
+	a := writeTemp(t, "a.csv", "name,zip\nAnn,1\n")
+	b := writeTemp(t, "b.csv", "name,zip,extra\nBob,2,x\n")
+
+	var out bytes.Buffer
+	_, err := ConcatToWriter([]string{a, b}, &out, ConcatOptions{})
+	if err == nil || !strings.Contains(err.Error(), "header mismatch") {
+		t.Fatalf("err = %v, want header mismatch", err)
+	}
+
+	out.Reset()
+	if _, err := ConcatToWriter([]string{a, b}, &out, ConcatOptions{IgnoreSchema: true}); err != nil {
+		t.Fatalf("IgnoreSchema: %v", err)
+	}
+	if want := "name,zip\nAnn,1\nBob,2\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestConcatFiles_NoInputs(t *testing.T) {
+	if _, err := ConcatFiles(nil, "unused.csv", ConcatOptions{}); err == nil {
+		t.Fatalf("expected error for empty input list")
+	}
+}
+
+func TestConcatFiles_MixedDelimiters(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "crm.csv")
+	tsvPath := filepath.Join(dir, "db.tsv")
+	if err := os.WriteFile(csvPath, []byte("name,city\nAnn,\"Troy, NY\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tsvPath, []byte("name\tcity\nBob\tAlbany\nCy\tUtica\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out.csv")
+
+	stats, err := ConcatFiles([]string{csvPath, tsvPath}, out, ConcatOptions{
+		FileOptions: []Options{{}, {Delimiter: '\t'}},
+	})
+	if err != nil {
+		t.Fatalf("ConcatFiles: %v", err)
+	}
+	if len(stats.Files) != 2 || stats.RowsWritten() != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "name,city\nAnn,\"Troy, NY\"\nBob,Albany\nCy,Utica\n"
+	if string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestConcatFiles_ReusesLastFileOptions(t *testing.T) {
+	a := writeTemp(t, "a.tsv", "id\tv\n1\tx\n")
+	b := writeTemp(t, "b.tsv", "id\tv\n2\ty\n")
+	out := filepath.Join(t.TempDir(), "out.tsv")
+
+	_, err := ConcatFiles([]string{a, b}, out, ConcatOptions{
+		Options:     Options{Delimiter: '\t'},
+		FileOptions: []Options{{Delimiter: '\t'}},
+	})
+	if err != nil {
+		t.Fatalf("ConcatFiles: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "id\tv\n1\tx\n2\ty\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
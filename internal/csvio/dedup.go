@@ -0,0 +1,122 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements removing duplicate rows by a key. Rows stream through,
+// but every distinct key seen is remembered; see DeduplicateFile.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DedupeStats summarizes a deduplication.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - RowsKept counts rows written (first occurrence of each key).
+//   - RowsDropped counts rows skipped as duplicates.
+type DedupeStats struct {
+	RowsRead    int
+	RowsKept    int
+	RowsDropped int
+}
+
+// DeduplicateFile writes inputPath to outputPath keeping only the first row
+// for each distinct key. The key is the tuple of values in the keys columns
+// (case-insensitive names); when keys is empty, every column is part of the
+// key, so only exact duplicate rows are removed.
+//
+// Key values are compared exactly; normalize case or whitespace beforehand
+// if "Ann@X.com" and "ann@x.com" should match.
+//
+// Memory: output is streamed, but a set of every distinct key is kept, so
+// memory grows with the number of unique keys (not the number of rows). For
+// files with tens of millions of unique keys, deduplicating on a narrow key
+// column is considerably cheaper than on whole rows.
+func DeduplicateFile(inputPath, outputPath string, keys []string, opts Options) (DedupeStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts)
+	if err != nil {
+		return DedupeStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return DedupeStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return DeduplicateReader(in, out, keys, opts)
+}
+
+// DeduplicateReader is DeduplicateFile for an already-open input and output,
+// e.g. when writing to stdout. r is raw input; opts.Encoding is applied.
+func DeduplicateReader(r io.Reader, w io.Writer, keys []string, opts Options) (DedupeStats, error) {
+	in, err := inputReader(r, opts)
+	if err != nil {
+		return DedupeStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts), opts)
+	if err != nil {
+		return DedupeStats{}, err
+	}
+
+	keyIdx, err := ColumnIndices(headers, keys)
+	if err != nil {
+		return DedupeStats{}, err
+	}
+	if len(keyIdx) == 0 {
+		for i := range headers {
+			keyIdx = append(keyIdx, i)
+		}
+	}
+
+	cw := newWriter(w, opts)
+	defer cw.Flush()
+
+	if err := cw.Write(headers); err != nil {
+		return DedupeStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	stats := DedupeStats{}
+	seen := make(map[string]struct{})
+	parts := make([]string, len(keyIdx))
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		rec = normalizeRow(rec, len(headers))
+		for i, idx := range keyIdx {
+			parts[i] = rec[idx]
+		}
+		// NUL cannot appear in text CSV, so joining on it cannot make two
+		// different tuples collide (unlike "a,b" + "c" vs "a" + "b,c").
+		key := strings.Join(parts, "\x00")
+		if _, dup := seen[key]; dup {
+			stats.RowsDropped++
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if err := cw.Write(rec); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+		stats.RowsKept++
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,63 @@
+package csvio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDeduplicateReader(t *testing.T) {
+	const input = "name,email\n" +
+		"Ann,a@x.com\n" +
+		"Ann B,a@x.com\n" +
+		"Bob,b@x.com\n" +
+		"Bob,b@x.com\n"
+
+	tests := []struct {
+		name    string
+		keys    []string
+		want    string
+		dropped int
+	}{
+		{
+			name:    "by key",
+			keys:    []string{"Email"},
+			want:    "name,email\nAnn,a@x.com\nBob,b@x.com\n",
+			dropped: 2,
+		},
+		{
+			name:    "whole row",
+			want:    "name,email\nAnn,a@x.com\nAnn B,a@x.com\nBob,b@x.com\n",
+			dropped: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			stats, err := DeduplicateReader(strings.NewReader(input), &out, tt.keys, Options{})
+			if err != nil {
+				t.Fatalf("DeduplicateReader: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+			if stats.RowsRead != 4 || stats.RowsDropped != tt.dropped || stats.RowsKept != 4-tt.dropped {
+				t.Fatalf("stats = %+v", stats)
+			}
+		})
+	}
+}
+
+func TestDeduplicateReader_KeySeparatorAvoidsCollisions(t *testing.T) {
+	in := strings.NewReader("a,b\n\"x,y\",z\nx,\"y,z\"\n")
+	var out bytes.Buffer
+
+	stats, err := DeduplicateReader(in, &out, nil, Options{})
+	if err != nil {
+		t.Fatalf("DeduplicateReader: %v", err)
+	}
+	if stats.RowsDropped != 0 {
+		t.Fatalf("distinct rows treated as duplicates: %+v", stats)
+	}
+}
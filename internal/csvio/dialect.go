@@ -0,0 +1,145 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file guesses the dialect of a file nobody documented: which delimiter
+// it uses and whether it starts with a header row.
+package csvio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// dialectSniffBytes is how much input DetectDialect inspects.
+const dialectSniffBytes = 4096
+
+// dialectDelimiters are the delimiters DetectDialect chooses between. On a
+// tie the earlier one wins, so comma is preferred.
+var dialectDelimiters = []rune{',', '\t', '|', ';'}
+
+// Dialect describes the shape of a delimited text file.
+type Dialect struct {
+	// Delimiter is the field separator.
+	Delimiter rune
+
+	// HasHeader reports whether the first record looks like a header row,
+	// as decided by LikelyHasHeader.
+	HasHeader bool
+}
+
+// DetectDialect guesses the dialect of the data in r from its first 4096
+// bytes.
+//
+// Each candidate delimiter (comma, tab, pipe, semicolon) is counted on every
+// complete line of the sample, ignoring quoted text. A delimiter that is
+// missing from any line is ruled out; of the rest, the one whose per-line
+// count varies least (lowest standard deviation) wins, and on a tie the one
+// that splits lines into more fields. When no candidate appears on every
+// line, or the sample is empty, the result is a comma. r is raw input: it is
+// not decompressed or transcoded.
+func DetectDialect(r io.ReaderAt) (Dialect, error) {
+	buf := make([]byte, dialectSniffBytes)
+	n, err := r.ReadAt(buf, 0)
+	if err != nil && err != io.EOF {
+		return Dialect{}, fmt.Errorf("read csv: %w", err)
+	}
+	buf = bytes.TrimPrefix(buf[:n], utf8BOM)
+
+	lines := sampleLines(string(buf), n == dialectSniffBytes)
+	d := Dialect{Delimiter: sniffDelimiter(lines), HasHeader: true}
+
+	if len(lines) > 0 {
+		first := splitLine(lines[0], d.Delimiter)
+		var second []string
+		if len(lines) > 1 {
+			second = splitLine(lines[1], d.Delimiter)
+		}
+		d.HasHeader = LikelyHasHeader(first, second)
+	}
+	return d, nil
+}
+
+// sampleLines splits a sample into its non-blank lines. When truncated is
+// set the sample was cut off mid-file, so its last line is dropped as
+// possibly incomplete (unless it is the only one).
+func sampleLines(s string, truncated bool) []string {
+	raw := strings.Split(s, "\n")
+	if truncated && len(raw) > 1 {
+		raw = raw[:len(raw)-1]
+	}
+
+	var lines []string
+	for _, ln := range raw {
+		ln = strings.TrimSuffix(ln, "\r")
+		if strings.TrimSpace(ln) != "" {
+			lines = append(lines, ln)
+		}
+	}
+	return lines
+}
+
+// sniffDelimiter implements the DetectDialect choice of delimiter.
+func sniffDelimiter(lines []string) rune {
+	best, bestDev, bestMean := ',', math.Inf(1), 0.0
+	for _, delim := range dialectDelimiters {
+		counts := make([]float64, len(lines))
+		present := len(lines) > 0
+		for i, ln := range lines {
+			counts[i] = float64(countUnquoted(ln, delim))
+			if counts[i] == 0 {
+				present = false
+				break
+			}
+		}
+		if !present {
+			continue
+		}
+
+		mean, dev := meanStddev(counts)
+		if dev < bestDev || dev == bestDev && mean > bestMean {
+			best, bestDev, bestMean = delim, dev, mean
+		}
+	}
+	return best
+}
+
+// countUnquoted counts the occurrences of delim in line outside double
+// quotes.
+func countUnquoted(line string, delim rune) int {
+	n, quoted := 0, false
+	for _, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == delim && !quoted:
+			n++
+		}
+	}
+	return n
+}
+
+// splitLine splits one line of text on delim, honoring double quotes, for
+// the header guess. Records spanning several lines are not reassembled.
+func splitLine(line string, delim rune) []string {
+	rec, err := newReader(strings.NewReader(line), Options{Delimiter: delim, LazyQuotes: true}).Read()
+	if err != nil {
+		return nil
+	}
+	return rec
+}
+
+// meanStddev returns the mean and population standard deviation of xs,
+// which must not be empty.
+func meanStddev(xs []float64) (mean, dev float64) {
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+
+	for _, x := range xs {
+		dev += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(dev / float64(len(xs)))
+}
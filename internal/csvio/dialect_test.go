@@ -0,0 +1,85 @@
+package csvio
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Dialect
+	}{
+		{
+			name:    "comma",
+			content: "name,city,zip\nAnn,Albany,12207\nBob,Troy,12180\n",
+			want:    Dialect{Delimiter: ',', HasHeader: true},
+		},
+		{
+			name:    "tab",
+			content: "name\tnote\nAnn\thi, there\nBob\tok\n",
+			want:    Dialect{Delimiter: '\t', HasHeader: true},
+		},
+		{
+			name:    "pipe",
+			content: "id|amount\n1|2.50\n2|3.75\n",
+			want:    Dialect{Delimiter: '|', HasHeader: true},
+		},
+		{
+			name:    "semicolon with decimal commas",
+			content: "name;price;qty\nAnn;1,50;2\nBob;2,75;10\nCy;3,00;1\n",
+			want:    Dialect{Delimiter: ';', HasHeader: true},
+		},
+		{
+			name:    "quoted delimiters are ignored",
+			content: "name;address\nAnn;\"1 Main St, Apt 2, Albany\"\nBob;\"5 Elm St\"\n",
+			want:    Dialect{Delimiter: ';', HasHeader: true},
+		},
+		{
+			name:    "no header",
+			content: "1;Ann\n2;Bob\n",
+			want:    Dialect{Delimiter: ';', HasHeader: false},
+		},
+		{
+			name:    "single column defaults to comma",
+			content: "email\nann@x.com\n",
+			want:    Dialect{Delimiter: ',', HasHeader: true},
+		},
+		{
+			name:    "empty",
+			content: "",
+			want:    Dialect{Delimiter: ',', HasHeader: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectDialect(strings.NewReader(tt.content))
+			if err != nil {
+				t.Fatalf("DetectDialect: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("DetectDialect = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectDialect_TruncatedSample(t *testing.T) {
+	// Only the first 4096 bytes are read; the cut-off last line must not
+	// count against the real delimiter.
+	var b strings.Builder
+	b.WriteString("a|b|c\n")
+	for b.Len() < 2*dialectSniffBytes {
+		b.WriteString("1|2|3\n")
+	}
+
+	got, err := DetectDialect(strings.NewReader("x" + b.String()))
+	if err != nil {
+		t.Fatalf("DetectDialect: %v", err)
+	}
+	if got.Delimiter != '|' {
+		t.Fatalf("Delimiter = %q, want '|'", got.Delimiter)
+	}
+}
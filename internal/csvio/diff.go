@@ -0,0 +1,312 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file compares two files row-by-row, matching rows by a key column.
+// The first file is indexed in memory; the second is streamed.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DiffKind classifies a DiffRow.
+type DiffKind string
+
+const (
+	DiffAdded   DiffKind = "added"
+	DiffRemoved DiffKind = "removed"
+	DiffChanged DiffKind = "changed"
+)
+
+// DiffOptions controls DiffFiles.
+type DiffOptions struct {
+	// Options controls how both files are parsed, unless FileOptions is
+	// set.
+	Options
+
+	// FileOptions holds the read options of the first and second file, in
+	// that order, for inputs in different dialects. A single entry applies
+	// to both.
+	FileOptions []Options
+
+	// IgnoreCols names columns (case-insensitive) left out of the
+	// comparison, e.g. generated timestamps. Unknown names are ignored.
+	IgnoreCols []string
+}
+
+// DiffRow is one differing row.
+//
+// A holds the row from the first file and B the row from the second; the
+// side a row is missing from is nil. For changed rows, Columns lists the
+// names of the columns whose values differ, in the first file's order.
+type DiffRow struct {
+	Kind    DiffKind `json:"kind"`
+	Key     string   `json:"key"`
+	A       []string `json:"a,omitempty"`
+	B       []string `json:"b,omitempty"`
+	Columns []string `json:"columns,omitempty"`
+}
+
+// DiffResult is the outcome of DiffFiles. Added and Changed follow the second
+// file's row order; Removed follows the first file's.
+type DiffResult struct {
+	Added     []DiffRow `json:"added"`
+	Removed   []DiffRow `json:"removed"`
+	Changed   []DiffRow `json:"changed"`
+	Unchanged int       `json:"unchanged"`
+}
+
+// DiffFiles compares pathA (before) with pathB (after), matching rows on
+// keyCol, which must exist in both files.
+//
+// Columns are compared by name, so reordering columns is not a change.
+// Only columns present in both files are compared. If a key occurs more than
+// once in a file, its first row is used and later ones are ignored.
+func DiffFiles(pathA, pathB string, keyCol string, opts DiffOptions) (DiffResult, error) {
+	headersA, index, order, err := indexByKey(pathA, keyCol, fileOptions(opts.Options, opts.FileOptions, 0))
+	if err != nil {
+		return DiffResult{}, err
+	}
+
+	optsB := fileOptions(opts.Options, opts.FileOptions, 1)
+	f, err := openCSV(pathB, optsB)
+	if err != nil {
+		return DiffResult{}, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headersB, r, err := readHeader(newReader(f, optsB), optsB)
+	if err != nil {
+		return DiffResult{}, fmt.Errorf("%s: %w", pathB, err)
+	}
+	keyB, err := ColumnIndex(headersB, keyCol)
+	if err != nil {
+		return DiffResult{}, fmt.Errorf("%s: %w", pathB, err)
+	}
+
+	// Pair up the compared columns: index in A, index in B.
+	ignore := make(map[string]bool, len(opts.IgnoreCols))
+	for _, c := range opts.IgnoreCols {
+		ignore[strings.ToLower(c)] = true
+	}
+	type colPair struct{ a, b int }
+	var compared []colPair
+	for i, h := range headersA {
+		if ignore[strings.ToLower(h)] {
+			continue
+		}
+		if j, err := ColumnIndex(headersB, h); err == nil {
+			compared = append(compared, colPair{a: i, b: j})
+		}
+	}
+
+	res := DiffResult{}
+	seen := make(map[string]bool, len(index))
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return res, fmt.Errorf("%s: read row: %w", pathB, err)
+		}
+
+		rec = normalizeRow(rec, len(headersB))
+		key := rec[keyB]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		a, ok := index[key]
+		if !ok {
+			res.Added = append(res.Added, DiffRow{Kind: DiffAdded, Key: key, B: rec})
+			continue
+		}
+
+		var changed []string
+		for _, p := range compared {
+			if a[p.a] != rec[p.b] {
+				changed = append(changed, headersA[p.a])
+			}
+		}
+		if len(changed) == 0 {
+			res.Unchanged++
+			continue
+		}
+		res.Changed = append(res.Changed, DiffRow{Kind: DiffChanged, Key: key, A: a, B: rec, Columns: changed})
+	}
+
+	for _, key := range order {
+		if !seen[key] {
+			res.Removed = append(res.Removed, DiffRow{Kind: DiffRemoved, Key: key, A: index[key]})
+		}
+	}
+
+	return res, nil
+}
+
+// indexByKey reads the file at path into a map from keyCol value to row,
+// keeping the first row for each key. order lists the keys in file order.
+func indexByKey(path, keyCol string, opts Options) (headers []string, index map[string][]string, order []string, err error) {
+	f, err := openCSV(path, opts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, r, err := readHeader(newReader(f, opts), opts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	key, err := ColumnIndex(headers, keyCol)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	index = make(map[string][]string)
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s: read row: %w", path, err)
+		}
+
+		rec = normalizeRow(rec, len(headers))
+		if _, dup := index[rec[key]]; dup {
+			continue
+		}
+		index[rec[key]] = rec
+		order = append(order, rec[key])
+	}
+
+	return headers, index, order, nil
+}
+
+// HeaderMove is a column present in both files at a different position
+// relative to the other shared columns. From and To are its zero-based
+// indexes in the first and second file.
+type HeaderMove struct {
+	Name string `json:"name"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// HeaderDiff is the outcome of DiffHeaders. Removed and Common follow the
+// first file's column order, Added and Moved the second's.
+type HeaderDiff struct {
+	Added   []string     `json:"added"`
+	Removed []string     `json:"removed"`
+	Common  []string     `json:"common"`
+	Moved   []HeaderMove `json:"moved"`
+}
+
+// DiffHeaders compares the header rows of pathA (before) and pathB (after).
+// Names are compared exactly, so a change of case counts as a removal plus
+// an addition.
+func DiffHeaders(pathA, pathB string, opts Options) (HeaderDiff, error) {
+	a, err := ReadHeaders(pathA, opts)
+	if err != nil {
+		return HeaderDiff{}, fmt.Errorf("%s: %w", pathA, err)
+	}
+	b, err := ReadHeaders(pathB, opts)
+	if err != nil {
+		return HeaderDiff{}, fmt.Errorf("%s: %w", pathB, err)
+	}
+	return diffHeaders(a, b), nil
+}
+
+// diffHeaders implements DiffHeaders for header slices.
+//
+// A column counts as moved only if it is out of order relative to the other
+// shared columns: adding or removing a column shifts the absolute position
+// of everything after it, which is not a move. The shared columns kept in
+// place are a longest common subsequence of the two orders; every other
+// shared column moved. This reports the fewest moves, e.g. taking one column
+// from the end to the front moves just that column.
+func diffHeaders(a, b []string) HeaderDiff {
+	posA := firstPositions(a)
+	posB := firstPositions(b)
+
+	var d HeaderDiff
+	var sharedA, sharedB []string
+	for i, h := range a {
+		if posA[h] != i {
+			continue // duplicate name: only the first occurrence counts
+		}
+		if _, ok := posB[h]; ok {
+			d.Common = append(d.Common, h)
+			sharedA = append(sharedA, h)
+		} else {
+			d.Removed = append(d.Removed, h)
+		}
+	}
+	for i, h := range b {
+		if posB[h] != i {
+			continue
+		}
+		if _, ok := posA[h]; ok {
+			sharedB = append(sharedB, h)
+		} else {
+			d.Added = append(d.Added, h)
+		}
+	}
+
+	kept := longestCommonSubsequence(sharedA, sharedB)
+	for _, h := range sharedB {
+		if !kept[h] {
+			d.Moved = append(d.Moved, HeaderMove{Name: h, From: posA[h], To: posB[h]})
+		}
+	}
+
+	return d
+}
+
+// firstPositions maps each name to the index of its first occurrence.
+func firstPositions(names []string) map[string]int {
+	pos := make(map[string]int, len(names))
+	for i, n := range names {
+		if _, ok := pos[n]; !ok {
+			pos[n] = i
+		}
+	}
+	return pos
+}
+
+// longestCommonSubsequence returns the set of names in one longest common
+// subsequence of a and b, which must each hold distinct names. Header rows
+// are short, so the quadratic table is fine.
+func longestCommonSubsequence(a, b []string) map[string]bool {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	kept := make(map[string]bool, lcs[0][0])
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			kept[a[i]] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return kept
+}
@@ -0,0 +1,110 @@
+package csvio
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiffFiles(t *testing.T) {
+	a := writeTemp(t, "a.csv", "id,name,updated\n"+
+		"1,Ann,mon\n"+
+		"2,Bob,mon\n"+
+		"3,Cy,mon\n")
+	// Columns reordered; row 2 changed, row 3 removed, row 4 added.
+	b := writeTemp(t, "b.csv", "updated,id,name\n"+
+		"tue,1,Ann\n"+
+		"tue,2,Bobby\n"+
+		"tue,4,Di\n")
+
+	res, err := DiffFiles(a, b, "ID", DiffOptions{IgnoreCols: []string{"updated"}})
+	if err != nil {
+		t.Fatalf("DiffFiles: %v", err)
+	}
+
+	want := DiffResult{
+		Added:     []DiffRow{{Kind: DiffAdded, Key: "4", B: []string{"tue", "4", "Di"}}},
+		Removed:   []DiffRow{{Kind: DiffRemoved, Key: "3", A: []string{"3", "Cy", "mon"}}},
+		Changed:   []DiffRow{{Kind: DiffChanged, Key: "2", A: []string{"2", "Bob", "mon"}, B: []string{"tue", "2", "Bobby"}, Columns: []string{"name"}}},
+		Unchanged: 1,
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Fatalf("diff mismatch\n got: %+v\nwant: %+v", res, want)
+	}
+}
+
+func TestDiffFiles_MissingKey(t *testing.T) {
+	a := writeTemp(t, "a.csv", "id\n1\n")
+	b := writeTemp(t, "b.csv", "name\nAnn\n")
+
+	_, err := DiffFiles(a, b, "id", DiffOptions{})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
+
+func TestDiffHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want HeaderDiff
+	}{
+		{
+			name: "identical",
+			a:    []string{"id", "name"},
+			b:    []string{"id", "name"},
+			want: HeaderDiff{Common: []string{"id", "name"}},
+		},
+		{
+			name: "added and removed without moves",
+			a:    []string{"id", "fax", "name", "email"},
+			b:    []string{"id", "name", "mobile", "email"},
+			want: HeaderDiff{
+				Added:   []string{"mobile"},
+				Removed: []string{"fax"},
+				Common:  []string{"id", "name", "email"},
+			},
+		},
+		{
+			name: "one column moved to the front",
+			a:    []string{"id", "name", "email", "zip"},
+			b:    []string{"zip", "id", "name", "email"},
+			want: HeaderDiff{
+				Common: []string{"id", "name", "email", "zip"},
+				Moved:  []HeaderMove{{Name: "zip", From: 3, To: 0}},
+			},
+		},
+		{
+			name: "case change is not a match",
+			a:    []string{"Email"},
+			b:    []string{"email"},
+			want: HeaderDiff{Added: []string{"email"}, Removed: []string{"Email"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffHeaders(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("diffHeaders =\n %+v\nwant\n %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffHeaders_Files(t *testing.T) {
+	a := writeTemp(t, "a.csv", "id;name;email\n1;Ann;a@x\n")
+	b := writeTemp(t, "b.csv", "email;id;name;zip\na@x;1;Ann;12207\n")
+
+	got, err := DiffHeaders(a, b, Options{Delimiter: ';'})
+	if err != nil {
+		t.Fatalf("DiffHeaders: %v", err)
+	}
+	want := HeaderDiff{
+		Added:  []string{"zip"},
+		Common: []string{"id", "name", "email"},
+		Moved:  []HeaderMove{{Name: "email", From: 2, To: 0}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DiffHeaders = %+v, want %+v", got, want)
+	}
+}
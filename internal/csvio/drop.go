@@ -0,0 +1,83 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements dropping columns, the inverse of SelectColumns. It
+// streams row-by-row.
+package csvio
+
+import (
+	"fmt"
+	"io"
+)
+
+// DropOptions controls DropColumns.
+type DropOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// Strict makes naming a column that does not exist an error (wrapping
+	// ErrColumnNotFound). By default such names are ignored, so one drop
+	// list can be applied to files that only partly share a schema.
+	Strict bool
+}
+
+// DropStats summarizes a drop operation.
+//
+//   - RowsProcessed counts data rows read (header excluded).
+//   - ColsDropped counts distinct columns removed.
+type DropStats struct {
+	RowsProcessed int
+	ColsDropped   int
+}
+
+// DropColumns writes inputPath to outputPath without the columns named in
+// cols (matched case-insensitively). Remaining columns keep their original
+// order.
+func DropColumns(inputPath, outputPath string, cols []string, opts DropOptions) (DropStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return DropStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return DropStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return DropReader(in, out, cols, opts)
+}
+
+// DropReader is DropColumns for an already-open input and output, e.g. when
+// writing to stdout. r is raw input; opts.Encoding is applied.
+func DropReader(r io.Reader, w io.Writer, cols []string, opts DropOptions) (DropStats, error) {
+	stats := DropStats{}
+
+	rows, err := projectColumns(r, w, opts.Options, func(headers []string) ([]int, error) {
+		drop := make(map[int]bool, len(cols))
+		for _, c := range cols {
+			idx, err := ColumnIndex(headers, c)
+			if err != nil {
+				if opts.Strict {
+					return nil, err
+				}
+				continue
+			}
+			drop[idx] = true
+		}
+		stats.ColsDropped = len(drop)
+
+		keep := make([]int, 0, len(headers)-len(drop))
+		for i := range headers {
+			if !drop[i] {
+				keep = append(keep, i)
+			}
+		}
+		return keep, nil
+	})
+	stats.RowsProcessed = rows
+
+	return stats, err
+}
@@ -0,0 +1,34 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDropReader(t *testing.T) {
+	const input = "name,email,phone\nAnn,a@x.com,555\n"
+
+	t.Run("lenient", func(t *testing.T) {
+		var out bytes.Buffer
+		stats, err := DropReader(strings.NewReader(input), &out, []string{"EMAIL", "missing"}, DropOptions{})
+		if err != nil {
+			t.Fatalf("DropReader: %v", err)
+		}
+		if stats.RowsProcessed != 1 || stats.ColsDropped != 1 {
+			t.Fatalf("stats = %+v", stats)
+		}
+		if want := "name,phone\nAnn,555\n"; out.String() != want {
+			t.Fatalf("output = %q, want %q", out.String(), want)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := DropReader(strings.NewReader(input), &out, []string{"missing"}, DropOptions{Strict: true})
+		if !errors.Is(err, ErrColumnNotFound) {
+			t.Fatalf("err = %v, want ErrColumnNotFound", err)
+		}
+	})
+}
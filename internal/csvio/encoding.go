@@ -0,0 +1,314 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file handles character encodings. CSV exports from legacy systems are
+// often Latin-1, Windows-1252, or UTF-16 rather than UTF-8; inputs are
+// transcoded to UTF-8 on the fly so the rest of the package only ever sees
+// UTF-8 text. Everything here is hand-rolled to keep the module free of
+// external dependencies.
+package csvio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding names understood by Options.Encoding and returned by
+// DetectEncoding.
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingUTF8BOM     = "utf-8-bom"
+	EncodingLatin1      = "latin1"
+	EncodingWindows1252 = "windows-1252"
+	EncodingUTF16LE     = "utf-16-le"
+	EncodingUTF16BE     = "utf-16-be"
+	EncodingUnknown     = "unknown"
+)
+
+// encodingSniffBytes is how much of a file DetectEncoding inspects.
+const encodingSniffBytes = 4096
+
+// DetectEncoding guesses the character encoding of the file at path from its
+// first 4096 bytes.
+//
+// The heuristics, in order:
+//
+//  1. A byte order mark identifies UTF-8 ("utf-8-bom") or UTF-16
+//     ("utf-16-le" / "utf-16-be").
+//  2. Without a BOM, text where most odd (or even) bytes are NUL is taken to
+//     be UTF-16 LE (or BE); CSV content is overwhelmingly ASCII.
+//  3. Any other NUL bytes mean the data is not CSV text: "unknown".
+//  4. Valid UTF-8 (including plain ASCII and empty files) is "utf-8".
+//  5. Invalid UTF-8 with bytes in 0x80-0x9F is "windows-1252" (those are
+//     printable characters such as curly quotes there, but unused control
+//     codes in Latin-1); otherwise "latin1".
+//
+// Latin-1 and Windows-1252 cannot be told apart reliably; the guess only
+// matters for the 0x80-0x9F range, where Windows-1252 is far more common.
+func DetectEncoding(path string) (string, error) {
+	f, err := OpenInput(path)
+	if err != nil {
+		return "", fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	return DetectEncodingReader(f)
+}
+
+// DetectEncodingReader is DetectEncoding for an already-open input. Only the
+// first bytes of r are read.
+func DetectEncodingReader(r io.Reader) (string, error) {
+	buf := make([]byte, encodingSniffBytes)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("read csv: %w", err)
+	}
+	return sniffEncoding(buf[:n]), nil
+}
+
+// sniffEncoding implements the DetectEncoding heuristics on a byte sample.
+func sniffEncoding(b []byte) string {
+	switch {
+	case bytes.HasPrefix(b, utf8BOM):
+		return EncodingUTF8BOM
+	case bytes.HasPrefix(b, []byte{0xFF, 0xFE}):
+		return EncodingUTF16LE
+	case bytes.HasPrefix(b, []byte{0xFE, 0xFF}):
+		return EncodingUTF16BE
+	}
+
+	var evenNUL, oddNUL int
+	for i, c := range b {
+		if c != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			evenNUL++
+		} else {
+			oddNUL++
+		}
+	}
+	half := len(b) / 2
+	switch {
+	case half > 0 && oddNUL*10 >= half*9:
+		return EncodingUTF16LE
+	case half > 0 && evenNUL*10 >= half*9:
+		return EncodingUTF16BE
+	}
+
+	if evenNUL+oddNUL > 0 {
+		return EncodingUnknown
+	}
+
+	// The sample may end in the middle of a multi-byte character; drop an
+	// incomplete trailing sequence before validating.
+	valid := b
+	if i := lastRuneStart(b); i >= 0 && !utf8.FullRune(b[i:]) {
+		valid = b[:i]
+	}
+	if utf8.Valid(valid) {
+		return EncodingUTF8
+	}
+
+	for _, c := range b {
+		if c >= 0x80 && c <= 0x9F {
+			return EncodingWindows1252
+		}
+	}
+	return EncodingLatin1
+}
+
+// lastRuneStart returns the index of the byte that starts the last (possibly
+// incomplete) UTF-8 sequence in b, or -1 if none is found in the last
+// utf8.UTFMax bytes.
+func lastRuneStart(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// utf8BOM is the UTF-8 encoding of U+FEFF, which Excel and other Windows
+// tools write at the start of "CSV UTF-8" exports.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ParseEncoding validates an encoding name for Options.Encoding and returns
+// its canonical form (one of the Encoding* constants). Matching is
+// case-insensitive, and the aliases "utf8", "iso-8859-1" and "cp1252" are
+// accepted. "" means UTF-8.
+func ParseEncoding(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "", EncodingUTF8, "utf8":
+		return EncodingUTF8, nil
+	case EncodingUTF8BOM:
+		return EncodingUTF8BOM, nil
+	case EncodingLatin1, "iso-8859-1":
+		return EncodingLatin1, nil
+	case EncodingWindows1252, "cp1252":
+		return EncodingWindows1252, nil
+	case EncodingUTF16LE:
+		return EncodingUTF16LE, nil
+	case EncodingUTF16BE:
+		return EncodingUTF16BE, nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q (want utf-8, latin1, windows-1252, utf-16-le or utf-16-be)", s)
+	}
+}
+
+// decodeReader wraps r so it yields UTF-8 text, transcoding from the named
+// encoding. UTF-8 input ("", "utf-8" or "utf-8-bom") is returned unchanged;
+// newReader drops a leading BOM whatever the encoding.
+func decodeReader(r io.Reader, encoding string) (io.Reader, error) {
+	enc, err := ParseEncoding(encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	switch enc {
+	case EncodingLatin1:
+		return &byteDecoder{r: r, table: nil}, nil
+	case EncodingWindows1252:
+		return &byteDecoder{r: r, table: &windows1252}, nil
+	case EncodingUTF16LE:
+		return &utf16Decoder{r: bufio.NewReader(r), bigEndian: false}, nil
+	case EncodingUTF16BE:
+		return &utf16Decoder{r: bufio.NewReader(r), bigEndian: true}, nil
+	default:
+		return r, nil
+	}
+}
+
+// OpenReaderWithEncoding opens path like OpenInput ("-" is stdin, gzip is
+// decompressed) and transcodes its content from encoding to UTF-8 (see
+// ParseEncoding; "" means UTF-8, passed through unchanged). Callers must
+// Close the result.
+func OpenReaderWithEncoding(path, encoding string) (io.ReadCloser, error) {
+	return openCSV(path, Options{Encoding: encoding})
+}
+
+// stripBOM returns a reader over r without its leading UTF-8 BOM, if any.
+// Input without a BOM is passed through byte for byte.
+func stripBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(b, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	return br
+}
+
+// windows1252 maps bytes 0x80-0x9F to their Windows-1252 characters. Bytes
+// outside that range are identical to Latin-1. Undefined positions map to
+// U+FFFD.
+var windows1252 = [32]rune{
+	'€', '�', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '�', 'Ž', '�',
+	'�', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '�', 'ž', 'Ÿ',
+}
+
+// byteDecoder transcodes a single-byte encoding (Latin-1, or Windows-1252 when
+// table is set) to UTF-8.
+type byteDecoder struct {
+	r     io.Reader
+	table *[32]rune
+	in    [1024]byte
+	out   []byte
+}
+
+func (d *byteDecoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		n, err := d.r.Read(d.in[:])
+		for _, c := range d.in[:n] {
+			r := rune(c)
+			if d.table != nil && c >= 0x80 && c <= 0x9F {
+				r = d.table[c-0x80]
+			}
+			d.out = utf8.AppendRune(d.out, r)
+		}
+		if n == 0 && err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// utf16Decoder transcodes UTF-16 (either byte order) to UTF-8. A leading BOM
+// is dropped and unpaired surrogates become U+FFFD.
+type utf16Decoder struct {
+	r         *bufio.Reader
+	bigEndian bool
+	started   bool
+	out       []byte
+
+	// pending is a unit read while looking for a low surrogate that turned
+	// out not to be one; it is decoded next.
+	pending    uint16
+	hasPending bool
+}
+
+func (d *utf16Decoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		u, err := d.unit()
+		if err != nil {
+			return 0, err
+		}
+		if !d.started {
+			d.started = true
+			if u == 0xFEFF {
+				continue
+			}
+		}
+
+		r := rune(u)
+		switch {
+		case isHighSurrogate(u):
+			lo, err := d.unit()
+			if err != nil && err != io.EOF {
+				return 0, err
+			}
+			if err == nil && isLowSurrogate(lo) {
+				r = utf16.DecodeRune(r, rune(lo))
+				break
+			}
+			r = utf8.RuneError
+			if err == nil {
+				d.pending, d.hasPending = lo, true
+			}
+		case isLowSurrogate(u):
+			r = utf8.RuneError
+		}
+		d.out = utf8.AppendRune(d.out, r)
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// unit reads one 16-bit code unit, or returns the pending one. A trailing
+// odd byte is reported as io.ErrUnexpectedEOF.
+func (d *utf16Decoder) unit() (uint16, error) {
+	if d.hasPending {
+		d.hasPending = false
+		return d.pending, nil
+	}
+	var b [2]byte
+	if _, err := io.ReadFull(d.r, b[:]); err != nil {
+		return 0, err
+	}
+	if d.bigEndian {
+		return uint16(b[0])<<8 | uint16(b[1]), nil
+	}
+	return uint16(b[1])<<8 | uint16(b[0]), nil
+}
+
+// isHighSurrogate and isLowSurrogate classify UTF-16 code units; only a high
+// surrogate followed by a low one is a valid pair.
+func isHighSurrogate(u uint16) bool { return u >= 0xD800 && u <= 0xDBFF }
+
+func isLowSurrogate(u uint16) bool { return u >= 0xDC00 && u <= 0xDFFF }
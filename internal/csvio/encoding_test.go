@@ -0,0 +1,175 @@
+package csvio
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "utf-8 bom", content: "\xef\xbb\xbfname\nJosé\n", want: EncodingUTF8BOM},
+		{name: "plain utf-8", content: "name\nJosé\n", want: EncodingUTF8},
+		{name: "ascii", content: "name\nJose\n", want: EncodingUTF8},
+		{name: "latin1", content: "name\nJos\xe9\n", want: EncodingLatin1},
+		{name: "windows-1252", content: "name\n\x93quoted\x94\n", want: EncodingWindows1252},
+		{name: "utf-16-le bom", content: "\xff\xfen\x00\n\x00", want: EncodingUTF16LE},
+		{name: "utf-16-be no bom", content: "\x00n\x00a\x00m\x00e\x00\n", want: EncodingUTF16BE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTemp(t, "in.csv", tt.content)
+			got, err := DetectEncoding(path)
+			if err != nil {
+				t.Fatalf("DetectEncoding: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadHead_Encoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		encoding string
+		want     string
+	}{
+		{name: "latin1", content: "name\nJos\xe9\n", encoding: EncodingLatin1, want: "José"},
+		{name: "windows-1252", content: "name\n\x93hi\x94\n", encoding: EncodingWindows1252, want: "“hi”"},
+		{name: "utf-8 bom", content: "\xef\xbb\xbfname\nJosé\n", encoding: EncodingUTF8BOM, want: "José"},
+		{name: "utf-16-le", content: "\xff\xfen\x00a\x00m\x00e\x00\n\x00J\x00\xe9\x00\n\x00", encoding: EncodingUTF16LE, want: "Jé"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTemp(t, "in.csv", tt.content)
+			headers, rows, err := ReadHead(path, 1, ReadOptions{Options: Options{Encoding: tt.encoding}})
+			if err != nil {
+				t.Fatalf("ReadHead: %v", err)
+			}
+			if !reflect.DeepEqual(headers, []string{"name"}) {
+				t.Fatalf("headers = %q", headers)
+			}
+			if len(rows) != 1 || rows[0][0] != tt.want {
+				t.Fatalf("rows = %q, want %q", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadHead_UTF16UnpairedSurrogate(t *testing.T) {
+	// "a,b\n" with an unpaired high surrogate before the comma, then a lone
+	// low surrogate and a valid pair (U+1F600) in the second row.
+	content := "a\x00,\x00b\x00\n\x00" +
+		"a\x00\x00\xd8,\x00b\x00\n\x00" +
+		"\x00\xdc,\x00\x3d\xd8\x00\xde\n\x00"
+	path := writeTemp(t, "in.csv", content)
+
+	_, rows, err := ReadHead(path, 5, ReadOptions{Options: Options{Encoding: EncodingUTF16LE}})
+	if err != nil {
+		t.Fatalf("ReadHead: %v", err)
+	}
+	want := [][]string{{"a\uFFFD", "b"}, {"\uFFFD", "\U0001F600"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestReadHead_UnsupportedEncoding(t *testing.T) {
+	path := writeTemp(t, "in.csv", "name\n")
+	if _, _, err := ReadHead(path, 1, ReadOptions{Options: Options{Encoding: "ebcdic"}}); err == nil {
+		t.Fatalf("expected error for unsupported encoding")
+	}
+}
+
+func TestOpenReaderWithEncoding(t *testing.T) {
+	// "name,city\nJosé,Montréal\n" in Latin-1.
+	raw := []byte{'n', 'a', 'm', 'e', ',', 'c', 'i', 't', 'y', '\n', 'J', 'o', 's', 0xE9, ',', 'M', 'o', 'n', 't', 'r', 0xE9, 'a', 'l', '\n'}
+	path := writeTemp(t, "in.csv", string(raw))
+
+	f, err := OpenReaderWithEncoding(path, "ISO-8859-1")
+	if err != nil {
+		t.Fatalf("OpenReaderWithEncoding: %v", err)
+	}
+	defer f.Close()
+
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !utf8.Valid(got) {
+		t.Fatalf("output is not valid UTF-8: %q", got)
+	}
+	if want := "name,city\nJosé,Montréal\n"; string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if _, err := OpenReaderWithEncoding(path, "ebcdic"); err == nil {
+		t.Fatal("expected error for unsupported encoding")
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":             EncodingUTF8,
+		"UTF8":         EncodingUTF8,
+		"latin1":       EncodingLatin1,
+		"cp1252":       EncodingWindows1252,
+		"Windows-1252": EncodingWindows1252,
+	}
+	for in, want := range tests {
+		if got, err := ParseEncoding(in); err != nil || got != want {
+			t.Errorf("ParseEncoding(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseEncoding("ebcdic"); err == nil {
+		t.Error("ParseEncoding(ebcdic): expected error")
+	}
+}
+
+func TestReadAllFromReader_StripsUTF8BOM(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "bom", content: "\xef\xbb\xbfname,email\nAlice,a@b.com"},
+		{name: "no bom", content: "name,email\nAlice,a@b.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, rows, err := ReadAllFromReader(strings.NewReader(tt.content), Options{})
+			if err != nil {
+				t.Fatalf("ReadAllFromReader: %v", err)
+			}
+			if !reflect.DeepEqual(headers, []string{"name", "email"}) {
+				t.Fatalf("headers = %q", headers)
+			}
+			if !reflect.DeepEqual(rows, [][]string{{"Alice", "a@b.com"}}) {
+				t.Fatalf("rows = %q", rows)
+			}
+		})
+	}
+}
+
+func TestReadHeaders_StripsUTF8BOM(t *testing.T) {
+	path := writeTemp(t, "in.csv", "\xef\xbb\xbfname,email\n")
+
+	headers, err := ReadHeaders(path, Options{})
+	if err != nil {
+		t.Fatalf("ReadHeaders: %v", err)
+	}
+	if headers[0] != "name" {
+		t.Fatalf("first header = %q, want %q", headers[0], "name")
+	}
+}
@@ -0,0 +1,141 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements adding a computed column from an expr.Expr, e.g.
+// score = clicks / impressions. It streams like NullifyFile.
+package csvio
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/expr"
+)
+
+// EvalOptions controls EvalColumn.
+type EvalOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// Column is the header of the computed column. If a column with that
+	// name exists (case-insensitive) its values are replaced in place;
+	// otherwise the column is appended. Required.
+	Column string
+
+	// Expr computes the value for each row. Its column references are
+	// resolved case-insensitively against the input headers. Required.
+	Expr *expr.Expr
+}
+
+// EvalStats summarizes an eval.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - EvalErrors counts rows where the expression could not be evaluated,
+//     e.g. division by zero or arithmetic on text. Those rows get an empty
+//     cell.
+//   - FirstError is the first such error, with its row number, or nil.
+type EvalStats struct {
+	RowsRead   int
+	EvalErrors int
+	FirstError error
+}
+
+// EvalColumn writes inputPath to outputPath with opts.Column set to the
+// value of opts.Expr on every row.
+func EvalColumn(inputPath, outputPath string, opts EvalOptions) (EvalStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return EvalStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return EvalStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return EvalColumnReader(in, out, opts)
+}
+
+// EvalColumnReader is EvalColumn for an already-open input and output, e.g.
+// when writing to stdout. r is raw input; opts.Encoding is applied.
+func EvalColumnReader(r io.Reader, w io.Writer, opts EvalOptions) (EvalStats, error) {
+	if opts.Column == "" || opts.Expr == nil {
+		return EvalStats{}, fmt.Errorf("eval: column and expression are required")
+	}
+
+	in, err := inputReader(r, opts.Options)
+	if err != nil {
+		return EvalStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return EvalStats{}, err
+	}
+
+	refs := make(map[string]int, len(opts.Expr.Columns()))
+	for _, name := range opts.Expr.Columns() {
+		i, err := ColumnIndex(headers, name)
+		if err != nil {
+			return EvalStats{}, err
+		}
+		refs[name] = i
+	}
+
+	target, err := ColumnIndex(headers, opts.Column)
+	width := len(headers)
+	outHeaders := headers
+	if err != nil {
+		target = width
+		outHeaders = append(append([]string(nil), headers...), opts.Column)
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	if err := cw.Write(outHeaders); err != nil {
+		return EvalStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	stats := EvalStats{}
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		rec = normalizeRow(rec, width)
+		v, err := opts.Expr.Eval(func(name string) string { return rec[refs[name]] })
+		cell := v.String()
+		if err != nil {
+			cell = ""
+			stats.EvalErrors++
+			if stats.FirstError == nil {
+				stats.FirstError = fmt.Errorf("row %d: %w", stats.RowsRead, err)
+			}
+		}
+		if target == width {
+			rec = append(rec, cell)
+		} else {
+			rec[target] = cell
+		}
+
+		if err := cw.Write(rec); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
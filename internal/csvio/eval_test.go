@@ -0,0 +1,77 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bensabler/go-mail/internal/expr"
+)
+
+func TestEvalColumnReader(t *testing.T) {
+	const input = "first,last,clicks,impressions\nAnn,Lee,3,12\nBob,Ray,1,0\n"
+
+	tests := []struct {
+		name   string
+		column string
+		src    string
+		want   string
+		errs   int
+	}{
+		{
+			name:   "append",
+			column: "full_name",
+			src:    `FIRST + " " + last`,
+			want:   "first,last,clicks,impressions,full_name\nAnn,Lee,3,12,Ann Lee\nBob,Ray,1,0,Bob Ray\n",
+		},
+		{
+			name:   "replace in place",
+			column: "Clicks",
+			src:    `clicks * 2`,
+			want:   "first,last,clicks,impressions\nAnn,Lee,6,12\nBob,Ray,2,0\n",
+		},
+		{
+			name:   "row errors leave the cell empty",
+			column: "ctr",
+			src:    `clicks / impressions`,
+			want:   "first,last,clicks,impressions,ctr\nAnn,Lee,3,12,0.25\nBob,Ray,1,0,\n",
+			errs:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := expr.Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			var out bytes.Buffer
+			stats, err := EvalColumnReader(strings.NewReader(input), &out, EvalOptions{Column: tt.column, Expr: e})
+			if err != nil {
+				t.Fatalf("EvalColumnReader: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+			if stats.RowsRead != 2 || stats.EvalErrors != tt.errs {
+				t.Fatalf("stats = %+v", stats)
+			}
+			if tt.errs > 0 && (stats.FirstError == nil || !strings.HasPrefix(stats.FirstError.Error(), "row 2: ")) {
+				t.Fatalf("FirstError = %v", stats.FirstError)
+			}
+		})
+	}
+}
+
+func TestEvalColumnReader_UnknownColumn(t *testing.T) {
+	e, err := expr.Parse("a + missing")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	_, err = EvalColumnReader(strings.NewReader("a\n1\n"), &bytes.Buffer{}, EvalOptions{Column: "b", Expr: e})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
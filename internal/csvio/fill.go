@@ -0,0 +1,211 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements filling NULL cells: with a constant, with the previous
+// non-null value (forward fill), or with the next one (backward fill).
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+// FillStrategy selects how FillFile replaces NULL cells.
+type FillStrategy string
+
+const (
+	// FillValue replaces NULL cells with FillOptions.Value.
+	FillValue FillStrategy = "value"
+
+	// FillForward carries the last non-null value down. It streams.
+	FillForward FillStrategy = "ffill"
+
+	// FillBackward carries the next non-null value up. Every row is held in
+	// memory, because a cell's value is only known once a later row has been
+	// read.
+	FillBackward FillStrategy = "bfill"
+)
+
+// FillOptions controls FillFile.
+type FillOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// Columns lists the columns to fill (case-insensitive). Required.
+	Columns []string
+
+	// Strategy selects the fill method. Required.
+	Strategy FillStrategy
+
+	// Value is the replacement for FillValue.
+	Value string
+
+	// Policy decides which cells are NULL, as for NullifyFile.
+	Policy nulls.Policy
+}
+
+// FillStats summarizes a fill.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - CellsFilled maps each filled column's header to the number of cells
+//     replaced. NULL cells with nothing to carry (e.g. before the first
+//     non-null value in forward fill) are left as-is and not counted.
+type FillStats struct {
+	RowsRead    int
+	CellsFilled map[string]int
+}
+
+// FillFile writes inputPath to outputPath with NULL cells in opts.Columns
+// replaced according to opts.Strategy.
+func FillFile(inputPath, outputPath string, opts FillOptions) (FillStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return FillStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return FillStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return FillReader(in, out, opts)
+}
+
+// FillReader is FillFile for an already-open input and output, e.g. when
+// writing to stdout. r is raw input; opts.Encoding is applied.
+func FillReader(r io.Reader, w io.Writer, opts FillOptions) (FillStats, error) {
+	switch opts.Strategy {
+	case FillValue, FillForward, FillBackward:
+	default:
+		return FillStats{}, fmt.Errorf("fill: unknown strategy %q", opts.Strategy)
+	}
+	if len(opts.Columns) == 0 {
+		return FillStats{}, errors.New("fill: no columns given")
+	}
+
+	in, err := inputReader(r, opts.Options)
+	if err != nil {
+		return FillStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return FillStats{}, err
+	}
+
+	cols, err := ColumnIndices(headers, opts.Columns)
+	if err != nil {
+		return FillStats{}, err
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	if err := cw.Write(headers); err != nil {
+		return FillStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	stats := FillStats{CellsFilled: make(map[string]int, len(cols))}
+	for _, c := range cols {
+		stats.CellsFilled[headers[c]] = 0
+	}
+
+	if opts.Strategy == FillBackward {
+		err = fillBackward(rows, cw, headers, cols, opts.Policy, &stats)
+	} else {
+		err = fillStreaming(rows, cw, headers, cols, opts, &stats)
+	}
+	if err != nil {
+		return stats, err
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
+
+// fillStreaming implements FillValue and FillForward row-by-row.
+func fillStreaming(rows *rowReader, cw *csv.Writer, headers []string, cols []int, opts FillOptions, stats *FillStats) error {
+	// last holds the most recent non-null value per filled column, for
+	// forward fill; ok reports whether one has been seen yet.
+	last := make([]string, len(cols))
+	ok := make([]bool, len(cols))
+
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		rec = normalizeRow(rec, len(headers))
+		for i, c := range cols {
+			if !opts.Policy.IsNull(rec[c]) {
+				last[i], ok[i] = rec[c], true
+				continue
+			}
+			switch {
+			case opts.Strategy == FillValue:
+				rec[c] = opts.Value
+			case ok[i]:
+				rec[c] = last[i]
+			default:
+				continue
+			}
+			stats.CellsFilled[headers[c]]++
+		}
+
+		if err := cw.Write(rec); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+}
+
+// fillBackward implements FillBackward: all rows are read, filled from the
+// bottom up, then written in original order.
+func fillBackward(rows *rowReader, cw *csv.Writer, headers []string, cols []int, policy nulls.Policy, stats *FillStats) error {
+	var all [][]string
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read row: %w", err)
+		}
+		all = append(all, normalizeRow(rec, len(headers)))
+	}
+	stats.RowsRead = len(all)
+
+	for _, c := range cols {
+		next, ok := "", false
+		for i := len(all) - 1; i >= 0; i-- {
+			if !policy.IsNull(all[i][c]) {
+				next, ok = all[i][c], true
+				continue
+			}
+			if ok {
+				all[i][c] = next
+				stats.CellsFilled[headers[c]]++
+			}
+		}
+	}
+
+	if err := cw.WriteAll(all); err != nil {
+		return fmt.Errorf("write row: %w", err)
+	}
+	return nil
+}
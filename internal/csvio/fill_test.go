@@ -0,0 +1,62 @@
+package csvio
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+func TestFillReader(t *testing.T) {
+	const input = "city,state\n" +
+		",\n" +
+		"Albany,NY\n" +
+		"NA,\n" +
+		"Troy,\n"
+
+	tests := []struct {
+		name   string
+		opts   FillOptions
+		want   string
+		filled map[string]int
+	}{
+		{
+			name:   "value",
+			opts:   FillOptions{Columns: []string{"state"}, Strategy: FillValue, Value: "??"},
+			want:   "city,state\n,??\nAlbany,NY\nNA,??\nTroy,??\n",
+			filled: map[string]int{"state": 3},
+		},
+		{
+			name:   "forward",
+			opts:   FillOptions{Columns: []string{"city", "state"}, Strategy: FillForward},
+			want:   "city,state\n,\nAlbany,NY\nAlbany,NY\nTroy,NY\n",
+			filled: map[string]int{"city": 1, "state": 2},
+		},
+		{
+			name:   "backward",
+			opts:   FillOptions{Columns: []string{"city", "state"}, Strategy: FillBackward},
+			want:   "city,state\nAlbany,NY\nAlbany,NY\nTroy,\nTroy,\n",
+			filled: map[string]int{"city": 2, "state": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Policy = nulls.Policy{TreatBlanks: true, TreatNA: true}
+
+			var out bytes.Buffer
+			stats, err := FillReader(strings.NewReader(input), &out, tt.opts)
+			if err != nil {
+				t.Fatalf("FillReader: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+			if stats.RowsRead != 4 || !reflect.DeepEqual(stats.CellsFilled, tt.filled) {
+				t.Fatalf("stats = %+v", stats)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements row filtering: keeping only the rows whose value in a
+// given column satisfies a predicate. Filtering streams row-by-row, so memory
+// use does not grow with file size.
+package csvio
+
+import (
+	"fmt"
+	"io"
+)
+
+// FilterStats summarizes a filter operation.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - RowsMatched counts rows for which the predicate returned true.
+//   - RowsWritten counts data rows written to the output (header excluded).
+type FilterStats struct {
+	RowsRead    int
+	RowsMatched int
+	RowsWritten int
+}
+
+// FilterFile writes the header plus every data row of inputPath whose value in
+// column col satisfies pred to outputPath.
+//
+// The column is looked up case-insensitively; an unknown column returns an
+// error wrapping ErrColumnNotFound. Taking a predicate rather than a value
+// lets one function serve equality, substring, and regex matching.
+func FilterFile(inputPath, outputPath string, col string, pred func(string) bool, opts Options) (FilterStats, error) {
+	in, err := openCSV(inputPath, opts)
+	if err != nil {
+		return FilterStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return FilterStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return FilterReader(in, out, col, pred, opts)
+}
+
+// FilterReader is FilterFile for an already-open input and output, e.g. when
+// writing to stdout. r is raw input; opts (including Encoding) is applied.
+func FilterReader(r io.Reader, w io.Writer, col string, pred func(string) bool, opts Options) (FilterStats, error) {
+	in, err := inputReader(r, opts)
+	if err != nil {
+		return FilterStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts), opts)
+	if err != nil {
+		return FilterStats{}, err
+	}
+
+	idx, err := ColumnIndex(headers, col)
+	if err != nil {
+		return FilterStats{}, err
+	}
+
+	cw := newWriter(w, opts)
+	defer cw.Flush()
+
+	if err := cw.Write(headers); err != nil {
+		return FilterStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	stats := FilterStats{}
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		rec = normalizeRow(rec, len(headers))
+		if !pred(rec[idx]) {
+			continue
+		}
+		stats.RowsMatched++
+
+		if err := cw.Write(rec); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+		stats.RowsWritten++
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
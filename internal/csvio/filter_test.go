@@ -0,0 +1,55 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFilterFile_Eq(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,State\nAnn,NY\nBob,CA\nCy,NY\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	stats, err := FilterFile(in, out, "state", func(v string) bool { return v == "NY" }, Options{})
+	if err != nil {
+		t.Fatalf("FilterFile: %v", err)
+	}
+	if stats != (FilterStats{RowsRead: 3, RowsMatched: 2, RowsWritten: 2}) {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "name,State\nAnn,NY\nCy,NY\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestFilterReader_QuotedCommas(t *testing.T) {
+	var buf bytes.Buffer
+	in := strings.NewReader("name,city\n\"Lee, Ann\",\"Troy, NY\"\nBob,Albany\n")
+
+	_, err := FilterReader(in, &buf, "city", func(v string) bool { return v == "Troy, NY" }, Options{})
+	if err != nil {
+		t.Fatalf("FilterReader: %v", err)
+	}
+	if want := "name,city\n\"Lee, Ann\",\"Troy, NY\"\n"; buf.String() != want {
+		t.Fatalf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFilterReader_MissingColumn(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := FilterReader(strings.NewReader("a,b\n1,2\n"), &buf, "c", func(string) bool { return true }, Options{})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("expected ErrColumnNotFound, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "a, b") {
+		t.Fatalf("expected available columns in error; got %v", err)
+	}
+}
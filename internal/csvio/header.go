@@ -0,0 +1,73 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file holds header detection helpers for files that may or may not
+// start with a header row.
+package csvio
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LikelyHasHeader guesses whether firstRow is a header row, using secondRow
+// (the next record, possibly nil) for comparison.
+//
+// The heuristic is deliberately simple and conservative:
+//
+//  1. If firstRow contains duplicate non-empty values, it is probably data:
+//     headers are normally unique. Result: false.
+//  2. If any cell in firstRow is numeric, it is probably data: column names
+//     are rarely numbers. This also covers mixed first rows (some numeric,
+//     some text). Result: false.
+//  3. If firstRow is all text and secondRow has a numeric cell, the type
+//     change strongly suggests a header. Result: true.
+//  4. Otherwise nothing distinguishes the rows, and the conventional CSV
+//     assumption (first row is a header) is kept. Result: true.
+func LikelyHasHeader(firstRow, secondRow []string) bool {
+	seen := make(map[string]struct{}, len(firstRow))
+	for _, c := range firstRow {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		if _, dup := seen[c]; dup {
+			return false
+		}
+		seen[c] = struct{}{}
+	}
+
+	for _, c := range firstRow {
+		if isNumeric(c) {
+			return false
+		}
+	}
+
+	for _, c := range secondRow {
+		if isNumeric(c) {
+			return true
+		}
+	}
+
+	return true
+}
+
+// synthesizeHeaders returns placeholder column names col_0..col_{n-1} for input
+// that has no header row.
+func synthesizeHeaders(n int) []string {
+	headers := make([]string, n)
+	for i := range headers {
+		headers[i] = "col_" + strconv.Itoa(i)
+	}
+	return headers
+}
+
+// isNumeric reports whether s (ignoring surrounding whitespace) parses as a
+// number.
+func isNumeric(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
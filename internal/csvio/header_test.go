@@ -0,0 +1,97 @@
+package csvio
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+func TestLikelyHasHeader(t *testing.T) {
+	tests := []struct {
+		name          string
+		first, second []string
+		want          bool
+	}{
+		{name: "text over numbers", first: []string{"name", "age"}, second: []string{"Ann", "42"}, want: true},
+		{name: "all text both rows", first: []string{"name", "city"}, second: []string{"Ann", "Troy"}, want: true},
+		{name: "numeric first row", first: []string{"1", "2"}, second: []string{"3", "4"}, want: false},
+		{name: "mixed first row", first: []string{"Ann", "42"}, second: []string{"Bob", "37"}, want: false},
+		{name: "duplicate values", first: []string{"NY", "NY"}, second: []string{"CA", "5"}, want: false},
+		{name: "single row", first: []string{"name", "email"}, second: nil, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LikelyHasHeader(tt.first, tt.second); got != tt.want {
+				t.Fatalf("LikelyHasHeader(%q, %q) = %v, want %v", tt.first, tt.second, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadHead_AutoDetectHeader(t *testing.T) {
+	opts := Options{AutoDetectHeader: true}
+
+	withHeader := writeTemp(t, "with.csv", "name,age\nAnn,42\nBob,37\n")
+	headers, rows, err := ReadHead(withHeader, 5, ReadOptions{Options: opts})
+	if err != nil {
+		t.Fatalf("ReadHead: %v", err)
+	}
+	if !reflect.DeepEqual(headers, []string{"name", "age"}) || len(rows) != 2 {
+		t.Fatalf("with header: got headers %q rows %q", headers, rows)
+	}
+
+	noHeader := writeTemp(t, "without.csv", "Ann,42\nBob,37\n")
+	headers, rows, err = ReadHead(noHeader, 5, ReadOptions{Options: opts})
+	if err != nil {
+		t.Fatalf("ReadHead: %v", err)
+	}
+	if !reflect.DeepEqual(headers, []string{"col_0", "col_1"}) {
+		t.Fatalf("without header: got headers %q", headers)
+	}
+	want := [][]string{{"Ann", "42"}, {"Bob", "37"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("without header: got rows %q, want %q", rows, want)
+	}
+}
+
+func TestNoHeader(t *testing.T) {
+	path := writeTemp(t, "in.csv", "Ann,,42\nBob,NULL,37\n")
+	opts := Options{NoHeader: true}
+
+	headers, err := ReadHeaders(path, opts)
+	if err != nil {
+		t.Fatalf("ReadHeaders: %v", err)
+	}
+	if want := []string{"col_0", "col_1", "col_2"}; !reflect.DeepEqual(headers, want) {
+		t.Fatalf("ReadHeaders = %q, want %q", headers, want)
+	}
+
+	// A header-like first row is still data.
+	_, rows, err := ReadHead(path, 5, ReadOptions{Options: Options{NoHeader: true, AutoDetectHeader: true}})
+	if err != nil {
+		t.Fatalf("ReadHead: %v", err)
+	}
+	if want := [][]string{{"Ann", "", "42"}, {"Bob", "NULL", "37"}}; !reflect.DeepEqual(rows, want) {
+		t.Fatalf("ReadHead rows = %q, want %q", rows, want)
+	}
+
+	out := filepath.Join(t.TempDir(), "out.csv")
+	stats, err := NullifyFile(path, out, nulls.Policy{TreatNULLLiteral: true}, NullifyOptions{Options: opts})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+	if stats.RowsRead != 2 {
+		t.Fatalf("RowsRead = %d, want 2", stats.RowsRead)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Ann,,42\nBob,,37\n"; string(got) != want {
+		t.Fatalf("NullifyFile output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements prepending a row-number column, e.g. to give rows a
+// stable ID before loading them into a database. It streams row-by-row.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// DefaultIndexColumn is the header AddIndexColumn uses when
+// IndexOptions.Column is empty.
+const DefaultIndexColumn = "_idx"
+
+// IndexOptions controls AddIndexColumn.
+type IndexOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// Column is the header of the new first column. Empty means
+	// DefaultIndexColumn. It must not match an existing header
+	// (case-insensitive).
+	Column string
+
+	// Start is the number given to the first data row.
+	Start int
+}
+
+// AddIndexColumn writes inputPath to outputPath with a new first column
+// numbering the data rows opts.Start, opts.Start+1, ...
+func AddIndexColumn(inputPath, outputPath string, opts IndexOptions) error {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return AddIndexReader(in, out, opts)
+}
+
+// AddIndexReader is AddIndexColumn for an already-open input and output,
+// e.g. when writing to stdout. r is raw input; opts.Encoding is applied.
+func AddIndexReader(r io.Reader, w io.Writer, opts IndexOptions) error {
+	if opts.Column == "" {
+		opts.Column = DefaultIndexColumn
+	}
+
+	in, err := inputReader(r, opts.Options)
+	if err != nil {
+		return fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return err
+	}
+	if _, err := ColumnIndex(headers, opts.Column); err == nil {
+		return fmt.Errorf("index column %q already exists", opts.Column)
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	// One output slice is reused for every row; csv.Writer does not retain it.
+	rec := make([]string, len(headers)+1)
+
+	rec[0] = opts.Column
+	copy(rec[1:], headers)
+	if err := cw.Write(rec); err != nil {
+		return fmt.Errorf("write headers: %w", err)
+	}
+
+	for n := opts.Start; ; n++ {
+		row, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read row: %w", err)
+		}
+
+		rec[0] = strconv.Itoa(n)
+		copy(rec[1:], normalizeRow(row, len(headers)))
+		if err := cw.Write(rec); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return nil
+}
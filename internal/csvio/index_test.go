@@ -0,0 +1,47 @@
+package csvio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAddIndexReader(t *testing.T) {
+	const input = "name,city\nAnn,Albany\nBob\nCy,Troy\n"
+
+	tests := []struct {
+		name string
+		opts IndexOptions
+		want string
+	}{
+		{
+			name: "defaults",
+			opts: IndexOptions{},
+			want: "_idx,name,city\n0,Ann,Albany\n1,Bob,\n2,Cy,Troy\n",
+		},
+		{
+			name: "column name and start",
+			opts: IndexOptions{Column: "id", Start: 100},
+			want: "id,name,city\n100,Ann,Albany\n101,Bob,\n102,Cy,Troy\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := AddIndexReader(strings.NewReader(input), &out, tt.opts); err != nil {
+				t.Fatalf("AddIndexReader: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAddIndexReader_ExistingColumn(t *testing.T) {
+	err := AddIndexReader(strings.NewReader("ID,name\n1,Ann\n"), &bytes.Buffer{}, IndexOptions{Column: "id"})
+	if err == nil {
+		t.Fatal("expected error for an existing column name")
+	}
+}
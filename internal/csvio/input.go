@@ -0,0 +1,221 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file maps user-supplied paths to readers and writers. By Unix
+// convention the path "-" means standard input (or standard output, for
+// outputs), so df can sit in a pipeline: cat data.csv | df head -.
+//
+// Gzip is handled here too, so every command reads and writes compressed
+// files transparently: input is decompressed when it starts with the gzip
+// magic bytes, and output is compressed when its path ends in ".gz".
+//
+// An input path starting with http:// or https:// is fetched with a GET, so
+// a signed S3 URL or a raw GitHub file can be read without downloading it
+// first.
+package csvio
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// StdioPath is the path that selects stdin for inputs and stdout for outputs.
+const StdioPath = "-"
+
+// DefaultHTTPTimeout bounds URL inputs when Options.HTTPTimeout is zero.
+const DefaultHTTPTimeout = 30 * time.Second
+
+// gzipMagic is the two-byte header every gzip stream starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// OpenInput opens path for reading, or returns standard input when path is
+// StdioPath. Stdin is wrapped so that Close is a no-op, letting callers
+// always defer Close.
+//
+// Gzip-compressed input is decompressed transparently. Detection sniffs the
+// first two bytes rather than trusting the extension, so a compressed file
+// without ".gz" (or a mislabeled plain file with it) is still read
+// correctly, and compressed stdin works too.
+//
+// A path starting with http:// or https:// is fetched with
+// http.DefaultClient within DefaultHTTPTimeout; see openURL and
+// OpenInputWithOptions. Close releases the response body.
+//
+// Stdin can only be read once: commands that read their input twice (for
+// example to sniff the encoding first) cannot use it. URLs can, but each
+// read fetches the resource again.
+func OpenInput(path string) (io.ReadCloser, error) {
+	return openInput(path, 0, nil)
+}
+
+// OpenInputWithOptions is OpenInput with opts.HTTPTimeout applied to URL
+// inputs. The other fields of opts are ignored: the content is returned
+// as stored (after gzip decompression) for a *Reader function to parse.
+func OpenInputWithOptions(path string, opts Options) (io.ReadCloser, error) {
+	return openInput(path, opts.HTTPTimeout, nil)
+}
+
+// openInput is OpenInput with a URL timeout (zero means DefaultHTTPTimeout)
+// and an optional tap applied to the raw bytes before decompression, e.g. to
+// hash the file exactly as stored on disk.
+func openInput(path string, timeout time.Duration, tap func(io.Reader) io.Reader) (io.ReadCloser, error) {
+	var f io.ReadCloser
+	switch {
+	case path == StdioPath:
+		f = io.NopCloser(os.Stdin)
+	case IsURL(path):
+		body, err := openURL(path, timeout)
+		if err != nil {
+			return nil, err
+		}
+		f = body
+	default:
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		f = file
+	}
+
+	var raw io.Reader = f
+	if tap != nil {
+		raw = tap(f)
+	}
+
+	br := bufio.NewReader(raw)
+	magic, _ := br.Peek(len(gzipMagic))
+	if string(magic) != string(gzipMagic) {
+		return readCloser{Reader: br, Closer: f}, nil
+	}
+
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+	return readCloser{Reader: gz, Closer: multiCloser{gz, f}}, nil
+}
+
+// IsURL reports whether path names an HTTP or HTTPS resource rather than a
+// local file.
+func IsURL(path string) bool {
+	p := strings.ToLower(path)
+	return strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://")
+}
+
+// openURL GETs url and returns the response body. Any status other than 2xx
+// is an error. The request is cancelled after timeout (DefaultHTTPTimeout
+// when zero), even while the body is still being read; closing the result
+// closes the body and releases the timer.
+//
+// A body sent with "Content-Encoding: gzip" is decompressed by net/http
+// itself. A gzip file served as-is (e.g. data.csv.gz from a bucket) is left
+// to the magic-byte sniffing in openInput.
+func openURL(url string, timeout time.Duration) (io.ReadCloser, error) {
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return readCloser{Reader: resp.Body, Closer: multiCloser{resp.Body, cancelCloser(cancel)}}, nil
+}
+
+// cancelCloser adapts a context.CancelFunc to io.Closer.
+type cancelCloser context.CancelFunc
+
+func (c cancelCloser) Close() error {
+	c()
+	return nil
+}
+
+// CreateOutput creates (or truncates) path for writing, or returns standard
+// output when path is StdioPath. As with OpenInput, closing stdout is a no-op.
+//
+// When path ends in ".gz" the output is gzip-compressed. Close must be
+// called (and its error checked): it flushes the compressor before closing
+// the file, and skipping it leaves a truncated archive.
+func CreateOutput(path string) (io.WriteCloser, error) {
+	return createOutput(path, nil)
+}
+
+// createOutput is CreateOutput with an optional tap applied to the bytes
+// after compression, i.e. exactly what lands in the file.
+func createOutput(path string, tap func(io.Writer) io.Writer) (io.WriteCloser, error) {
+	var f io.WriteCloser
+	if path == StdioPath {
+		f = nopWriteCloser{os.Stdout}
+	} else {
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		f = file
+	}
+
+	return wrapOutput(path, f, tap), nil
+}
+
+// wrapOutput applies tap and, for ".gz" paths, gzip compression to the
+// already-open f. Closing the result closes the compressor, then f.
+func wrapOutput(path string, f io.WriteCloser, tap func(io.Writer) io.Writer) io.WriteCloser {
+	var raw io.Writer = f
+	if tap != nil {
+		raw = tap(f)
+	}
+
+	if !strings.HasSuffix(strings.ToLower(path), ".gz") {
+		return writeCloser{Writer: raw, Closer: f}
+	}
+
+	gz := gzip.NewWriter(raw)
+	return writeCloser{Writer: gz, Closer: multiCloser{gz, f}}
+}
+
+// nopWriteCloser is io.NopCloser for writers.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// writeCloser pairs a (possibly wrapped) writer with what must be closed
+// underneath it.
+type writeCloser struct {
+	io.Writer
+	io.Closer
+}
+
+// multiCloser closes each element in order and returns the first error. It
+// is used to close a gzip stream before the file beneath it.
+type multiCloser []io.Closer
+
+func (m multiCloser) Close() error {
+	var first error
+	for _, c := range m {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,211 @@
+package csvio
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+// gzipBytes returns content gzip-compressed.
+func gzipBytes(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(content)); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// gunzipFile returns the decompressed content of a gzip file.
+func gunzipFile(t *testing.T, path string) string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("output is not gzip: %v", err)
+	}
+	b, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("gunzip: %v", err)
+	}
+	return string(b)
+}
+
+// withStdin replaces os.Stdin with a pipe carrying content for the duration
+// of the test.
+func withStdin(t *testing.T, content string) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	go func() {
+		_, _ = w.WriteString(content)
+		w.Close()
+	}()
+
+	orig := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = orig
+		r.Close()
+	})
+}
+
+func TestReadHead_Stdin(t *testing.T) {
+	withStdin(t, "name,zip\nAnn,12207\nBob,12180\n")
+
+	headers, rows, err := ReadHead(StdioPath, 1, ReadOptions{})
+	if err != nil {
+		t.Fatalf("ReadHead: %v", err)
+	}
+	if !reflect.DeepEqual(headers, []string{"name", "zip"}) {
+		t.Fatalf("headers = %v", headers)
+	}
+	if !reflect.DeepEqual(rows, [][]string{{"Ann", "12207"}}) {
+		t.Fatalf("rows = %v", rows)
+	}
+}
+
+func TestOpenInput_StdinCloseIsNoop(t *testing.T) {
+	withStdin(t, "")
+
+	f, err := OpenInput(StdioPath)
+	if err != nil {
+		t.Fatalf("OpenInput: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stdin.Stat(); err != nil {
+		t.Fatalf("stdin was closed: %v", err)
+	}
+}
+
+func TestOpenInput_Gzip(t *testing.T) {
+	// Detection is by content, so the extension does not matter.
+	for _, name := range []string{"in.csv.gz", "in.csv"} {
+		t.Run(name, func(t *testing.T) {
+			path := writeTemp(t, name, string(gzipBytes(t, "name,zip\nAnn,12207\n")))
+
+			headers, rows, err := ReadHead(path, 5, ReadOptions{})
+			if err != nil {
+				t.Fatalf("ReadHead: %v", err)
+			}
+			if !reflect.DeepEqual(headers, []string{"name", "zip"}) {
+				t.Fatalf("headers = %v", headers)
+			}
+			if !reflect.DeepEqual(rows, [][]string{{"Ann", "12207"}}) {
+				t.Fatalf("rows = %v", rows)
+			}
+		})
+	}
+}
+
+func TestOpenInput_PlainFileNamedGz(t *testing.T) {
+	path := writeTemp(t, "in.csv.gz", "name\nAnn\n")
+
+	n, err := CountRows(path, Options{})
+	if err != nil {
+		t.Fatalf("CountRows: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("CountRows = %d, want 1", n)
+	}
+}
+
+func TestCreateOutput_Gzip(t *testing.T) {
+	in := writeTemp(t, "in.csv.gz", string(gzipBytes(t, "name,email\nAnn,NA\n")))
+	out := filepath.Join(t.TempDir(), "out.csv.gz")
+
+	if _, err := NullifyFile(in, out, nulls.Policy{TreatNA: true}, NullifyOptions{}); err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+	if got, want := gunzipFile(t, out), "name,email\nAnn,\n"; got != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+// csvServer serves a small fixture CSV: plain at /plain.csv, with
+// Content-Encoding: gzip at /encoded.csv, and as a gzip file at /data.csv.gz.
+// Other paths are 404.
+func csvServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	const fixture = "name,zip\nAnn,12207\n"
+	zipped := gzipBytes(t, fixture)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/plain.csv":
+			_, _ = io.WriteString(w, fixture)
+		case "/encoded.csv":
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(zipped)
+		case "/data.csv.gz":
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write(zipped)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOpenInput_URL(t *testing.T) {
+	srv := csvServer(t)
+
+	for _, path := range []string{"/plain.csv", "/encoded.csv", "/data.csv.gz"} {
+		t.Run(path, func(t *testing.T) {
+			headers, rows, err := ReadHead(srv.URL+path, 5, ReadOptions{})
+			if err != nil {
+				t.Fatalf("ReadHead: %v", err)
+			}
+			if !reflect.DeepEqual(headers, []string{"name", "zip"}) {
+				t.Fatalf("headers = %v", headers)
+			}
+			if !reflect.DeepEqual(rows, [][]string{{"Ann", "12207"}}) {
+				t.Fatalf("rows = %v", rows)
+			}
+		})
+	}
+}
+
+func TestOpenInput_URLNotFound(t *testing.T) {
+	srv := csvServer(t)
+
+	_, err := OpenInput(srv.URL + "/missing.csv")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("err = %v, want a 404 error", err)
+	}
+}
+
+func TestOpenInput_URLTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	if _, err := OpenInputWithOptions(srv.URL+"/slow.csv", Options{HTTPTimeout: 50 * time.Millisecond}); err == nil {
+		t.Fatal("OpenInput succeeded, want a timeout error")
+	}
+}
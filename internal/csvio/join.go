@@ -0,0 +1,383 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements relational joins of two files on a key column. The
+// smaller file (by size on disk) is indexed in memory and the larger one is
+// streamed, so memory use tracks the smaller input.
+package csvio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// JoinType selects which unmatched rows a join keeps.
+type JoinType string
+
+const (
+	JoinInner JoinType = "inner" // matched rows only
+	JoinLeft  JoinType = "left"  // plus unmatched left rows
+	JoinRight JoinType = "right" // plus unmatched right rows
+	JoinFull  JoinType = "full"  // plus unmatched rows from both sides
+)
+
+// ParseJoinType converts a name such as "left" into a JoinType.
+func ParseJoinType(s string) (JoinType, error) {
+	switch t := JoinType(strings.ToLower(s)); t {
+	case JoinInner, JoinLeft, JoinRight, JoinFull:
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown join type %q (want inner, left, right, or full)", s)
+}
+
+// JoinOptions controls JoinFiles.
+type JoinOptions struct {
+	// Options controls the output delimiter, and how both files are parsed
+	// unless FileOptions is set.
+	Options
+
+	// FileOptions holds the read options of the left and right file, in
+	// that order, for inputs in different dialects. A single entry applies
+	// to both.
+	FileOptions []Options
+
+	// LeftKey and RightKey name the key column in each file
+	// (case-insensitive). When RightKey is empty it defaults to LeftKey;
+	// when both are empty, the first left column whose name also appears
+	// in the right file is used.
+	LeftKey  string
+	RightKey string
+
+	// Type is the join type. Empty means JoinInner.
+	Type JoinType
+
+	// PrefixLeft and PrefixRight are prepended (with "_") to non-key column
+	// names that appear in both files. Empty means the file name without
+	// its extension.
+	PrefixLeft  string
+	PrefixRight string
+}
+
+// JoinStats counts data rows on each side by whether they found a partner.
+type JoinStats struct {
+	LeftMatched    int
+	LeftUnmatched  int
+	RightMatched   int
+	RightUnmatched int
+	RowsWritten    int
+}
+
+// JoinFiles joins leftPath and rightPath on their key columns and writes the
+// result to outputPath.
+//
+// Output columns are the left file's columns followed by the right file's
+// columns minus its key, so the key appears once; for right-only rows it is
+// filled from the right file. A key with several rows on both sides yields
+// every pairing. Matched rows follow the streamed (larger) file's order;
+// unmatched rows from the indexed file come last.
+func JoinFiles(leftPath, rightPath, outputPath string, opts JoinOptions) (JoinStats, error) {
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return JoinStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return JoinToWriter(leftPath, rightPath, out, opts)
+}
+
+// joinSide is one open input of a join.
+type joinSide struct {
+	path    string
+	size    int64
+	closer  io.Closer
+	headers []string
+	rows    *rowReader
+	key     int
+}
+
+// openJoinSide opens path and reads its header.
+func openJoinSide(path string, opts Options) (*joinSide, error) {
+	// The size only picks which side to index. A URL's size is not known
+	// up front, so it counts as the larger side and is streamed.
+	size := int64(math.MaxInt64)
+	if !IsURL(path) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("open csv: %w", err)
+		}
+		size = info.Size()
+	}
+	f, err := openCSV(path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("open csv: %w", err)
+	}
+	headers, rows, err := readHeader(newReader(f, opts), opts)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &joinSide{path: path, size: size, closer: f, headers: headers, rows: rows}, nil
+}
+
+// JoinToWriter is JoinFiles writing to an already-open output, e.g. stdout.
+func JoinToWriter(leftPath, rightPath string, w io.Writer, opts JoinOptions) (JoinStats, error) {
+	joinType := opts.Type
+	if joinType == "" {
+		joinType = JoinInner
+	}
+	if _, err := ParseJoinType(string(joinType)); err != nil {
+		return JoinStats{}, err
+	}
+
+	left, err := openJoinSide(leftPath, fileOptions(opts.Options, opts.FileOptions, 0))
+	if err != nil {
+		return JoinStats{}, err
+	}
+	defer left.closer.Close()
+
+	right, err := openJoinSide(rightPath, fileOptions(opts.Options, opts.FileOptions, 1))
+	if err != nil {
+		return JoinStats{}, err
+	}
+	defer right.closer.Close()
+
+	if err := resolveJoinKeys(left, right, opts); err != nil {
+		return JoinStats{}, err
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	if err := cw.Write(joinHeaders(left, right, opts)); err != nil {
+		return JoinStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	// emit writes one output row; either side may be nil for outer joins.
+	stats := JoinStats{}
+	emit := func(l, r []string) error {
+		row := make([]string, 0, len(left.headers)+len(right.headers)-1)
+		if l == nil {
+			l = make([]string, len(left.headers))
+			l[left.key] = r[right.key]
+		}
+		row = append(row, l...)
+		for i := range right.headers {
+			if i == right.key {
+				continue
+			}
+			if r == nil {
+				row = append(row, "")
+			} else {
+				row = append(row, r[i])
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+		stats.RowsWritten++
+		return nil
+	}
+
+	// Index the smaller side, stream the larger one.
+	indexed, streamed := right, left
+	if left.size < right.size {
+		indexed, streamed = left, right
+	}
+	indexedIsLeft := indexed == left
+
+	keepLeft := joinType == JoinLeft || joinType == JoinFull
+	keepRight := joinType == JoinRight || joinType == JoinFull
+	keepStreamed, keepIndexed := keepLeft, keepRight
+	if indexedIsLeft {
+		keepStreamed, keepIndexed = keepRight, keepLeft
+	}
+
+	index, order, err := indexJoinSide(indexed)
+	if err != nil {
+		return stats, err
+	}
+	matched := make(map[string]bool, len(index))
+
+	streamedMatched, streamedUnmatched := 0, 0
+	for {
+		rec, err := streamed.rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("%s: read row: %w", streamed.path, err)
+		}
+		rec = normalizeRow(rec, len(streamed.headers))
+
+		partners, ok := index[rec[streamed.key]]
+		if !ok {
+			streamedUnmatched++
+			if keepStreamed {
+				if indexedIsLeft {
+					err = emit(nil, rec)
+				} else {
+					err = emit(rec, nil)
+				}
+				if err != nil {
+					return stats, err
+				}
+			}
+			continue
+		}
+
+		streamedMatched++
+		matched[rec[streamed.key]] = true
+		for _, p := range partners {
+			if indexedIsLeft {
+				err = emit(p, rec)
+			} else {
+				err = emit(rec, p)
+			}
+			if err != nil {
+				return stats, err
+			}
+		}
+	}
+
+	indexedMatched, indexedUnmatched := 0, 0
+	for _, key := range order {
+		rows := index[key]
+		if matched[key] {
+			indexedMatched += len(rows)
+			continue
+		}
+		indexedUnmatched += len(rows)
+		if !keepIndexed {
+			continue
+		}
+		for _, rec := range rows {
+			if indexedIsLeft {
+				err = emit(rec, nil)
+			} else {
+				err = emit(nil, rec)
+			}
+			if err != nil {
+				return stats, err
+			}
+		}
+	}
+
+	if indexedIsLeft {
+		stats.LeftMatched, stats.LeftUnmatched = indexedMatched, indexedUnmatched
+		stats.RightMatched, stats.RightUnmatched = streamedMatched, streamedUnmatched
+	} else {
+		stats.LeftMatched, stats.LeftUnmatched = streamedMatched, streamedUnmatched
+		stats.RightMatched, stats.RightUnmatched = indexedMatched, indexedUnmatched
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
+
+// resolveJoinKeys sets the key index on both sides from opts.
+func resolveJoinKeys(left, right *joinSide, opts JoinOptions) error {
+	leftKey, rightKey := opts.LeftKey, opts.RightKey
+	if leftKey == "" && rightKey == "" {
+		for _, h := range left.headers {
+			if _, err := ColumnIndex(right.headers, h); err == nil {
+				leftKey = h
+				break
+			}
+		}
+		if leftKey == "" {
+			return errors.New("join: no column name is shared by both files; set the key explicitly")
+		}
+	}
+	if rightKey == "" {
+		rightKey = leftKey
+	}
+
+	var err error
+	if left.key, err = ColumnIndex(left.headers, leftKey); err != nil {
+		return fmt.Errorf("%s: %w", left.path, err)
+	}
+	if right.key, err = ColumnIndex(right.headers, rightKey); err != nil {
+		return fmt.Errorf("%s: %w", right.path, err)
+	}
+	return nil
+}
+
+// joinHeaders builds the output header, prefixing non-key names that occur
+// in both files.
+func joinHeaders(left, right *joinSide, opts JoinOptions) []string {
+	prefixLeft := opts.PrefixLeft
+	if prefixLeft == "" {
+		prefixLeft = fileStem(left.path)
+	}
+	prefixRight := opts.PrefixRight
+	if prefixRight == "" {
+		prefixRight = fileStem(right.path)
+	}
+
+	conflict := func(name string, other *joinSide) bool {
+		for i, h := range other.headers {
+			if i != other.key && strings.EqualFold(h, name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	out := make([]string, 0, len(left.headers)+len(right.headers)-1)
+	for i, h := range left.headers {
+		if i != left.key && conflict(h, right) {
+			h = prefixLeft + "_" + h
+		}
+		out = append(out, h)
+	}
+	for i, h := range right.headers {
+		if i == right.key {
+			continue
+		}
+		if conflict(h, left) {
+			h = prefixRight + "_" + h
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+// indexJoinSide reads every row of s into a map from key value to rows.
+// order lists distinct keys in file order.
+func indexJoinSide(s *joinSide) (map[string][][]string, []string, error) {
+	index := make(map[string][][]string)
+	var order []string
+	for {
+		rec, err := s.rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: read row: %w", s.path, err)
+		}
+		rec = normalizeRow(rec, len(s.headers))
+
+		key := rec[s.key]
+		if _, ok := index[key]; !ok {
+			order = append(order, key)
+		}
+		index[key] = append(index[key], rec)
+	}
+	return index, order, nil
+}
+
+// fileStem returns the base name of path without its extension.
+func fileStem(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,86 @@
+package csvio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJoinToWriter(t *testing.T) {
+	// The right file is larger on disk, so the left one is indexed; the
+	// tests cover both the streamed and the indexed side's unmatched rows.
+	left := writeTemp(t, "contacts.csv", "id,name\n1,Ann\n2,Bob\n")
+	right := writeTemp(t, "orders.csv", "customer,name,total\n"+
+		"1,Widget,10\n"+
+		"3,Gadget,5\n"+
+		"1,Gizmo,7\n")
+
+	tests := []struct {
+		joinType JoinType
+		want     string
+		stats    JoinStats
+	}{
+		{
+			joinType: JoinInner,
+			want: "id,contacts_name,orders_name,total\n" +
+				"1,Ann,Widget,10\n" +
+				"1,Ann,Gizmo,7\n",
+			stats: JoinStats{LeftMatched: 1, LeftUnmatched: 1, RightMatched: 2, RightUnmatched: 1, RowsWritten: 2},
+		},
+		{
+			joinType: JoinLeft,
+			want: "id,contacts_name,orders_name,total\n" +
+				"1,Ann,Widget,10\n" +
+				"1,Ann,Gizmo,7\n" +
+				"2,Bob,,\n",
+			stats: JoinStats{LeftMatched: 1, LeftUnmatched: 1, RightMatched: 2, RightUnmatched: 1, RowsWritten: 3},
+		},
+		{
+			joinType: JoinFull,
+			want: "id,contacts_name,orders_name,total\n" +
+				"1,Ann,Widget,10\n" +
+				"3,,Gadget,5\n" +
+				"1,Ann,Gizmo,7\n" +
+				"2,Bob,,\n",
+			stats: JoinStats{LeftMatched: 1, LeftUnmatched: 1, RightMatched: 2, RightUnmatched: 1, RowsWritten: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.joinType), func(t *testing.T) {
+			var out bytes.Buffer
+			stats, err := JoinToWriter(left, right, &out, JoinOptions{LeftKey: "id", RightKey: "customer", Type: tt.joinType})
+			if err != nil {
+				t.Fatalf("JoinToWriter: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+			if stats != tt.stats {
+				t.Fatalf("stats = %+v, want %+v", stats, tt.stats)
+			}
+		})
+	}
+}
+
+func TestJoinToWriter_DefaultKeyAndPrefixes(t *testing.T) {
+	left := writeTemp(t, "a.csv", "email,note\na@x.com,left\n")
+	right := writeTemp(t, "b.csv", "note,email\nright,a@x.com\nextra,b@x.com\n")
+
+	var out bytes.Buffer
+	_, err := JoinToWriter(left, right, &out, JoinOptions{PrefixLeft: "l", PrefixRight: "r", Type: JoinRight})
+	if err != nil {
+		t.Fatalf("JoinToWriter: %v", err)
+	}
+
+	want := "email,l_note,r_note\na@x.com,left,right\nb@x.com,,extra\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestParseJoinType(t *testing.T) {
+	if _, err := ParseJoinType("outer"); err == nil || !strings.Contains(err.Error(), "outer") {
+		t.Fatalf("expected error naming the bad type, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package csvio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMaskValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		first, last int
+		char        rune
+		want        string
+		masked      bool
+	}{
+		{name: "email", in: "ann@example.com", first: 1, last: 12, want: "a**@example.com", masked: true},
+		{name: "last four", in: "4111111111111111", last: 4, want: "************1111", masked: true},
+		{name: "everything", in: "secret", want: "******", masked: true},
+		{name: "multi-byte runes", in: "Zoë Ørsted", first: 2, last: 2, want: "Zo******ed", masked: true},
+		{name: "cjk", in: "東京都港区", first: 1, last: 1, char: '#', want: "東###区", masked: true},
+		{name: "shown parts cover cell", in: "abcd", first: 2, last: 3, want: "abcd"},
+		{name: "exactly covered", in: "abcd", first: 2, last: 2, want: "abcd"},
+		{name: "empty", in: "", last: 4, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := tt.char
+			if char == 0 {
+				char = DefaultMaskChar
+			}
+			got, masked := maskValue(tt.in, tt.first, tt.last, char)
+			if got != tt.want || masked != tt.masked {
+				t.Fatalf("maskValue(%q, %d, %d) = %q, %v; want %q, %v", tt.in, tt.first, tt.last, got, masked, tt.want, tt.masked)
+			}
+		})
+	}
+}
+
+func TestMaskColumnsReader(t *testing.T) {
+	const input = "name,card\nAnn,4111111111111111\nBob,\n"
+
+	var out bytes.Buffer
+	stats, err := MaskColumnsReader(strings.NewReader(input), &out, MaskOptions{Columns: []string{"CARD"}, ShowLast: 4})
+	if err != nil {
+		t.Fatalf("MaskColumnsReader: %v", err)
+	}
+	if want := "name,card\nAnn,************1111\nBob,\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if stats.RowsRead != 2 || stats.CellsMasked != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
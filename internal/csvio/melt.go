@@ -0,0 +1,129 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements melting (unpivoting): turning a wide table into a
+// long one with a row per (identifier, column) pair. It is the inverse of
+// PivotFile and streams, holding one input row at a time.
+package csvio
+
+import (
+	"fmt"
+	"io"
+)
+
+// MeltOptions controls MeltFile.
+type MeltOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// IDCols lists the columns kept as identifiers on every output row
+	// (case-insensitive), in output order. Every other column is melted.
+	IDCols []string
+}
+
+// MeltStats summarizes a melt.
+//
+//   - InputRows counts data rows read (header excluded).
+//   - OutputRows counts data rows written: InputRows * VariableColumns.
+//   - VariableColumns counts the melted (non-ID) columns.
+type MeltStats struct {
+	InputRows       int
+	OutputRows      int
+	VariableColumns int
+}
+
+// MeltFile writes inputPath to outputPath in long format. The output header
+// is the ID columns followed by "variable" and "value"; each input row
+// becomes one output row per melted column, holding the ID values, the
+// melted column's header and its cell.
+func MeltFile(inputPath, outputPath string, opts MeltOptions) (MeltStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return MeltStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return MeltStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return MeltReader(in, out, opts)
+}
+
+// MeltReader is MeltFile for an already-open input and output, e.g. when
+// writing to stdout. r is raw input; opts.Encoding is applied.
+func MeltReader(r io.Reader, w io.Writer, opts MeltOptions) (MeltStats, error) {
+	in, err := inputReader(r, opts.Options)
+	if err != nil {
+		return MeltStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return MeltStats{}, err
+	}
+
+	ids, err := ColumnIndices(headers, opts.IDCols)
+	if err != nil {
+		return MeltStats{}, err
+	}
+	isID := make([]bool, len(headers))
+	for _, i := range ids {
+		isID[i] = true
+	}
+	var vars []int
+	for i := range headers {
+		if !isID[i] {
+			vars = append(vars, i)
+		}
+	}
+
+	outHeaders := make([]string, 0, len(ids)+2)
+	for _, i := range ids {
+		outHeaders = append(outHeaders, headers[i])
+	}
+	outHeaders = append(outHeaders, "variable", "value")
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	if err := cw.Write(outHeaders); err != nil {
+		return MeltStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	stats := MeltStats{VariableColumns: len(vars)}
+	rec := make([]string, len(outHeaders))
+	for {
+		row, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.InputRows++
+
+		row = normalizeRow(row, len(headers))
+		for j, i := range ids {
+			rec[j] = row[i]
+		}
+		for _, v := range vars {
+			rec[len(ids)] = headers[v]
+			rec[len(ids)+1] = row[v]
+			if err := cw.Write(rec); err != nil {
+				return stats, fmt.Errorf("write row: %w", err)
+			}
+			stats.OutputRows++
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,43 @@
+package csvio
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMeltFile(t *testing.T) {
+	in := writeTemp(t, "in.csv", "id,name,jan,feb\n1,Ann,10,20\n2,Bob,5\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	stats, err := MeltFile(in, out, MeltOptions{IDCols: []string{"NAME", "id"}})
+	if err != nil {
+		t.Fatalf("MeltFile: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	want := "name,id,variable,value\n" +
+		"Ann,1,jan,10\n" +
+		"Ann,1,feb,20\n" +
+		"Bob,2,jan,5\n" +
+		"Bob,2,feb,\n"
+	if string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+	if want := (MeltStats{InputRows: 2, OutputRows: 4, VariableColumns: 2}); stats != want {
+		t.Fatalf("stats = %+v, want %+v", stats, want)
+	}
+}
+
+func TestMeltFile_UnknownColumn(t *testing.T) {
+	in := writeTemp(t, "in.csv", "a,b\n1,2\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	if _, err := MeltFile(in, out, MeltOptions{IDCols: []string{"c"}}); !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
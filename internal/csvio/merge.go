@@ -0,0 +1,46 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements MergeFiles, the original API for stacking files with
+// different dialects. It is now a thin wrapper over ConcatFiles, which
+// accepts per-file read options itself.
+package csvio
+
+// MergeOptions controls MergeFiles.
+type MergeOptions struct {
+	// FileOptions holds per-input read options, matched to input paths by
+	// position. When it is shorter than the input list, the last entry is
+	// reused for the remaining files; when empty, every file uses the zero
+	// Options (standard CSV).
+	FileOptions []Options
+
+	// OutputOptions controls the output dialect (currently the delimiter).
+	OutputOptions Options
+}
+
+// MergeStats summarizes a MergeFiles run.
+type MergeStats struct {
+	FilesMerged int
+	RowsWritten int
+}
+
+// MergeFiles stacks the data rows of inputPaths into a single file at
+// outputPath. It is ConcatFiles with every header required to match, each
+// input parsed with its own entry of opts.FileOptions, and the output
+// written with opts.OutputOptions.
+func MergeFiles(inputPaths []string, outputPath string, opts MergeOptions) (MergeStats, error) {
+	fileOpts := opts.FileOptions
+	if len(fileOpts) == 0 {
+		fileOpts = []Options{{}}
+	}
+
+	cs, err := ConcatFiles(inputPaths, outputPath, ConcatOptions{
+		Options:     opts.OutputOptions,
+		FileOptions: fileOpts,
+	})
+	stats := MergeStats{FilesMerged: len(cs.Files), RowsWritten: cs.RowsWritten()}
+	if err != nil && stats.FilesMerged > 0 {
+		// The last entry is the file that failed.
+		stats.FilesMerged--
+	}
+	return stats, err
+}
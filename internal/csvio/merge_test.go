@@ -0,0 +1,71 @@
+package csvio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergeFiles_MixedDelimiters(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "crm.csv")
+	tsvPath := filepath.Join(dir, "db.tsv")
+	if err := os.WriteFile(csvPath, []byte("name,city\nAnn,\"Troy, NY\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tsvPath, []byte("name\tcity\nBob\tAlbany\nCy\tUtica\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out.csv")
+
+	stats, err := MergeFiles([]string{csvPath, tsvPath}, out, MergeOptions{
+		FileOptions: []Options{{}, {Delimiter: '\t'}},
+	})
+	if err != nil {
+		t.Fatalf("MergeFiles: %v", err)
+	}
+	if stats.FilesMerged != 2 || stats.RowsWritten != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "name,city\nAnn,\"Troy, NY\"\nBob,Albany\nCy,Utica\n"
+	if string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestMergeFiles_ReusesLastFileOptions(t *testing.T) {
+	a := writeTemp(t, "a.tsv", "id\tv\n1\tx\n")
+	b := writeTemp(t, "b.tsv", "id\tv\n2\ty\n")
+	out := filepath.Join(t.TempDir(), "out.tsv")
+
+	_, err := MergeFiles([]string{a, b}, out, MergeOptions{
+		FileOptions:   []Options{{Delimiter: '\t'}},
+		OutputOptions: Options{Delimiter: '\t'},
+	})
+	if err != nil {
+		t.Fatalf("MergeFiles: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "id\tv\n1\tx\n2\ty\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestMergeFiles_HeaderMismatch(t *testing.T) {
+	a := writeTemp(t, "a.csv", "id,v\n1,x\n")
+	b := writeTemp(t, "b.csv", "id,w\n2,y\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	if _, err := MergeFiles([]string{a, b}, out, MergeOptions{}); err == nil {
+		t.Fatalf("expected header mismatch error")
+	}
+}
@@ -0,0 +1,211 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements pivoting: cross-tabulating one column's values
+// against another's, aggregating a third. Rows stream through an
+// accumulator, so memory grows with the number of distinct key pairs rather
+// than the number of rows.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PivotAgg selects how PivotFile combines the values that land in one cell.
+type PivotAgg string
+
+const (
+	PivotCount PivotAgg = "count" // number of rows; Val is not needed
+	PivotSum   PivotAgg = "sum"   // sum of Val parsed as float64
+	PivotMean  PivotAgg = "mean"  // arithmetic mean of Val
+)
+
+// ParsePivotAgg converts a name such as "sum" into a PivotAgg.
+func ParsePivotAgg(s string) (PivotAgg, error) {
+	switch a := PivotAgg(strings.ToLower(s)); a {
+	case PivotCount, PivotSum, PivotMean:
+		return a, nil
+	}
+	return "", fmt.Errorf("unknown aggregation %q (want count, sum, or mean)", s)
+}
+
+// PivotOptions controls PivotFile.
+type PivotOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// Row names the column whose values become output rows. Required.
+	Row string
+
+	// Col names the column whose values become output columns. Required.
+	Col string
+
+	// Val names the column to aggregate. Required for sum and mean.
+	Val string
+
+	// Agg selects the aggregation. Empty means PivotCount.
+	Agg PivotAgg
+}
+
+// PivotStats summarizes a pivot.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - RowKeys and ColKeys count the distinct Row and Col values, i.e. the
+//     output's data rows and value columns.
+//   - ParseErrors counts non-blank Val cells that did not parse as a number
+//     for sum or mean. They are left out of the aggregate; blank cells are
+//     skipped without being counted.
+type PivotStats struct {
+	RowsRead    int
+	RowKeys     int
+	ColKeys     int
+	ParseErrors int
+}
+
+// pivotCell accumulates the values for one (row key, column key) pair.
+type pivotCell struct {
+	sum float64
+	n   int
+}
+
+// PivotFile writes a cross-tabulation of inputPath to outputPath. The first
+// output column holds the distinct values of opts.Row and is headed by its
+// name; the remaining columns are the distinct values of opts.Col. Both keep
+// the order in which they first appear in the input. A cell with no data is
+// written as "".
+func PivotFile(inputPath, outputPath string, opts PivotOptions) (PivotStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return PivotStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return PivotStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return PivotReader(in, out, opts)
+}
+
+// PivotReader is PivotFile for an already-open input and output, e.g. when
+// writing to stdout. r is raw input; opts.Encoding is applied.
+func PivotReader(r io.Reader, w io.Writer, opts PivotOptions) (PivotStats, error) {
+	agg := opts.Agg
+	if agg == "" {
+		agg = PivotCount
+	}
+	if _, err := ParsePivotAgg(string(agg)); err != nil {
+		return PivotStats{}, err
+	}
+	if opts.Row == "" || opts.Col == "" {
+		return PivotStats{}, fmt.Errorf("pivot: row and column are required")
+	}
+	if agg != PivotCount && opts.Val == "" {
+		return PivotStats{}, fmt.Errorf("pivot: %s requires a value column", agg)
+	}
+
+	in, err := inputReader(r, opts.Options)
+	if err != nil {
+		return PivotStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return PivotStats{}, err
+	}
+
+	rowIdx, err := resolveColumn(headers, opts.Row)
+	if err != nil {
+		return PivotStats{}, err
+	}
+	colIdx, err := resolveColumn(headers, opts.Col)
+	if err != nil {
+		return PivotStats{}, err
+	}
+	valIdx := -1
+	if agg != PivotCount {
+		if valIdx, err = resolveColumn(headers, opts.Val); err != nil {
+			return PivotStats{}, err
+		}
+	}
+
+	var rowKeys, colKeys []string
+	seenCol := make(map[string]bool)
+	cells := make(map[string]map[string]*pivotCell)
+
+	stats := PivotStats{}
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		rec = normalizeRow(rec, len(headers))
+		rk, ck := rec[rowIdx], rec[colIdx]
+
+		byCol, ok := cells[rk]
+		if !ok {
+			byCol = make(map[string]*pivotCell)
+			cells[rk] = byCol
+			rowKeys = append(rowKeys, rk)
+		}
+		if !seenCol[ck] {
+			seenCol[ck] = true
+			colKeys = append(colKeys, ck)
+		}
+
+		v := 1.0
+		if valIdx >= 0 {
+			s := strings.TrimSpace(rec[valIdx])
+			if s == "" {
+				continue
+			}
+			if v, err = strconv.ParseFloat(s, 64); err != nil {
+				stats.ParseErrors++
+				continue
+			}
+		}
+
+		c, ok := byCol[ck]
+		if !ok {
+			c = &pivotCell{}
+			byCol[ck] = c
+		}
+		c.sum += v
+		c.n++
+	}
+	stats.RowKeys = len(rowKeys)
+	stats.ColKeys = len(colKeys)
+
+	outHeaders := append([]string{headers[rowIdx]}, colKeys...)
+	outRows := make([][]string, 0, len(rowKeys))
+	for _, rk := range rowKeys {
+		row := make([]string, 0, len(outHeaders))
+		row = append(row, rk)
+		for _, ck := range colKeys {
+			c, ok := cells[rk][ck]
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+			v := c.sum
+			if agg == PivotMean {
+				v /= float64(c.n)
+			}
+			row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		outRows = append(outRows, row)
+	}
+
+	return stats, WriteRowsToWriter(w, outHeaders, outRows, opts.Options)
+}
@@ -0,0 +1,79 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPivotReader(t *testing.T) {
+	const input = "region,month,amount\n" +
+		"east,jan,10\n" +
+		"west,jan,5\n" +
+		"east,feb,n/a\n" +
+		"east,jan,20\n" +
+		"west,feb,\n" +
+		"north,feb,7.5\n"
+
+	tests := []struct {
+		name string
+		agg  PivotAgg
+		want string
+	}{
+		{
+			name: "count",
+			agg:  "",
+			want: "region,jan,feb\neast,2,1\nwest,1,1\nnorth,,1\n",
+		},
+		{
+			name: "sum",
+			agg:  PivotSum,
+			want: "region,jan,feb\neast,30,\nwest,5,\nnorth,,7.5\n",
+		},
+		{
+			name: "mean",
+			agg:  PivotMean,
+			want: "region,jan,feb\neast,15,\nwest,5,\nnorth,,7.5\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			stats, err := PivotReader(strings.NewReader(input), &out, PivotOptions{
+				Row: "region", Col: "month", Val: "amount", Agg: tt.agg,
+			})
+			if err != nil {
+				t.Fatalf("PivotReader: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+
+			wantErrs := 1
+			if tt.agg == "" {
+				wantErrs = 0
+			}
+			want := PivotStats{RowsRead: 6, RowKeys: 3, ColKeys: 2, ParseErrors: wantErrs}
+			if stats != want {
+				t.Fatalf("stats = %+v, want %+v", stats, want)
+			}
+		})
+	}
+}
+
+func TestPivotReader_Errors(t *testing.T) {
+	const input = "a,b,c\n1,2,3\n"
+
+	if _, err := PivotReader(strings.NewReader(input), &bytes.Buffer{}, PivotOptions{Row: "a", Col: "b", Agg: PivotSum}); err == nil {
+		t.Fatal("expected error for sum without a value column")
+	}
+	if _, err := PivotReader(strings.NewReader(input), &bytes.Buffer{}, PivotOptions{Row: "a", Col: "b", Agg: "median"}); err == nil {
+		t.Fatal("expected error for unknown aggregation")
+	}
+	_, err := PivotReader(strings.NewReader(input), &bytes.Buffer{}, PivotOptions{Row: "a", Col: "missing"})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements the nullify preview: running the transform without
+// writing output, to see which cells a policy would change before applying
+// it to a real file.
+package csvio
+
+import (
+	"io"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+// DefaultPreviewLimit is the number of changed cells PreviewNullify
+// collects when given a limit <= 0.
+const DefaultPreviewLimit = 20
+
+// ChangedCell describes one cell that nullify changed (or would change).
+type ChangedCell struct {
+	// Row is the 1-based data row number (the header is not counted).
+	Row int
+
+	// Col is the column's header name.
+	Col string
+
+	// OriginalValue is the cell as read, before it was replaced with "".
+	OriginalValue string
+
+	// Reason is the rule that matched, as returned by
+	// nulls.Policy.IsNullWithReason (e.g. "na" or "sentinel:TBD").
+	Reason string
+}
+
+// PreviewNullify applies policy to the CSV read from src exactly like
+// NullifyReader but discards the output. It returns the first limit changed
+// cells, in input order, and the statistics for the whole input, so callers
+// can report both a sample and the total.
+//
+// The output-side options MultiWriter and ExplainWriter are ignored.
+func PreviewNullify(src io.Reader, policy nulls.Policy, opts NullifyOptions, limit int) ([]ChangedCell, NullifyStats, error) {
+	if limit <= 0 {
+		limit = DefaultPreviewLimit
+	}
+	opts.MultiWriter = nil
+	opts.ExplainWriter = nil
+
+	var cells []ChangedCell
+	stats, err := nullifyReader(src, io.Discard, policy, opts, func(c ChangedCell) {
+		if len(cells) < limit {
+			cells = append(cells, c)
+		}
+	})
+	return cells, stats, err
+}
@@ -0,0 +1,51 @@
+package csvio
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+func TestPreviewNullify(t *testing.T) {
+	src := "name,email,phone\nAnn,NA,\nBob,b@x,TBD\nCy,N/A,-\n"
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true, CustomSentinels: []string{"TBD"}}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []ChangedCell
+	}{
+		{
+			name:  "all",
+			limit: 0,
+			want: []ChangedCell{
+				{Row: 1, Col: "email", OriginalValue: "NA", Reason: nulls.ReasonNA},
+				{Row: 2, Col: "phone", OriginalValue: "TBD", Reason: nulls.ReasonSentinelPrefix + "TBD"},
+				{Row: 3, Col: "email", OriginalValue: "N/A", Reason: nulls.ReasonNA},
+			},
+		},
+		{
+			name:  "capped",
+			limit: 1,
+			want:  []ChangedCell{{Row: 1, Col: "email", OriginalValue: "NA", Reason: nulls.ReasonNA}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells, stats, err := PreviewNullify(strings.NewReader(src), policy, NullifyOptions{}, tt.limit)
+			if err != nil {
+				t.Fatalf("PreviewNullify: %v", err)
+			}
+			if !reflect.DeepEqual(cells, tt.want) {
+				t.Fatalf("cells = %+v, want %+v", cells, tt.want)
+			}
+			// Stats always cover the whole input, whatever the cap.
+			if stats.RowsRead != 3 || stats.CellsNullified != 3 {
+				t.Fatalf("stats = %+v", stats)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file supports CSV-like files quoted with a character other than the
+// double quote, e.g. 'Smith, Ann' or `a,b`. encoding/csv only knows the
+// double quote, so the input is rewritten to standard quoting before it is
+// parsed.
+package csvio
+
+import (
+	"bufio"
+	"io"
+	"unicode/utf8"
+)
+
+// quoteTranslator rewrites input quoted with quote into standard
+// double-quoted CSV:
+//
+//   - quote at the start of a field opens a quoted field and becomes '"';
+//     the matching closing quote becomes '"' too;
+//   - a doubled quote inside a quoted field is a literal quote character;
+//   - a literal '"' inside a quoted field is doubled so it stays text;
+//   - quote anywhere else, e.g. the apostrophe in O'Brien, is plain text.
+//
+// A literal '"' in an unquoted field is passed through; newReader enables
+// LazyQuotes so the parser keeps it as text (unless it starts the field).
+type quoteTranslator struct {
+	r     *bufio.Reader
+	quote rune
+	delim rune
+
+	quoted     bool // inside a quoted field
+	fieldStart bool // the next rune starts a field
+	closing    bool // saw quote inside a quoted field; it closes the field unless doubled
+
+	out []byte
+	err error
+}
+
+func newQuoteTranslator(r io.Reader, quote, delim rune) *quoteTranslator {
+	if delim == 0 {
+		delim = ','
+	}
+	return &quoteTranslator{r: bufio.NewReader(r), quote: quote, delim: delim, fieldStart: true}
+}
+
+func (t *quoteTranslator) Read(p []byte) (int, error) {
+	for len(t.out) < len(p) && t.err == nil {
+		c, _, err := t.r.ReadRune()
+		if err != nil {
+			t.err = err
+			if t.closing {
+				t.out = append(t.out, '"')
+				t.closing = false
+			}
+			break
+		}
+		t.translate(c)
+	}
+
+	n := copy(p, t.out)
+	t.out = append(t.out[:0], t.out[n:]...)
+	if n == 0 && t.err != nil {
+		return 0, t.err
+	}
+	return n, nil
+}
+
+// translate appends the standard-CSV form of c to t.out.
+func (t *quoteTranslator) translate(c rune) {
+	if t.closing {
+		t.closing = false
+		if c == t.quote {
+			t.out = utf8.AppendRune(t.out, c)
+			return
+		}
+		t.out = append(t.out, '"')
+		t.quoted = false
+		t.fieldStart = false
+	}
+
+	if t.quoted {
+		switch c {
+		case t.quote:
+			t.closing = true
+		case '"':
+			t.out = append(t.out, '"', '"')
+		default:
+			t.out = utf8.AppendRune(t.out, c)
+		}
+		return
+	}
+
+	switch {
+	case c == t.quote && t.fieldStart:
+		t.out = append(t.out, '"')
+		t.quoted = true
+		t.fieldStart = false
+	case c == t.delim || c == '\n':
+		t.out = utf8.AppendRune(t.out, c)
+		t.fieldStart = true
+	default:
+		t.out = utf8.AppendRune(t.out, c)
+		t.fieldStart = false
+	}
+}
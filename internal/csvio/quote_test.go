@@ -0,0 +1,75 @@
+package csvio
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQuoteChar(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		opts    Options
+		want    [][]string
+	}{
+		{
+			name: "single quotes",
+			content: "name,note\n" +
+				"'Smith, Ann','said \"hi\"'\n" +
+				"O'Brien,'it''s fine'\n" +
+				"'two\nlines',plain\n",
+			opts: Options{QuoteChar: '\''},
+			want: [][]string{
+				{"Smith, Ann", `said "hi"`},
+				{"O'Brien", "it's fine"},
+				{"two\nlines", "plain"},
+			},
+		},
+		{
+			name:    "backticks with semicolons",
+			content: "name;note\n`a;b`;5\" screen\n",
+			opts:    Options{QuoteChar: '`', Delimiter: ';'},
+			want:    [][]string{{"a;b", `5" screen`}},
+		},
+		{
+			name:    "double quote is standard",
+			content: "name,note\n\"a,b\",c\n",
+			opts:    Options{QuoteChar: '"'},
+			want:    [][]string{{"a,b", "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, rows, err := ReadHeadFromReader(strings.NewReader(tt.content), 10, ReadOptions{Options: tt.opts})
+			if err != nil {
+				t.Fatalf("ReadHeadFromReader: %v", err)
+			}
+			if want := []string{"name", "note"}; !reflect.DeepEqual(headers, want) {
+				t.Fatalf("headers = %q, want %q", headers, want)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Fatalf("rows = %q, want %q", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteTranslator_SmallReads(t *testing.T) {
+	// One-byte reads exercise a closing quote split from the next rune.
+	tr := newQuoteTranslator(strings.NewReader("'a''b','é'\n"), '\'', ',')
+
+	var got strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := tr.Read(buf)
+		got.Write(buf[:n])
+		if err != nil {
+			break
+		}
+	}
+	if want := "\"a'b\",\"é\"\n"; got.String() != want {
+		t.Fatalf("translated = %q, want %q", got.String(), want)
+	}
+}
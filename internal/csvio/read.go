@@ -17,37 +17,323 @@
 package csvio
 
 import (
+	"bufio"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
-	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// Options controls how CSV input is parsed.
+//
+// The zero value matches the historical behavior of this package: comma
+// delimited, strict quoting, and variable field counts accepted (rows are
+// normalized to the header width afterwards).
+type Options struct {
+	// Delimiter is the field separator. Zero means ','.
+	Delimiter rune
+
+	// LazyQuotes relaxes quote handling, mirroring csv.Reader.LazyQuotes.
+	// Useful for files with stray quotes inside unquoted fields.
+	LazyQuotes bool
+
+	// QuoteChar is the character that quotes fields, for files that use
+	// e.g. ' or ` instead of ". Zero or '"' means standard CSV quoting.
+	// Input quoted with another character is rewritten to standard quoting
+	// before parsing, with LazyQuotes enabled so literal double quotes in
+	// the data stay text. Output is always written with double quotes.
+	QuoteChar rune
+
+	// TrimLeadingSpace drops leading whitespace from each field at parse time,
+	// mirroring csv.Reader.TrimLeadingSpace. Trailing whitespace is kept.
+	TrimLeadingSpace bool
+
+	// AutoDetectHeader guesses whether the first record is a header using
+	// LikelyHasHeader. When it looks like data, synthesized names (col_0,
+	// col_1, ...) are used as headers and the first record is returned as a
+	// data row. When false, the first record is always the header.
+	AutoDetectHeader bool
+
+	// NoHeader declares that the input has no header row: every record is
+	// data, and synthesized names (col_0, col_1, ...) sized from the first
+	// record are used as headers. It overrides AutoDetectHeader.
+	NoHeader bool
+
+	// Encoding names the input character encoding (see the Encoding*
+	// constants, e.g. "latin1" or "windows-1252"). Input is transcoded to
+	// UTF-8 while reading. "" means UTF-8 with no transformation.
+	Encoding string
+
+	// Comment, when non-zero, marks comment lines: a line starting with it
+	// is skipped wherever it appears, before the header or between rows,
+	// mirroring csv.Reader.Comment. It must differ from the delimiter.
+	Comment rune
+
+	// SkipLines drops this many physical lines from the start of the input,
+	// whatever they contain, before CSV parsing begins. Use it for preamble
+	// rows that are not marked as comments.
+	SkipLines int
+
+	// HTTPTimeout bounds each http:// or https:// input, from sending the
+	// request to reading the last byte of the body. Zero means
+	// DefaultHTTPTimeout.
+	HTTPTimeout time.Duration
+}
+
+// ReadOptions controls ReadHead and ReadSlice, which return rows in memory.
+type ReadOptions struct {
+	// Options controls how the input is parsed.
+	Options
+
+	// Strict fails on the first data row whose field count differs from
+	// the header's, instead of padding or truncating it. The error names
+	// the data row (1-based, header not counted) and wraps
+	// csv.ErrFieldCount.
+	Strict bool
+}
+
+// openCSV opens path for reading (see OpenInputWithOptions; "-" is stdin) and
+// applies the input-level options, currently just the encoding (see
+// decodeReader). Callers must Close the result and add their own error
+// context.
+func openCSV(path string, opts Options) (io.ReadCloser, error) {
+	f, err := OpenInputWithOptions(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decodeReader(f, opts.Encoding)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return readCloser{Reader: r, Closer: f}, nil
+}
+
+// inputReader wraps raw file bytes with the input-level options (currently
+// transcoding to UTF-8) so the CSV parser sees plain UTF-8 text.
+func inputReader(r io.Reader, opts Options) (io.Reader, error) {
+	return decodeReader(r, opts.Encoding)
+}
+
+// readCloser pairs a (possibly wrapped) reader with the file that must be
+// closed underneath it.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// newReader returns a csv.Reader configured from opts.
+//
+// FieldsPerRecord is -1: jagged rows are accepted here and normalized
+// explicitly via normalizeRow (ReadOptions.Strict resets it to enforce the
+// header width). A leading UTF-8 BOM, as written by Excel, is
+// dropped so it does not end up glued to the first header.
+func newReader(r io.Reader, opts Options) *csv.Reader {
+	r = stripBOM(r)
+	if opts.SkipLines > 0 {
+		r = &lineSkipper{r: bufio.NewReader(r), n: opts.SkipLines}
+	}
+	customQuote := opts.QuoteChar != 0 && opts.QuoteChar != '"'
+	if customQuote {
+		r = newQuoteTranslator(r, opts.QuoteChar, opts.Delimiter)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	if opts.Delimiter != 0 {
+		cr.Comma = opts.Delimiter
+	}
+	cr.Comment = opts.Comment
+	cr.LazyQuotes = opts.LazyQuotes || customQuote
+	cr.TrimLeadingSpace = opts.TrimLeadingSpace
+	return cr
+}
+
+// lineSkipper discards the first n lines of r on the first Read. Lines are
+// split on '\n' only, without regard to CSV quoting, so a preamble line is
+// skipped even if it contains an unbalanced quote.
+type lineSkipper struct {
+	r *bufio.Reader
+	n int
+}
+
+func (s *lineSkipper) Read(p []byte) (int, error) {
+	for s.n > 0 {
+		_, err := s.r.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		s.n--
+	}
+	return s.r.Read(p)
+}
+
+// newWriter returns a csv.Writer configured from opts. Only the delimiter
+// applies to output; the other Options fields are parse-time settings.
+func newWriter(w io.Writer, opts Options) *csv.Writer {
+	cw := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		cw.Comma = opts.Delimiter
+	}
+	return cw
+}
+
+// fileOptions returns the read options for the i-th of several inputs:
+// per[i], the last entry of per when it is shorter, or base when per is
+// empty.
+func fileOptions(base Options, per []Options, i int) Options {
+	if len(per) == 0 {
+		return base
+	}
+	return per[min(i, len(per)-1)]
+}
+
+// rowReader yields data records. Records consumed while detecting the header
+// are replayed first, so callers never lose a row to detection.
+type rowReader struct {
+	r       *csv.Reader
+	pending [][]string
+
+	// headerless reports that the input had no header row and the headers
+	// returned alongside this reader were synthesized.
+	headerless bool
+}
+
+// Read returns the next data record, or io.EOF when the input is exhausted.
+func (rr *rowReader) Read() ([]string, error) {
+	if len(rr.pending) > 0 {
+		rec := rr.pending[0]
+		rr.pending = rr.pending[1:]
+		return rec, nil
+	}
+	return rr.r.Read()
+}
+
+// readHeader reads the header row from r according to opts and returns a
+// rowReader positioned at the first data row.
+//
+// Errors are wrapped with "read headers" context.
+func readHeader(r *csv.Reader, opts Options) ([]string, *rowReader, error) {
+	first, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read headers: %w", err)
+	}
+	if opts.NoHeader {
+		rr := &rowReader{r: r, pending: [][]string{first}, headerless: true}
+		return synthesizeHeaders(len(first)), rr, nil
+	}
+	if !opts.AutoDetectHeader {
+		return first, &rowReader{r: r}, nil
+	}
+
+	// Peek at the second record for comparison; a header-only or one-line
+	// file simply has none.
+	rr := &rowReader{r: r}
+	second, err := r.Read()
+	switch {
+	case err == io.EOF:
+	case err != nil:
+		return nil, nil, fmt.Errorf("read row: %w", err)
+	default:
+		rr.pending = append(rr.pending, second)
+	}
+
+	if LikelyHasHeader(first, second) {
+		return first, rr, nil
+	}
+
+	rr.pending = append([][]string{first}, rr.pending...)
+	rr.headerless = true
+	return synthesizeHeaders(len(first)), rr, nil
+}
+
+// ErrColumnNotFound is returned (wrapped) when a requested column does not
+// exist in the header row. The CLI maps it to a usage error.
+var ErrColumnNotFound = errors.New("column not found")
+
+// ColumnIndex returns the zero-based index of the first header matching name
+// (case-insensitive). If no header matches, the error wraps ErrColumnNotFound
+// and lists the available columns so CLI users can correct typos without
+// opening the file.
+func ColumnIndex(headers []string, name string) (int, error) {
+	for i, h := range headers {
+		if strings.EqualFold(h, name) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %q (available: %s)", ErrColumnNotFound, name, strings.Join(headers, ", "))
+}
+
+// ColumnIndices is ColumnIndex for several names, returning their indexes in
+// the order given. It fails on the first name that matches no header.
+func ColumnIndices(headers []string, names []string) ([]int, error) {
+	idx := make([]int, len(names))
+	for i, name := range names {
+		var err error
+		if idx[i], err = ColumnIndex(headers, name); err != nil {
+			return nil, err
+		}
+	}
+	return idx, nil
+}
+
+// resolveColumn is ColumnIndex with a fallback for callers that also accept a
+// zero-based column index: when name matches no header but parses as an
+// in-range integer, that index is returned. A header literally named "2" wins
+// over index 2.
+func resolveColumn(headers []string, name string) (int, error) {
+	idx, err := ColumnIndex(headers, name)
+	if err == nil {
+		return idx, nil
+	}
+	if n, convErr := strconv.Atoi(name); convErr == nil && n >= 0 && n < len(headers) {
+		return n, nil
+	}
+	return -1, err
+}
+
 // ReadHeaders reads and returns only the header row from a CSV file.
 //
 // The returned slice is the column names exactly as they appear in the file.
 // This function does not attempt to trim whitespace, de-duplicate names, or
 // validate "meaningful" headers; callers decide what to do with the result.
 //
+// Parsing is configured via opts; the zero value reads standard CSV.
+//
 // Errors are wrapped with context (e.g. "open csv", "read headers") to make
 // CLI error messages more actionable.
-func ReadHeaders(path string) ([]string, error) {
+func ReadHeaders(path string, opts Options) ([]string, error) {
 	// Open the file for reading.
-	f, err := os.Open(path)
+	f, err := OpenInputWithOptions(path, opts)
 	if err != nil {
 		return nil, fmt.Errorf("open csv: %w", err)
 	}
 	defer f.Close()
 
-	// Use the standard library CSV reader. FieldsPerRecord = -1 tells the reader
-	// not to enforce a consistent field count per row. We normalize later based
-	// on header width.
-	r := csv.NewReader(f)
-	r.FieldsPerRecord = -1
+	return ReadHeadersFromReader(f, opts)
+}
+
+// ReadHeadersFromReader is ReadHeaders for CSV read from r, such as stdin, a
+// network response, or the output of another transform. Only the header row
+// is consumed.
+func ReadHeadersFromReader(r io.Reader, opts Options) ([]string, error) {
+	in, err := inputReader(r, opts)
+	if err != nil {
+		return nil, fmt.Errorf("open csv: %w", err)
+	}
 
-	headers, err := r.Read()
+	// newReader sets FieldsPerRecord = -1, which tells the reader not to enforce
+	// a consistent field count per row. We normalize later based on header width.
+	headers, _, err := readHeader(newReader(in, opts), opts)
 	if err != nil {
-		return nil, fmt.Errorf("read headers: %w", err)
+		return nil, err
 	}
 
 	return headers, nil
@@ -61,36 +347,85 @@ func ReadHeaders(path string) ([]string, error) {
 //   - If a row has more fields than headers, extra fields are discarded.
 //
 // This mirrors how many spreadsheet workflows behave: headers define the schema,
-// and every record is forced to match that schema.
+// and every record is forced to match that schema. With opts.Strict a jagged
+// row is an error instead.
 //
 // Note: if n is 0, the function returns headers and an empty row slice.
-func ReadHead(path string, n int) ([]string, [][]string, error) {
-	f, err := os.Open(path)
+func ReadHead(path string, n int, opts ReadOptions) ([]string, [][]string, error) {
+	f, err := OpenInputWithOptions(path, opts.Options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	return ReadHeadFromReader(f, n, opts)
+}
+
+// ReadHeadFromReader is ReadHead for CSV read from r. Reading stops after n
+// data rows, so the rest of r is left unread.
+func ReadHeadFromReader(r io.Reader, n int, opts ReadOptions) ([]string, [][]string, error) {
+	return ReadSliceFromReader(r, 0, n, opts)
+}
+
+// ReadSlice is ReadHead starting offset data rows into the file: the first
+// offset rows are read and discarded, then up to n rows are returned. Skipped
+// rows are streamed past, so memory use is bounded by n.
+//
+// An offset at or past the end of the file returns headers and no rows.
+func ReadSlice(path string, offset, n int, opts ReadOptions) ([]string, [][]string, error) {
+	f, err := OpenInputWithOptions(path, opts.Options)
 	if err != nil {
 		return nil, nil, fmt.Errorf("open csv: %w", err)
 	}
 	defer f.Close()
 
-	r := csv.NewReader(f)
-	r.FieldsPerRecord = -1
+	return ReadSliceFromReader(f, offset, n, opts)
+}
 
-	// The first record is treated as headers, not data.
-	headers, err := r.Read()
+// ReadSliceFromReader is ReadSlice for CSV read from r.
+func ReadSliceFromReader(r io.Reader, offset, n int, opts ReadOptions) ([]string, [][]string, error) {
+	in, err := inputReader(r, opts.Options)
 	if err != nil {
-		return nil, nil, fmt.Errorf("read headers: %w", err)
+		return nil, nil, fmt.Errorf("open csv: %w", err)
+	}
+
+	// In strict mode FieldsPerRecord = 0 makes the csv package require every
+	// record to have as many fields as the first one, the header.
+	cr := newReader(in, opts.Options)
+	if opts.Strict {
+		cr.FieldsPerRecord = 0
+	}
+
+	// The first record is treated as headers, not data (unless header
+	// auto-detection decides otherwise).
+	headers, rr, err := readHeader(cr, opts.Options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Skip offset records without normalizing them.
+	for i := 0; i < offset; i++ {
+		rec, err := rr.Read()
+		if err == io.EOF {
+			return headers, [][]string{}, nil
+		}
+		if err != nil {
+			return nil, nil, rowError(err, i+1, len(headers), rec)
+		}
 	}
 
-	// Pre-allocate capacity for n rows to reduce allocations when n is small.
-	rows := make([][]string, 0, n)
+	// Pre-allocate capacity for n rows to reduce allocations when n is small;
+	// n may be huge (e.g. "to end of file"), so cap the up-front allocation.
+	rows := make([][]string, 0, min(n, readAllInitialRows))
 
 	// Read up to n records, stopping early on EOF.
 	for len(rows) < n {
-		rec, err := r.Read()
+		rec, err := rr.Read()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, nil, fmt.Errorf("read row: %w", err)
+			return nil, nil, rowError(err, offset+len(rows)+1, len(headers), rec)
 		}
 
 		rows = append(rows, normalizeRow(rec, len(headers)))
@@ -99,6 +434,135 @@ func ReadHead(path string, n int) ([]string, [][]string, error) {
 	return headers, rows, nil
 }
 
+// rowError adds "read row" context to an error from reading data row row
+// (1-based). A field count mismatch, which only strict reads report, is
+// described by row rather than by the csv package's line number, e.g.
+// "row 7: expected 5 fields, got 3".
+func rowError(err error, row, want int, rec []string) error {
+	if errors.Is(err, csv.ErrFieldCount) {
+		return fmt.Errorf("row %d: expected %d fields, got %d: %w", row, want, len(rec), csv.ErrFieldCount)
+	}
+	return fmt.Errorf("read row: %w", err)
+}
+
+// ReadTail reads a CSV file and returns its headers along with the last n data
+// rows, in file order.
+//
+// CSV cannot be read backwards reliably (quoted fields may contain newlines),
+// so the file is streamed forward while a ring buffer keeps only the most
+// recent n rows; memory use is bounded by n, not file size. Rows are
+// normalized to the header width exactly like ReadHead.
+//
+// If n is 0, or the file has only a header, the row slice is empty. Files
+// with fewer than n rows return all of them.
+//
+// total is the number of data rows in the file, so the first returned row
+// is data row total-len(rows) (zero-based), e.g. for
+// render.TableOptions.RowIndexStart.
+func ReadTail(path string, n int, opts Options) (headers []string, rows [][]string, total int, err error) {
+	f, err := openCSV(path, opts)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	r := NewReader(f, opts)
+	headers, err = r.Headers()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	rows, err = r.ReadTailN(n)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("read row: %w", err)
+	}
+
+	return headers, rows, r.rowsRead, nil
+}
+
+// CountRows returns the number of data rows in a CSV file (the header is not
+// counted).
+//
+// Rows are counted as CSV records, not lines, so quoted fields containing
+// newlines are handled correctly (unlike "wc -l"). The file is streamed and
+// no rows are retained, so memory use is constant.
+func CountRows(path string, opts Options) (int, error) {
+	f, err := openCSV(path, opts)
+	if err != nil {
+		return 0, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	_, r, err := readHeader(newReader(f, opts), opts)
+	if err != nil {
+		return 0, err
+	}
+
+	// Reuse the record slice; only the count matters.
+	r.r.ReuseRecord = true
+
+	n := 0
+	for {
+		_, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, fmt.Errorf("read row: %w", err)
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+// ReadAll reads a CSV file and returns its headers and every data row,
+// normalized to the header width exactly like ReadHead.
+//
+// Memory use is O(n) in the file size: every row is held in memory at once.
+// Use it only for operations that cannot stream, such as sorting; prefer
+// ReadHead, ReadTail or a streaming operation for large files.
+func ReadAll(path string, opts Options) ([]string, [][]string, error) {
+	f, err := OpenInputWithOptions(path, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	return ReadAllFromReader(f, opts)
+}
+
+// readAllInitialRows is the initial capacity of the ReadAll row slice, enough
+// for typical small files without early reallocations.
+const readAllInitialRows = 512
+
+// ReadAllFromReader is ReadAll for CSV read from r, which is read to EOF.
+func ReadAllFromReader(r io.Reader, opts Options) ([]string, [][]string, error) {
+	in, err := inputReader(r, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open csv: %w", err)
+	}
+
+	headers, rr, err := readHeader(newReader(in, opts), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows := make([][]string, 0, readAllInitialRows)
+	for {
+		rec, err := rr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("read row: %w", err)
+		}
+		rows = append(rows, normalizeRow(rec, len(headers)))
+	}
+
+	return headers, rows, nil
+}
+
 // normalizeRow coerces a CSV record to a fixed width.
 //
 // If row is already the desired width, it is returned as-is.
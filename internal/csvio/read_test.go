@@ -0,0 +1,301 @@
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadHead_TrimLeadingSpace(t *testing.T) {
+	path := writeTemp(t, "in.csv", "name,city\nAnn,  Albany \n")
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{name: "default preserves", opts: Options{}, want: []string{"Ann", "  Albany "}},
+		{name: "trim enabled", opts: Options{TrimLeadingSpace: true}, want: []string{"Ann", "Albany "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, rows, err := ReadHead(path, 1, ReadOptions{Options: tt.opts})
+			if err != nil {
+				t.Fatalf("ReadHead: %v", err)
+			}
+			if len(rows) != 1 || !reflect.DeepEqual(rows[0], tt.want) {
+				t.Fatalf("got %q, want %q", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadTail(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		n       int
+		want    [][]string
+		total   int
+	}{
+		{name: "last two", content: "n\n1\n2\n3\n", n: 2, want: [][]string{{"2"}, {"3"}}, total: 3},
+		{name: "shorter than n", content: "n\n1\n2\n", n: 5, want: [][]string{{"1"}, {"2"}}, total: 2},
+		{name: "zero", content: "n\n1\n", n: 0, want: [][]string{}, total: 1},
+		{name: "header only", content: "n\n", n: 3, want: [][]string{}, total: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTemp(t, "in.csv", tt.content)
+			headers, rows, total, err := ReadTail(path, tt.n, Options{})
+			if err != nil {
+				t.Fatalf("ReadTail: %v", err)
+			}
+			if !reflect.DeepEqual(headers, []string{"n"}) {
+				t.Fatalf("headers = %q", headers)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Fatalf("rows = %q, want %q", rows, tt.want)
+			}
+			if total != tt.total {
+				t.Fatalf("total = %d, want %d", total, tt.total)
+			}
+		})
+	}
+}
+
+func TestCountRows_QuotedNewlines(t *testing.T) {
+	path := writeTemp(t, "in.csv", "name,note\nAnn,\"two\nlines\"\nBob,x\n")
+
+	n, err := CountRows(path, Options{})
+	if err != nil {
+		t.Fatalf("CountRows: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("CountRows = %d, want 2", n)
+	}
+}
+
+func TestReadHeadFromReader(t *testing.T) {
+	r := strings.NewReader("name,zip\nAnn,12207\nBob\nCy,14202\n")
+
+	headers, rows, err := ReadHeadFromReader(r, 2, ReadOptions{})
+	if err != nil {
+		t.Fatalf("ReadHeadFromReader: %v", err)
+	}
+	if !reflect.DeepEqual(headers, []string{"name", "zip"}) {
+		t.Fatalf("headers = %q", headers)
+	}
+	want := [][]string{{"Ann", "12207"}, {"Bob", ""}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestReadHeadersFromReader(t *testing.T) {
+	headers, err := ReadHeadersFromReader(strings.NewReader("name;zip\nAnn;12207\n"), Options{Delimiter: ';'})
+	if err != nil {
+		t.Fatalf("ReadHeadersFromReader: %v", err)
+	}
+	if !reflect.DeepEqual(headers, []string{"name", "zip"}) {
+		t.Fatalf("headers = %q", headers)
+	}
+}
+
+func TestReadHeadersFromReader_Empty(t *testing.T) {
+	if _, err := ReadHeadersFromReader(strings.NewReader(""), Options{}); err == nil {
+		t.Fatalf("expected error for empty input")
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	path := writeTemp(t, "in.csv", "name,zip\nAnn,12207\nBob\nCy,14202,extra\n")
+
+	headers, rows, err := ReadAll(path, Options{})
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !reflect.DeepEqual(headers, []string{"name", "zip"}) {
+		t.Fatalf("headers = %q", headers)
+	}
+	want := [][]string{{"Ann", "12207"}, {"Bob", ""}, {"Cy", "14202"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("rows = %q, want %q", rows, want)
+	}
+}
+
+func TestReadAll_MissingFile(t *testing.T) {
+	if _, _, err := ReadAll(filepath.Join(t.TempDir(), "missing.csv"), Options{}); err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	headers := []string{"Name", "zip", "name"}
+
+	tests := []struct {
+		name    string
+		col     string
+		want    int
+		wantErr bool
+	}{
+		{name: "exact match", col: "zip", want: 1},
+		{name: "case-insensitive", col: "ZIP", want: 1},
+		{name: "duplicate returns first", col: "name", want: 0},
+		{name: "missing", col: "city", want: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ColumnIndex(headers, tt.col)
+			if tt.wantErr {
+				if !errors.Is(err, ErrColumnNotFound) {
+					t.Fatalf("err = %v, want ErrColumnNotFound", err)
+				}
+				if !strings.Contains(err.Error(), "Name, zip, name") {
+					t.Fatalf("error does not list available columns: %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("ColumnIndex: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("ColumnIndex = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnIndices(t *testing.T) {
+	headers := []string{"Name", "zip", "name"}
+
+	tests := []struct {
+		name    string
+		cols    []string
+		want    []int
+		wantErr bool
+	}{
+		{name: "exact match", cols: []string{"zip", "Name"}, want: []int{1, 0}},
+		{name: "case-insensitive", cols: []string{"ZIP"}, want: []int{1}},
+		{name: "duplicate returns first", cols: []string{"NAME", "name"}, want: []int{0, 0}},
+		{name: "empty", cols: nil, want: []int{}},
+		{name: "missing", cols: []string{"zip", "city"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ColumnIndices(headers, tt.cols)
+			if tt.wantErr {
+				if !errors.Is(err, ErrColumnNotFound) {
+					t.Fatalf("err = %v, want ErrColumnNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ColumnIndices: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ColumnIndices = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadHead_Strict(t *testing.T) {
+	path := writeTemp(t, "in.csv", "a,b,c\n1,2,3\n4,5\n")
+
+	_, rows, err := ReadHead(path, 5, ReadOptions{})
+	if err != nil {
+		t.Fatalf("ReadHead: %v", err)
+	}
+	if want := []string{"4", "5", ""}; !reflect.DeepEqual(rows[1], want) {
+		t.Fatalf("rows[1] = %q, want %q", rows[1], want)
+	}
+
+	_, _, err = ReadHead(path, 5, ReadOptions{Strict: true})
+	if !errors.Is(err, csv.ErrFieldCount) {
+		t.Fatalf("err = %v, want csv.ErrFieldCount", err)
+	}
+	if want := "row 2: expected 3 fields, got 2"; !strings.HasPrefix(err.Error(), want) {
+		t.Fatalf("err = %q, want prefix %q", err, want)
+	}
+
+	// Rows skipped by the offset are checked too.
+	if _, _, err := ReadSlice(path, 2, 1, ReadOptions{Strict: true}); err == nil || !strings.HasPrefix(err.Error(), "row 2:") {
+		t.Fatalf("ReadSlice err = %v, want row 2 error", err)
+	}
+}
+
+func TestReadHead_CommentAndSkipLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		opts    Options
+	}{
+		{
+			name:    "comments before header and between rows",
+			content: "# Generated by ExportTool v2.3\n#\nname,zip\nAnn,12207\n# page 2\nBob,10001\n",
+			opts:    Options{Comment: '#'},
+		},
+		{
+			name:    "skip lines",
+			content: "Export \"Contacts\", 2024\n\nname,zip\nAnn,12207\nBob,10001\n",
+			opts:    Options{SkipLines: 2},
+		},
+		{
+			name:    "skip lines then comments",
+			content: "REPORT\nname,zip\nAnn,12207\n;Bob,99999\nBob,10001\n",
+			opts:    Options{SkipLines: 1, Comment: ';'},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTemp(t, "in.csv", tt.content)
+
+			headers, rows, err := ReadHead(path, 5, ReadOptions{Options: tt.opts})
+			if err != nil {
+				t.Fatalf("ReadHead: %v", err)
+			}
+			if want := []string{"name", "zip"}; !reflect.DeepEqual(headers, want) {
+				t.Fatalf("headers = %q, want %q", headers, want)
+			}
+			if want := [][]string{{"Ann", "12207"}, {"Bob", "10001"}}; !reflect.DeepEqual(rows, want) {
+				t.Fatalf("rows = %q, want %q", rows, want)
+			}
+		})
+	}
+}
+
+func TestReadSlice(t *testing.T) {
+	path := writeTemp(t, "in.csv", "n\n0\n1\n2\n3\n")
+
+	tests := []struct {
+		name      string
+		offset, n int
+		want      [][]string
+	}{
+		{name: "middle", offset: 1, n: 2, want: [][]string{{"1"}, {"2"}}},
+		{name: "runs past end", offset: 3, n: 5, want: [][]string{{"3"}}},
+		{name: "offset past end", offset: 10, n: 2, want: [][]string{}},
+		{name: "zero offset is ReadHead", offset: 0, n: 1, want: [][]string{{"0"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers, rows, err := ReadSlice(path, tt.offset, tt.n, ReadOptions{})
+			if err != nil {
+				t.Fatalf("ReadSlice: %v", err)
+			}
+			if !reflect.DeepEqual(headers, []string{"n"}) {
+				t.Fatalf("headers = %q", headers)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Fatalf("rows = %q, want %q", rows, tt.want)
+			}
+		})
+	}
+}
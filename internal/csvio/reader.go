@@ -0,0 +1,130 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file provides Reader, a streaming record reader that applies the same
+// header handling and width normalization as the path-based helpers, for
+// callers that already hold an io.Reader or need finer control.
+package csvio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Reader reads a CSV stream as a header row followed by normalized data rows.
+//
+// Typical use:
+//
+//	r := csvio.NewReader(f, csvio.Options{})
+//	headers, err := r.Headers()
+//	for {
+//		row, err := r.Read()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+//
+// Every row returned by Read or ReadTailN has exactly len(headers) fields.
+type Reader struct {
+	opts    Options
+	src     io.Reader
+	headers []string
+	rows    *rowReader
+
+	// readCalled records that Read has consumed at least one row, which makes
+	// ReadTailN meaningless.
+	readCalled bool
+
+	// rowsRead counts the data rows returned by Read so far.
+	rowsRead int
+}
+
+// NewReader returns a Reader that parses r according to opts. r is raw
+// input; opts.Encoding is applied.
+func NewReader(r io.Reader, opts Options) *Reader {
+	return &Reader{opts: opts, src: r}
+}
+
+// Headers returns the header row, reading it on first use. Later calls return
+// the same slice.
+func (r *Reader) Headers() ([]string, error) {
+	if r.rows != nil {
+		return r.headers, nil
+	}
+
+	in, err := inputReader(r.src, r.opts)
+	if err != nil {
+		return nil, fmt.Errorf("open input csv: %w", err)
+	}
+	headers, rows, err := readHeader(newReader(in, r.opts), r.opts)
+	if err != nil {
+		return nil, err
+	}
+	r.headers, r.rows = headers, rows
+	return r.headers, nil
+}
+
+// Read returns the next data row, normalized to the header width, or io.EOF
+// at the end of input. The header is read first if Headers has not been
+// called yet.
+func (r *Reader) Read() ([]string, error) {
+	if _, err := r.Headers(); err != nil {
+		return nil, err
+	}
+
+	rec, err := r.rows.Read()
+	if err != nil {
+		return nil, err
+	}
+	r.readCalled = true
+	r.rowsRead++
+	return normalizeRow(rec, len(r.headers)), nil
+}
+
+// ReadTailN consumes the rest of the input and returns its last n data rows in
+// file order. Fewer rows are returned if the input is shorter than n.
+//
+// Only n rows are held in memory at a time (a ring buffer), so this is safe
+// on large inputs. ReadTailN must be called after Headers, which fixes the
+// row width, and before any call to Read; otherwise it returns an error.
+func (r *Reader) ReadTailN(n int) ([][]string, error) {
+	if r.rows == nil {
+		return nil, errors.New("read tail: Headers must be called first")
+	}
+	if r.readCalled {
+		return nil, errors.New("read tail: rows already consumed by Read")
+	}
+	if n <= 0 {
+		// Still consume the input, so the row count is complete.
+		for {
+			if _, err := r.Read(); err == io.EOF {
+				return [][]string{}, nil
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ring := make([][]string, n)
+	total := 0
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ring[total%n] = rec
+		total++
+	}
+
+	// Unroll the ring so the oldest kept row comes first.
+	count := min(total, n)
+	tail := make([][]string, 0, count)
+	for i := total - count; i < total; i++ {
+		tail = append(tail, ring[i%n])
+	}
+	return tail, nil
+}
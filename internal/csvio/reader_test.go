@@ -0,0 +1,83 @@
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// numberedCSV returns a single-column CSV with rows "0".."rows-1".
+func numberedCSV(rows int) string {
+	var b strings.Builder
+	b.WriteString("n\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&b, "%d\n", i)
+	}
+	return b.String()
+}
+
+func TestReader_ReadTailN(t *testing.T) {
+	tests := []struct {
+		name string
+		rows int
+		n    int
+		want [][]string
+	}{
+		{name: "last five of ten", rows: 10, n: 5, want: [][]string{{"5"}, {"6"}, {"7"}, {"8"}, {"9"}}},
+		{name: "short file", rows: 3, n: 5, want: [][]string{{"0"}, {"1"}, {"2"}}},
+		{name: "zero", rows: 3, n: 0, want: [][]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(numberedCSV(tt.rows)), Options{})
+			if _, err := r.Headers(); err != nil {
+				t.Fatalf("Headers: %v", err)
+			}
+			got, err := r.ReadTailN(tt.n)
+			if err != nil {
+				t.Fatalf("ReadTailN: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReader_ReadTailNAfterRead(t *testing.T) {
+	r := NewReader(strings.NewReader(numberedCSV(10)), Options{})
+	if _, err := r.Read(); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if _, err := r.ReadTailN(5); err == nil {
+		t.Fatalf("expected error calling ReadTailN after Read")
+	}
+}
+
+func TestReader_ReadTailNBeforeHeaders(t *testing.T) {
+	r := NewReader(strings.NewReader(numberedCSV(10)), Options{})
+	if _, err := r.ReadTailN(5); err == nil {
+		t.Fatalf("expected error calling ReadTailN before Headers")
+	}
+}
+
+func TestReader_ReadNormalizes(t *testing.T) {
+	r := NewReader(strings.NewReader("a,b\n1\n1,2,3\n"), Options{})
+	var got [][]string
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		got = append(got, row)
+	}
+	if want := [][]string{{"1", ""}, {"1", "2"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements renaming header columns. Only the header row changes;
+// data rows are streamed through, so memory use does not grow with file size.
+package csvio
+
+import (
+	"fmt"
+	"io"
+)
+
+// RenameColumns writes inputPath to outputPath with header names replaced
+// according to mapping (old name -> new name).
+//
+// Old names are matched case-insensitively against the first matching
+// header; a name that matches nothing returns an error wrapping
+// ErrColumnNotFound. New names are written verbatim (the CSV writer still
+// quotes them if they contain the delimiter or quotes).
+func RenameColumns(inputPath, outputPath string, mapping map[string]string, opts Options) error {
+	in, err := OpenInputWithOptions(inputPath, opts)
+	if err != nil {
+		return fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return RenameReader(in, out, mapping, opts)
+}
+
+// RenameReader is RenameColumns for an already-open input and output, e.g.
+// when writing to stdout. r is raw input; opts.Encoding is applied.
+func RenameReader(r io.Reader, w io.Writer, mapping map[string]string, opts Options) error {
+	in, err := inputReader(r, opts)
+	if err != nil {
+		return fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts), opts)
+	if err != nil {
+		return err
+	}
+
+	renamed := append([]string(nil), headers...)
+	for from, to := range mapping {
+		idx, err := ColumnIndex(headers, from)
+		if err != nil {
+			return err
+		}
+		renamed[idx] = to
+	}
+
+	cw := newWriter(w, opts)
+	defer cw.Flush()
+
+	if err := cw.Write(renamed); err != nil {
+		return fmt.Errorf("write headers: %w", err)
+	}
+
+	// Data rows are not interpreted, so the parser's buffer can be reused.
+	rows.r.ReuseRecord = true
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read row: %w", err)
+		}
+		if err := cw.Write(rec); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenameReader(t *testing.T) {
+	in := strings.NewReader("First Name,email\nAnn,a@x.com\nBob\n")
+	var out bytes.Buffer
+
+	err := RenameReader(in, &out, map[string]string{"first name": "first_name", "EMAIL": "e-mail"}, Options{})
+	if err != nil {
+		t.Fatalf("RenameReader: %v", err)
+	}
+
+	// Data rows pass through untouched, including jagged ones.
+	want := "first_name,e-mail\nAnn,a@x.com\nBob\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestRenameReader_UnknownColumn(t *testing.T) {
+	var out bytes.Buffer
+	err := RenameReader(strings.NewReader("a\n1\n"), &out, map[string]string{"b": "c"}, Options{})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
@@ -0,0 +1,73 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements moving chosen columns to the front while keeping
+// every other column, in its original relative order, after them. It reuses
+// the streaming projection behind SelectColumns.
+package csvio
+
+import (
+	"fmt"
+	"io"
+)
+
+// ReorderColumns writes inputPath to outputPath with the columns named in
+// firstCols moved to the front, in the order given, followed by the
+// remaining columns in file order. No column is dropped or duplicated:
+// naming a column twice moves it once.
+//
+// Each entry is a header name (case-insensitive) or, if no header matches, a
+// zero-based index. An unknown column returns an error wrapping
+// ErrColumnNotFound.
+func ReorderColumns(inputPath, outputPath string, firstCols []string, opts Options) error {
+	in, err := OpenInputWithOptions(inputPath, opts)
+	if err != nil {
+		return fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return ReorderReader(in, out, firstCols, opts)
+}
+
+// ReorderReader is ReorderColumns for an already-open input and output, e.g.
+// when writing to stdout. r is raw input; opts.Encoding is applied.
+func ReorderReader(r io.Reader, w io.Writer, firstCols []string, opts Options) error {
+	if len(firstCols) == 0 {
+		return fmt.Errorf("reorder: no columns given")
+	}
+
+	_, err := projectColumns(r, w, opts, func(headers []string) ([]int, error) {
+		return reorderPermutation(headers, firstCols)
+	})
+	return err
+}
+
+// reorderPermutation returns the output order for ReorderColumns: the
+// indexes of first, then every other index ascending.
+func reorderPermutation(headers, first []string) ([]int, error) {
+	moved := make([]bool, len(headers))
+	perm := make([]int, 0, len(headers))
+	for _, c := range first {
+		idx, err := resolveColumn(headers, c)
+		if err != nil {
+			return nil, err
+		}
+		if !moved[idx] {
+			moved[idx] = true
+			perm = append(perm, idx)
+		}
+	}
+	for i := range headers {
+		if !moved[i] {
+			perm = append(perm, i)
+		}
+	}
+	return perm, nil
+}
@@ -0,0 +1,48 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReorderReader(t *testing.T) {
+	const input = "a,b,c,id,d,email\n1,2,3,4,5,6\n7,8\n"
+
+	tests := []struct {
+		name  string
+		first []string
+		want  string
+	}{
+		{
+			name:  "subset keeps the rest in order",
+			first: []string{"ID", "email"},
+			want:  "id,email,a,b,c,d\n4,6,1,2,3,5\n,,7,8,,\n",
+		},
+		{
+			name:  "index and repeat",
+			first: []string{"2", "c"},
+			want:  "c,a,b,id,d,email\n3,1,2,4,5,6\n,7,8,,,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := ReorderReader(strings.NewReader(input), &out, tt.first, Options{}); err != nil {
+				t.Fatalf("ReorderReader: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestReorderReader_UnknownColumn(t *testing.T) {
+	err := ReorderReader(strings.NewReader("a,b\n1,2\n"), &bytes.Buffer{}, []string{"z"}, Options{})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
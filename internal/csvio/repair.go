@@ -0,0 +1,187 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements RepairFile, which fixes the structural problems that
+// CheckFile reports where a safe fix exists. Every repair is opt-in, so a
+// repair never changes more of the file than the caller asked for.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RepairOptions controls RepairFile.
+type RepairOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// TrimHeaders strips leading and trailing whitespace from header names.
+	TrimHeaders bool
+
+	// DedupeHeaders renames repeated header names (compared
+	// case-insensitively, like column lookups) by appending "_2", "_3", ...
+	// The first occurrence keeps its name.
+	DedupeHeaders bool
+
+	// DropEmptyRows removes data rows whose cells are all empty or
+	// whitespace.
+	DropEmptyRows bool
+
+	// NormalizeWidth pads short rows with empty cells and truncates long
+	// rows to the header width.
+	NormalizeWidth bool
+}
+
+// RepairStats summarizes a repair.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - RowsWritten counts data rows written.
+//   - HeadersTrimmed counts header names that had surrounding whitespace.
+//   - HeadersRenamed counts header names renamed to remove a duplicate.
+//   - EmptyRowsDropped counts rows removed because every cell was blank.
+//   - RowsPadded and RowsTruncated count rows widened or cut to the header
+//     width.
+type RepairStats struct {
+	RowsRead         int
+	RowsWritten      int
+	HeadersTrimmed   int
+	HeadersRenamed   int
+	EmptyRowsDropped int
+	RowsPadded       int
+	RowsTruncated    int
+}
+
+// RepairFile writes inputPath to outputPath with the repairs selected in
+// opts applied.
+func RepairFile(inputPath, outputPath string, opts RepairOptions) (RepairStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return RepairStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return RepairStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return RepairReader(in, out, opts)
+}
+
+// RepairReader is RepairFile for an already-open input and output, e.g.
+// when writing to stdout. r is raw input; opts.Encoding is applied.
+func RepairReader(r io.Reader, w io.Writer, opts RepairOptions) (RepairStats, error) {
+	in, err := inputReader(r, opts.Options)
+	if err != nil {
+		return RepairStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return RepairStats{}, err
+	}
+
+	stats := RepairStats{}
+	out := append([]string(nil), headers...)
+	if opts.TrimHeaders {
+		for i, h := range out {
+			if t := strings.TrimSpace(h); t != h {
+				out[i] = t
+				stats.HeadersTrimmed++
+			}
+		}
+	}
+	if opts.DedupeHeaders {
+		stats.HeadersRenamed = dedupeHeaders(out)
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	if err := cw.Write(out); err != nil {
+		return stats, fmt.Errorf("write headers: %w", err)
+	}
+
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		if opts.DropEmptyRows && blankRow(rec) {
+			stats.EmptyRowsDropped++
+			continue
+		}
+
+		if opts.NormalizeWidth {
+			switch {
+			case len(rec) < len(out):
+				stats.RowsPadded++
+			case len(rec) > len(out):
+				stats.RowsTruncated++
+			}
+			rec = normalizeRow(rec, len(out))
+		}
+
+		if err := cw.Write(rec); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+		stats.RowsWritten++
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
+
+// dedupeHeaders renames repeated names in headers in place and returns how
+// many it renamed. A suffix is skipped if the resulting name is already
+// taken, so "a,a,a_2" becomes "a,a_3,a_2" rather than repeating a_2.
+func dedupeHeaders(headers []string) int {
+	taken := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		taken[strings.ToLower(h)] = true
+	}
+
+	seen := make(map[string]bool, len(headers))
+	renamed := 0
+	for i, h := range headers {
+		key := strings.ToLower(h)
+		if !seen[key] {
+			seen[key] = true
+			continue
+		}
+		for n := 2; ; n++ {
+			name := h + "_" + strconv.Itoa(n)
+			if !taken[strings.ToLower(name)] {
+				headers[i] = name
+				taken[strings.ToLower(name)] = true
+				renamed++
+				break
+			}
+		}
+	}
+	return renamed
+}
+
+// blankRow reports whether every cell in rec is empty or whitespace.
+func blankRow(rec []string) bool {
+	for _, v := range rec {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
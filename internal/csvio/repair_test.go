@@ -0,0 +1,96 @@
+package csvio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRepairFile(t *testing.T) {
+	// csv.Writer quotes fields with leading spaces, so untrimmed headers and
+	// kept blank rows come back quoted.
+	const input = " id ,Name,name,name_2\n" +
+		"1,Ann,x\n" +
+		",,,\n" +
+		"2,Bob,y,z,extra\n" +
+		"  , ,\t,\n" +
+		"3,Cy,w,v\n"
+
+	tests := []struct {
+		name  string
+		opts  RepairOptions
+		want  string
+		stats RepairStats
+	}{
+		{
+			name: "all repairs",
+			opts: RepairOptions{TrimHeaders: true, DedupeHeaders: true, DropEmptyRows: true, NormalizeWidth: true},
+			want: "id,Name,name_3,name_2\n" +
+				"1,Ann,x,\n" +
+				"2,Bob,y,z\n" +
+				"3,Cy,w,v\n",
+			stats: RepairStats{
+				RowsRead: 5, RowsWritten: 3,
+				HeadersTrimmed: 1, HeadersRenamed: 1,
+				EmptyRowsDropped: 2, RowsPadded: 1, RowsTruncated: 1,
+			},
+		},
+		{
+			name: "headers only",
+			opts: RepairOptions{TrimHeaders: true, DedupeHeaders: true},
+			want: "id,Name,name_3,name_2\n" +
+				"1,Ann,x\n" +
+				",,,\n" +
+				"2,Bob,y,z,extra\n" +
+				"\"  \",\" \",\"\t\",\n" +
+				"3,Cy,w,v\n",
+			stats: RepairStats{RowsRead: 5, RowsWritten: 5, HeadersTrimmed: 1, HeadersRenamed: 1},
+		},
+		{
+			name: "nothing selected",
+			opts: RepairOptions{},
+			want: "\" id \",Name,name,name_2\n" +
+				"1,Ann,x\n" +
+				",,,\n" +
+				"2,Bob,y,z,extra\n" +
+				"\"  \",\" \",\"\t\",\n" +
+				"3,Cy,w,v\n",
+			stats: RepairStats{RowsRead: 5, RowsWritten: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := writeTemp(t, "in.csv", input)
+			out := filepath.Join(t.TempDir(), "out.csv")
+
+			stats, err := RepairFile(in, out, tt.opts)
+			if err != nil {
+				t.Fatalf("RepairFile: %v", err)
+			}
+			got, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("read output: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("output = %q, want %q", got, tt.want)
+			}
+			if stats != tt.stats {
+				t.Fatalf("stats = %+v, want %+v", stats, tt.stats)
+			}
+		})
+	}
+}
+
+func TestDedupeHeaders(t *testing.T) {
+	headers := []string{"a", "A", "a", "b", "a_2"}
+	if n := dedupeHeaders(headers); n != 2 {
+		t.Fatalf("renamed = %d, want 2", n)
+	}
+	want := []string{"a", "A_3", "a_4", "b", "a_2"}
+	for i := range want {
+		if headers[i] != want[i] {
+			t.Fatalf("headers = %q, want %q", headers, want)
+		}
+	}
+}
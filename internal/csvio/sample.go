@@ -0,0 +1,181 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements random row sampling. Both modes stream the input;
+// fixed-size sampling keeps at most N rows in memory.
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+)
+
+// SampleOptions controls SampleFile. Exactly one of N and Pct must be set.
+type SampleOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// N selects a fixed number of rows using reservoir sampling. Files with
+	// fewer than N data rows are returned whole.
+	N int
+
+	// Pct selects each row independently with probability Pct/100, so the
+	// output size is only approximately Pct percent of the input.
+	Pct float64
+
+	// Seed seeds the random source. The same input, options, and seed always
+	// produce the same output.
+	Seed int64
+}
+
+// SampleStats summarizes a sample operation.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - RowsSelected counts data rows written.
+type SampleStats struct {
+	RowsRead     int
+	RowsSelected int
+}
+
+// SampleFile writes the header plus a random subset of the data rows of
+// inputPath to outputPath. Selected rows keep their original relative order.
+func SampleFile(inputPath, outputPath string, opts SampleOptions) (SampleStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return SampleStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return SampleStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return SampleReader(in, out, opts)
+}
+
+// SampleReader is SampleFile for an already-open input and output, e.g. when
+// writing to stdout. r is raw input; opts.Encoding is applied.
+func SampleReader(r io.Reader, w io.Writer, opts SampleOptions) (SampleStats, error) {
+	switch {
+	case opts.N > 0 && opts.Pct > 0:
+		return SampleStats{}, errors.New("sample: set either N or Pct, not both")
+	case opts.N < 0:
+		return SampleStats{}, fmt.Errorf("sample: N must be >= 0 (got %d)", opts.N)
+	case opts.Pct < 0 || opts.Pct > 100:
+		return SampleStats{}, fmt.Errorf("sample: Pct must be between 0 and 100 (got %g)", opts.Pct)
+	case opts.N == 0 && opts.Pct == 0:
+		return SampleStats{}, errors.New("sample: one of N or Pct is required")
+	}
+
+	in, err := inputReader(r, opts.Options)
+	if err != nil {
+		return SampleStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return SampleStats{}, err
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	if err := cw.Write(headers); err != nil {
+		return SampleStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	var stats SampleStats
+	if opts.N > 0 {
+		stats, err = sampleReservoir(rows, cw, len(headers), opts.N, rng)
+	} else {
+		stats, err = sampleBernoulli(rows, cw, len(headers), opts.Pct/100, rng)
+	}
+	if err != nil {
+		return stats, err
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
+
+// sampleReservoir selects n rows uniformly at random using Algorithm R: the
+// first n rows fill the reservoir, then row i (0-based) replaces a random
+// slot with probability n/(i+1). Rows are written in file order at the end.
+func sampleReservoir(rows *rowReader, w *csv.Writer, width, n int, rng *rand.Rand) (SampleStats, error) {
+	type numbered struct {
+		pos int
+		rec []string
+	}
+
+	stats := SampleStats{}
+	reservoir := make([]numbered, 0, n)
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		i := stats.RowsRead
+		stats.RowsRead++
+
+		if len(reservoir) < n {
+			reservoir = append(reservoir, numbered{pos: i, rec: normalizeRow(rec, width)})
+			continue
+		}
+		if j := rng.Intn(i + 1); j < n {
+			reservoir[j] = numbered{pos: i, rec: normalizeRow(rec, width)}
+		}
+	}
+
+	sort.Slice(reservoir, func(a, b int) bool { return reservoir[a].pos < reservoir[b].pos })
+	for _, s := range reservoir {
+		if err := w.Write(s.rec); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+		stats.RowsSelected++
+	}
+
+	return stats, nil
+}
+
+// sampleBernoulli writes each row independently with probability p.
+func sampleBernoulli(rows *rowReader, w *csv.Writer, width int, p float64, rng *rand.Rand) (SampleStats, error) {
+	stats := SampleStats{}
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		// Draw for every row, selected or not, so the sequence of random
+		// numbers (and thus the output) depends only on the seed.
+		if rng.Float64() >= p {
+			continue
+		}
+		if err := w.Write(normalizeRow(rec, width)); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+		stats.RowsSelected++
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,51 @@
+package csvio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSampleReader(t *testing.T) {
+	input := numberedCSV(1000)
+
+	tests := []struct {
+		name string
+		opts SampleOptions
+		min  int
+		max  int
+	}{
+		{name: "fixed", opts: SampleOptions{N: 10, Seed: 7}, min: 10, max: 10},
+		{name: "fixed larger than file", opts: SampleOptions{N: 5000, Seed: 7}, min: 1000, max: 1000},
+		{name: "percent", opts: SampleOptions{Pct: 10, Seed: 7}, min: 50, max: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var first, second bytes.Buffer
+			stats, err := SampleReader(strings.NewReader(input), &first, tt.opts)
+			if err != nil {
+				t.Fatalf("SampleReader: %v", err)
+			}
+			if stats.RowsRead != 1000 || stats.RowsSelected < tt.min || stats.RowsSelected > tt.max {
+				t.Fatalf("stats = %+v", stats)
+			}
+
+			if _, err := SampleReader(strings.NewReader(input), &second, tt.opts); err != nil {
+				t.Fatalf("SampleReader: %v", err)
+			}
+			if first.String() != second.String() {
+				t.Fatalf("same seed produced different output")
+			}
+		})
+	}
+}
+
+func TestSampleReader_InvalidOptions(t *testing.T) {
+	for _, opts := range []SampleOptions{{}, {N: 1, Pct: 1}, {Pct: 101}, {N: -1}} {
+		var out bytes.Buffer
+		if _, err := SampleReader(strings.NewReader("a\n1\n"), &out, opts); err == nil {
+			t.Errorf("SampleReader(%+v): expected error", opts)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file infers a column type for each column, as a sanity check before
+// loading a file into a database. The file is streamed; memory grows with the
+// number of distinct values per column (see ColumnType.DistinctCount).
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Inferred column types, from narrowest to widest. A column gets the first
+// type that every non-null value is compatible with.
+const (
+	TypeInt    = "int"
+	TypeFloat  = "float"
+	TypeBool   = "bool"
+	TypeDate   = "date"
+	TypeString = "string"
+)
+
+// DefaultDateLayouts are the time layouts tried when SchemaOptions.DateLayouts
+// is empty.
+var DefaultDateLayouts = []string{
+	"2006-01-02",
+	"01/02/2006",
+	time.RFC3339,
+}
+
+// SchemaOptions controls InferSchema.
+type SchemaOptions struct {
+	// Options controls how the input is parsed.
+	Options
+
+	// DateLayouts lists time.Parse layouts a value may match to count as a
+	// date. Empty means DefaultDateLayouts.
+	DateLayouts []string
+
+	// SampleSize caps ColumnType.SampleValues. Zero means 3.
+	SampleSize int
+}
+
+// ColumnType describes the inferred type of one column.
+//
+// Empty and whitespace-only cells are nulls: they are counted in NullCount
+// and do not constrain the type. A column with no non-null values is a
+// string.
+type ColumnType struct {
+	Name          string   `json:"name"`
+	InferredType  string   `json:"type"`
+	NullCount     int      `json:"null_count"`
+	DistinctCount int      `json:"distinct_count"`
+	SampleValues  []string `json:"sample_values"`
+}
+
+// InferSchema reads every data row of the file at path and returns one
+// ColumnType per header, in header order.
+//
+// DistinctCount is exact, so a set of every distinct non-null value is kept
+// per column; on very large, high-cardinality files that set dominates
+// memory use.
+func InferSchema(path string, opts SchemaOptions) ([]ColumnType, error) {
+	f, err := openCSV(path, opts.Options)
+	if err != nil {
+		return nil, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, r, err := readHeader(newReader(f, opts.Options), opts.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	layouts := opts.DateLayouts
+	if len(layouts) == 0 {
+		layouts = DefaultDateLayouts
+	}
+	sampleSize := opts.SampleSize
+	if sampleSize == 0 {
+		sampleSize = 3
+	}
+
+	cols := make([]typeTracker, len(headers))
+	for i := range cols {
+		cols[i] = typeTracker{
+			isInt: true, isFloat: true, isBool: true, isDate: true,
+			distinct: make(map[string]struct{}),
+		}
+	}
+
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read row: %w", err)
+		}
+
+		rec = normalizeRow(rec, len(headers))
+		for i, v := range rec {
+			cols[i].add(v, layouts, sampleSize)
+		}
+	}
+
+	out := make([]ColumnType, len(headers))
+	for i, h := range headers {
+		out[i] = ColumnType{
+			Name:          h,
+			InferredType:  cols[i].inferred(),
+			NullCount:     cols[i].nulls,
+			DistinctCount: len(cols[i].distinct),
+			SampleValues:  cols[i].samples,
+		}
+	}
+	return out, nil
+}
+
+// typeTracker records which types are still compatible with every non-null
+// value seen so far in one column.
+type typeTracker struct {
+	isInt, isFloat, isBool, isDate bool
+
+	nulls    int
+	nonNull  int
+	distinct map[string]struct{}
+	samples  []string
+}
+
+func (t *typeTracker) add(v string, layouts []string, sampleSize int) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		t.nulls++
+		return
+	}
+	t.nonNull++
+
+	if _, seen := t.distinct[v]; !seen {
+		t.distinct[v] = struct{}{}
+		if len(t.samples) < sampleSize {
+			t.samples = append(t.samples, v)
+		}
+	}
+
+	if t.isInt {
+		_, err := strconv.ParseInt(v, 10, 64)
+		t.isInt = err == nil
+	}
+	if t.isFloat {
+		_, err := strconv.ParseFloat(v, 64)
+		t.isFloat = err == nil
+	}
+	if t.isBool {
+		_, err := strconv.ParseBool(v)
+		t.isBool = err == nil
+	}
+	if t.isDate {
+		t.isDate = parsesAsDate(v, layouts)
+	}
+}
+
+func (t *typeTracker) inferred() string {
+	switch {
+	case t.nonNull == 0:
+		return TypeString
+	case t.isInt:
+		return TypeInt
+	case t.isFloat:
+		return TypeFloat
+	case t.isBool:
+		return TypeBool
+	case t.isDate:
+		return TypeDate
+	default:
+		return TypeString
+	}
+}
+
+// parsesAsDate reports whether v matches any of layouts.
+func parsesAsDate(v string, layouts []string) bool {
+	for _, l := range layouts {
+		if _, err := time.Parse(l, v); err == nil {
+			return true
+		}
+	}
+	return false
+}
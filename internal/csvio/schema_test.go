@@ -0,0 +1,42 @@
+package csvio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	in := writeTemp(t, "in.csv", "id,price,active,joined,name,empty\n"+
+		"1,9.99,true,2024-01-31,Ann,\n"+
+		"2,10,false,2024-02-29,Bob, \n"+
+		"3,,TRUE,,Ann,\n")
+
+	got, err := InferSchema(in, SchemaOptions{})
+	if err != nil {
+		t.Fatalf("InferSchema: %v", err)
+	}
+
+	want := []ColumnType{
+		{Name: "id", InferredType: TypeInt, DistinctCount: 3, SampleValues: []string{"1", "2", "3"}},
+		{Name: "price", InferredType: TypeFloat, NullCount: 1, DistinctCount: 2, SampleValues: []string{"9.99", "10"}},
+		{Name: "active", InferredType: TypeBool, DistinctCount: 3, SampleValues: []string{"true", "false", "TRUE"}},
+		{Name: "joined", InferredType: TypeDate, NullCount: 1, DistinctCount: 2, SampleValues: []string{"2024-01-31", "2024-02-29"}},
+		{Name: "name", InferredType: TypeString, DistinctCount: 2, SampleValues: []string{"Ann", "Bob"}},
+		{Name: "empty", InferredType: TypeString, NullCount: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("schema mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestInferSchema_CustomDateLayout(t *testing.T) {
+	in := writeTemp(t, "in.csv", "when\n31.01.2024\n")
+
+	got, err := InferSchema(in, SchemaOptions{DateLayouts: []string{"02.01.2006"}})
+	if err != nil {
+		t.Fatalf("InferSchema: %v", err)
+	}
+	if got[0].InferredType != TypeDate {
+		t.Fatalf("InferredType = %q, want %q", got[0].InferredType, TypeDate)
+	}
+}
@@ -0,0 +1,125 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements column projection: writing a chosen subset of columns,
+// in a chosen order. It streams row-by-row.
+package csvio
+
+import (
+	"fmt"
+	"io"
+)
+
+// SelectStats summarizes a select operation.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - ColsSelected is the number of output columns, including duplicates.
+type SelectStats struct {
+	RowsRead     int
+	ColsSelected int
+}
+
+// SelectColumns writes the columns named in cols from inputPath to
+// outputPath, in the order given in cols rather than file order, so a
+// selection can reorder columns as well as drop them.
+//
+// Each entry is a header name (case-insensitive) or, if no header matches, a
+// zero-based index. Naming a column twice writes it twice. An unknown column
+// returns an error wrapping ErrColumnNotFound.
+func SelectColumns(inputPath, outputPath string, cols []string, opts Options) (SelectStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts)
+	if err != nil {
+		return SelectStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return SelectStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return SelectReader(in, out, cols, opts)
+}
+
+// SelectReader is SelectColumns for an already-open input and output, e.g.
+// when writing to stdout. r is raw input; opts.Encoding is applied.
+func SelectReader(r io.Reader, w io.Writer, cols []string, opts Options) (SelectStats, error) {
+	if len(cols) == 0 {
+		return SelectStats{}, fmt.Errorf("select: no columns given")
+	}
+
+	rows, err := projectColumns(r, w, opts, func(headers []string) ([]int, error) {
+		keep := make([]int, len(cols))
+		for i, c := range cols {
+			idx, err := resolveColumn(headers, c)
+			if err != nil {
+				return nil, err
+			}
+			keep[i] = idx
+		}
+		return keep, nil
+	})
+
+	return SelectStats{RowsRead: rows, ColsSelected: len(cols)}, err
+}
+
+// projectColumns streams r to w keeping only the column indexes returned by
+// pick, which is called once with the header row. It returns the number of
+// data rows processed.
+func projectColumns(r io.Reader, w io.Writer, opts Options, pick func(headers []string) ([]int, error)) (int, error) {
+	in, err := inputReader(r, opts)
+	if err != nil {
+		return 0, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts), opts)
+	if err != nil {
+		return 0, err
+	}
+
+	keep, err := pick(headers)
+	if err != nil {
+		return 0, err
+	}
+
+	cw := newWriter(w, opts)
+	defer cw.Flush()
+
+	// One output slice is reused for every row; csv.Writer does not retain it.
+	rec := make([]string, len(keep))
+	project := func(src []string) []string {
+		for i, idx := range keep {
+			rec[i] = src[idx]
+		}
+		return rec
+	}
+
+	if err := cw.Write(project(headers)); err != nil {
+		return 0, fmt.Errorf("write headers: %w", err)
+	}
+
+	n := 0
+	for {
+		row, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, fmt.Errorf("read row: %w", err)
+		}
+		n++
+
+		if err := cw.Write(project(normalizeRow(row, len(headers)))); err != nil {
+			return n, fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return n, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,34 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSelectReader_ReordersAndDuplicates(t *testing.T) {
+	in := strings.NewReader("a,b,c\n1,2,3\n4,5\n")
+	var out bytes.Buffer
+
+	stats, err := SelectReader(in, &out, []string{"C", "a", "0"}, Options{})
+	if err != nil {
+		t.Fatalf("SelectReader: %v", err)
+	}
+	if stats.RowsRead != 2 || stats.ColsSelected != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	want := "c,a,a\n3,1,1\n,4,4\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestSelectReader_UnknownColumn(t *testing.T) {
+	var out bytes.Buffer
+	_, err := SelectReader(strings.NewReader("a,b\n1,2\n"), &out, []string{"z"}, Options{})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
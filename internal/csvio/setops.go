@@ -0,0 +1,358 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements set operations on two files keyed by a column, such
+// as "contacts on both the opt-in list and the purchase list". Intersect and
+// except index the second file's keys in memory and stream the first, so
+// memory grows with the number of distinct keys in the second file only;
+// union streams both and remembers every key it has written.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// SetOptions controls IntersectFiles and ExceptFiles.
+type SetOptions struct {
+	// Options controls the output delimiter, and how both files are parsed
+	// unless FileOptions is set.
+	Options
+
+	// FileOptions holds the read options of the first and second file, in
+	// that order, for inputs in different dialects. A single entry applies
+	// to both.
+	FileOptions []Options
+
+	// Key names the key column, which must exist in both files
+	// (case-insensitive). Key values are compared exactly. Required.
+	Key string
+}
+
+// IntersectStats summarizes an intersect.
+//
+//   - RowsInA and RowsInB count the data rows of each input.
+//   - RowsMatched counts rows of A whose key occurs in B, i.e. the rows
+//     written.
+type IntersectStats struct {
+	RowsInA     int
+	RowsInB     int
+	RowsMatched int
+}
+
+// IntersectFiles writes the rows of pathA whose key also occurs in pathB to
+// outputPath, in pathA's order and with pathA's header. Every matching row
+// of A is written, even when several share a key.
+func IntersectFiles(pathA, pathB, outputPath string, opts SetOptions) (IntersectStats, error) {
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return IntersectStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return IntersectToWriter(pathA, pathB, out, opts)
+}
+
+// IntersectToWriter is IntersectFiles writing to an already-open output,
+// e.g. stdout.
+func IntersectToWriter(pathA, pathB string, w io.Writer, opts SetOptions) (IntersectStats, error) {
+	rowsA, rowsB, written, err := filterByKeyPresence(true, pathA, pathB, w, opts)
+	return IntersectStats{RowsInA: rowsA, RowsInB: rowsB, RowsMatched: written}, err
+}
+
+// ExceptStats summarizes an except.
+//
+//   - RowsInA and RowsInB count the data rows of each input.
+//   - RowsKept counts rows of A whose key does not occur in B, i.e. the
+//     rows written; RowsInA - RowsKept rows were suppressed.
+type ExceptStats struct {
+	RowsInA  int
+	RowsInB  int
+	RowsKept int
+}
+
+// ExceptFiles writes the rows of pathA whose key does not occur in pathB to
+// outputPath, in pathA's order and with pathA's header; e.g. a master list
+// minus an unsubscribe list.
+func ExceptFiles(pathA, pathB, outputPath string, opts SetOptions) (ExceptStats, error) {
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return ExceptStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return ExceptToWriter(pathA, pathB, out, opts)
+}
+
+// ExceptToWriter is ExceptFiles writing to an already-open output, e.g.
+// stdout.
+func ExceptToWriter(pathA, pathB string, w io.Writer, opts SetOptions) (ExceptStats, error) {
+	rowsA, rowsB, written, err := filterByKeyPresence(false, pathA, pathB, w, opts)
+	return ExceptStats{RowsInA: rowsA, RowsInB: rowsB, RowsKept: written}, err
+}
+
+// UnionOptions controls UnionFiles.
+type UnionOptions struct {
+	// Options controls the output delimiter, and how both files are parsed
+	// unless FileOptions is set.
+	Options
+
+	// FileOptions holds the read options of the first and second file, in
+	// that order, for inputs in different dialects. A single entry applies
+	// to both.
+	FileOptions []Options
+
+	// IgnoreSchema skips the check that both files have the same header.
+	// The second file's rows are padded or truncated to the first file's
+	// width and read by its column positions.
+	IgnoreSchema bool
+}
+
+// UnionStats summarizes a union.
+//
+//   - RowsFromA and RowsFromB count the rows written from each input.
+//   - RowsDeduplicated counts rows dropped, from either input, because an
+//     earlier row had the same key.
+type UnionStats struct {
+	RowsFromA        int
+	RowsFromB        int
+	RowsDeduplicated int
+}
+
+// UnionFiles writes the distinct union of the rows of pathA and pathB to
+// outputPath: every row of A, then every row of B, skipping any row whose
+// key was already written, so the first occurrence wins. The key is the
+// tuple of values in the keyCols columns (case-insensitive names); when
+// keyCols is empty every column is part of the key, so only exact
+// duplicate rows are removed.
+//
+// Both files must have the same header unless opts.IgnoreSchema is set.
+// Every distinct key is kept in memory, as for DeduplicateFile.
+func UnionFiles(pathA, pathB, outputPath string, keyCols []string, opts UnionOptions) (UnionStats, error) {
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return UnionStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return UnionToWriter(pathA, pathB, out, keyCols, opts)
+}
+
+// UnionToWriter is UnionFiles writing to an already-open output, e.g.
+// stdout.
+func UnionToWriter(pathA, pathB string, w io.Writer, keyCols []string, opts UnionOptions) (UnionStats, error) {
+	// Read both headers before writing anything, so a schema mismatch
+	// leaves the output empty rather than holding a partial union.
+	fa, schema, rowsA, err := openWithHeader(pathA, fileOptions(opts.Options, opts.FileOptions, 0))
+	if err != nil {
+		return UnionStats{}, err
+	}
+	defer fa.Close()
+
+	fb, headersB, rowsB, err := openWithHeader(pathB, fileOptions(opts.Options, opts.FileOptions, 1))
+	if err != nil {
+		return UnionStats{}, err
+	}
+	defer fb.Close()
+
+	if !opts.IgnoreSchema && !slices.Equal(headersB, schema) {
+		return UnionStats{}, fmt.Errorf("%s: header mismatch (got %q, want %q)", pathB, headersB, schema)
+	}
+
+	keyIdx, err := ColumnIndices(schema, keyCols)
+	if err != nil {
+		return UnionStats{}, fmt.Errorf("%s: %w", pathA, err)
+	}
+	if len(keyIdx) == 0 {
+		for i := range schema {
+			keyIdx = append(keyIdx, i)
+		}
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+	if err := cw.Write(schema); err != nil {
+		return UnionStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	var (
+		stats UnionStats
+		parts = make([]string, len(keyIdx))
+		seen  = make(map[string]struct{})
+	)
+
+	// stream writes the new rows of one input and returns how many.
+	stream := func(path string, rows *rowReader) (int, error) {
+		written := 0
+		for {
+			rec, err := rows.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return written, fmt.Errorf("%s: read row: %w", path, err)
+			}
+
+			rec = normalizeRow(rec, len(schema))
+			for i, idx := range keyIdx {
+				parts[i] = rec[idx]
+			}
+			// Joined on NUL, as in DeduplicateReader.
+			key := strings.Join(parts, "\x00")
+			if _, dup := seen[key]; dup {
+				stats.RowsDeduplicated++
+				continue
+			}
+			seen[key] = struct{}{}
+
+			if err := cw.Write(rec); err != nil {
+				return written, fmt.Errorf("write row: %w", err)
+			}
+			written++
+		}
+		return written, nil
+	}
+
+	if stats.RowsFromA, err = stream(pathA, rowsA); err != nil {
+		return stats, err
+	}
+	if stats.RowsFromB, err = stream(pathB, rowsB); err != nil {
+		return stats, err
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
+
+// openWithHeader opens path, parsed with ropts, and reads its header. The
+// caller closes the returned file.
+func openWithHeader(path string, ropts Options) (io.Closer, []string, *rowReader, error) {
+	f, err := openCSV(path, ropts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("open csv: %w", err)
+	}
+	headers, rows, err := readHeader(newReader(f, ropts), ropts)
+	if err != nil {
+		f.Close()
+		return nil, nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, headers, rows, nil
+}
+
+// filterByKeyPresence indexes the keys of pathB, then streams pathA to w,
+// header included, keeping the rows whose key is in B when include is true
+// and those whose key is not when it is false. It returns the data rows in
+// A and B and the rows written.
+func filterByKeyPresence(include bool, pathA, pathB string, w io.Writer, opts SetOptions) (int, int, int, error) {
+	keys, rowsB, err := indexKeys(pathB, fileOptions(opts.Options, opts.FileOptions, 1), opts.Key)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	rowsA, written, err := streamByKeys(pathA, fileOptions(opts.Options, opts.FileOptions, 0), w, opts, keys, include)
+	return rowsA, rowsB, written, err
+}
+
+// indexKeys returns the set of keyCol values in path, parsed with ropts,
+// and its data row count.
+func indexKeys(path string, ropts Options, keyCol string) (map[string]struct{}, int, error) {
+	if keyCol == "" {
+		return nil, 0, fmt.Errorf("set operation: no key column given")
+	}
+
+	f, err := openCSV(path, ropts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, rows, err := readHeader(newReader(f, ropts), ropts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", path, err)
+	}
+	key, err := ColumnIndex(headers, keyCol)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", path, err)
+	}
+
+	keys := make(map[string]struct{})
+	n := 0
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, n, fmt.Errorf("%s: read row: %w", path, err)
+		}
+		n++
+		keys[normalizeRow(rec, len(headers))[key]] = struct{}{}
+	}
+	return keys, n, nil
+}
+
+// streamByKeys streams path, parsed with ropts, to w, header included,
+// keeping the rows whose presence in keys equals include. It returns the
+// data rows read and written.
+func streamByKeys(path string, ropts Options, w io.Writer, opts SetOptions, keys map[string]struct{}, include bool) (int, int, error) {
+	f, err := openCSV(path, ropts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, rows, err := readHeader(newReader(f, ropts), ropts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", path, err)
+	}
+	key, err := ColumnIndex(headers, opts.Key)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", path, err)
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	if err := cw.Write(headers); err != nil {
+		return 0, 0, fmt.Errorf("write headers: %w", err)
+	}
+
+	read, written := 0, 0
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return read, written, fmt.Errorf("%s: read row: %w", path, err)
+		}
+		read++
+
+		rec = normalizeRow(rec, len(headers))
+		if _, found := keys[rec[key]]; found != include {
+			continue
+		}
+		if err := cw.Write(rec); err != nil {
+			return read, written, fmt.Errorf("write row: %w", err)
+		}
+		written++
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return read, written, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return read, written, nil
+}
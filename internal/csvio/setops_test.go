@@ -0,0 +1,114 @@
+package csvio
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestIntersectToWriter(t *testing.T) {
+	a := writeTemp(t, "optin.csv", "email,name\nann@x.com,Ann\nbob@x.com,Bob\nann@x.com,Ann B\ncy@x.com,Cy\n")
+	b := writeTemp(t, "purchases.csv", "order,EMAIL\n1,cy@x.com\n2,ann@x.com\n3,dee@x.com\n")
+
+	var out bytes.Buffer
+	stats, err := IntersectToWriter(a, b, &out, SetOptions{Key: "email"})
+	if err != nil {
+		t.Fatalf("IntersectToWriter: %v", err)
+	}
+
+	want := "email,name\nann@x.com,Ann\nann@x.com,Ann B\ncy@x.com,Cy\n"
+	if out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if want := (IntersectStats{RowsInA: 4, RowsInB: 3, RowsMatched: 3}); stats != want {
+		t.Fatalf("stats = %+v, want %+v", stats, want)
+	}
+}
+
+func TestIntersectToWriter_MissingKey(t *testing.T) {
+	a := writeTemp(t, "a.csv", "id\n1\n")
+	b := writeTemp(t, "b.csv", "other\n1\n")
+
+	if _, err := IntersectToWriter(a, b, &bytes.Buffer{}, SetOptions{Key: "id"}); !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
+
+func TestExceptToWriter_Unsubscribe(t *testing.T) {
+	master := writeTemp(t, "master.csv", "email,name\nann@x.com,Ann\nbob@x.com,Bob\ncy@x.com,Cy\nbob@x.com,Bob 2\n")
+	unsub := writeTemp(t, "unsubscribed.csv", "email,date\nbob@x.com,2024-01-02\nzed@x.com,2024-02-03\n")
+
+	var out bytes.Buffer
+	stats, err := ExceptToWriter(master, unsub, &out, SetOptions{Key: "email"})
+	if err != nil {
+		t.Fatalf("ExceptToWriter: %v", err)
+	}
+
+	if want := "email,name\nann@x.com,Ann\ncy@x.com,Cy\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+	if want := (ExceptStats{RowsInA: 4, RowsInB: 2, RowsKept: 2}); stats != want {
+		t.Fatalf("stats = %+v, want %+v", stats, want)
+	}
+}
+
+func TestUnionToWriter(t *testing.T) {
+	a := writeTemp(t, "a.csv", "email,name\nann@x.com,Ann\nbob@x.com,Bob\nann@x.com,Ann\n")
+	b := writeTemp(t, "b.csv", "email,name\nbob@x.com,Robert\ncy@x.com,Cy\n")
+
+	tests := []struct {
+		name  string
+		keys  []string
+		want  string
+		stats UnionStats
+	}{
+		{
+			name:  "all columns",
+			want:  "email,name\nann@x.com,Ann\nbob@x.com,Bob\nbob@x.com,Robert\ncy@x.com,Cy\n",
+			stats: UnionStats{RowsFromA: 2, RowsFromB: 2, RowsDeduplicated: 1},
+		},
+		{
+			name:  "key column",
+			keys:  []string{"EMAIL"},
+			want:  "email,name\nann@x.com,Ann\nbob@x.com,Bob\ncy@x.com,Cy\n",
+			stats: UnionStats{RowsFromA: 2, RowsFromB: 1, RowsDeduplicated: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			stats, err := UnionToWriter(a, b, &out, tt.keys, UnionOptions{})
+			if err != nil {
+				t.Fatalf("UnionToWriter: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+			if stats != tt.stats {
+				t.Fatalf("stats = %+v, want %+v", stats, tt.stats)
+			}
+		})
+	}
+}
+
+func TestUnionToWriter_Schema(t *testing.T) {
+	a := writeTemp(t, "a.csv", "id,name\n1,Ann\n")
+	b := writeTemp(t, "b.csv", "id,full_name,extra\n1,Ann,x\n2,Bob,y\n")
+
+	// The mismatch is found before anything is written.
+	var out bytes.Buffer
+	if _, err := UnionToWriter(a, b, &out, nil, UnionOptions{}); err == nil {
+		t.Fatal("expected header mismatch error")
+	}
+	if out.Len() != 0 {
+		t.Fatalf("output = %q, want nothing on a header mismatch", out.String())
+	}
+
+	if _, err := UnionToWriter(a, b, &out, []string{"id"}, UnionOptions{IgnoreSchema: true}); err != nil {
+		t.Fatalf("IgnoreSchema: %v", err)
+	}
+	if want := "id,name\n1,Ann\n2,Bob\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,137 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements sorting rows by one or more columns. Unlike most
+// operations in this package, sorting cannot stream: every row is held in
+// memory, so memory use grows with file size.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// SortOptions controls SortFile.
+type SortOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// Columns lists the sort keys in priority order. Each entry is a header
+	// name (case-insensitive) or, if no header matches, a zero-based index.
+	Columns []string
+
+	// Descending reverses the order for every key.
+	Descending bool
+
+	// Numeric compares keys as float64. Values that do not parse as a
+	// number (including NaN) sort after every number, lexicographically
+	// among themselves; Descending reverses this whole order, so they come
+	// first.
+	Numeric bool
+}
+
+// SortFile writes the header plus the data rows of inputPath, sorted by
+// opts.Columns, to outputPath.
+//
+// The sort is stable: rows with equal keys keep their original relative
+// order, in both ascending and descending mode.
+func SortFile(inputPath, outputPath string, opts SortOptions) error {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return SortReader(in, out, opts)
+}
+
+// SortReader is SortFile for an already-open input and output, e.g. when
+// writing to stdout. r is raw input; opts.Encoding is applied.
+func SortReader(r io.Reader, w io.Writer, opts SortOptions) error {
+	if len(opts.Columns) == 0 {
+		return fmt.Errorf("sort: no columns given")
+	}
+
+	headers, rows, err := ReadAllFromReader(r, opts.Options)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]int, len(opts.Columns))
+	for i, c := range opts.Columns {
+		if keys[i], err = resolveColumn(headers, c); err != nil {
+			return err
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, k := range keys {
+			c := compareValues(rows[i][k], rows[j][k], opts.Numeric)
+			if c == 0 {
+				continue
+			}
+			if opts.Descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	return WriteRowsToWriter(w, headers, rows, opts.Options)
+}
+
+// compareValues returns -1, 0, or +1 comparing a and b. When numeric is set,
+// numbers come first in numeric order and the remaining values follow in
+// lexicographic order; deciding per pair instead would not be a total order
+// ("2" < "10" < "1a" < "2") and would leave the sort result undefined.
+func compareValues(a, b string, numeric bool) int {
+	if numeric {
+		fa, okA := parseSortNumber(a)
+		fb, okB := parseSortNumber(b)
+		switch {
+		case okA && !okB:
+			return -1
+		case !okA && okB:
+			return 1
+		case okA && okB:
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parseSortNumber parses s for a numeric sort. NaN is rejected because it is
+// unordered against every number.
+func parseSortNumber(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) {
+		return 0, false
+	}
+	return f, true
+}
@@ -0,0 +1,94 @@
+package csvio
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSortFile(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,state,zip\n"+
+		"Ann,NY,9\n"+
+		"Bob,CA,10\n"+
+		"Cy,NY,100\n"+
+		"Di,CA,x\n")
+
+	tests := []struct {
+		name string
+		opts SortOptions
+		want string
+	}{
+		{
+			name: "lexicographic",
+			opts: SortOptions{Columns: []string{"zip"}},
+			want: "name,state,zip\nBob,CA,10\nCy,NY,100\nAnn,NY,9\nDi,CA,x\n",
+		},
+		{
+			name: "numeric",
+			opts: SortOptions{Columns: []string{"ZIP"}, Numeric: true},
+			want: "name,state,zip\nAnn,NY,9\nBob,CA,10\nCy,NY,100\nDi,CA,x\n",
+		},
+		{
+			name: "stable descending",
+			opts: SortOptions{Columns: []string{"state"}, Descending: true},
+			want: "name,state,zip\nAnn,NY,9\nCy,NY,100\nBob,CA,10\nDi,CA,x\n",
+		},
+		{
+			name: "multiple keys and index fallback",
+			opts: SortOptions{Columns: []string{"1", "name"}},
+			want: "name,state,zip\nBob,CA,10\nDi,CA,x\nAnn,NY,9\nCy,NY,100\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "out.csv")
+			if err := SortFile(in, out, tt.opts); err != nil {
+				t.Fatalf("SortFile: %v", err)
+			}
+			got, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("read output: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortReader_NumericTotalOrder(t *testing.T) {
+	perms := []string{"2\n10\n1a\n", "1a\n10\n2\n", "10\n1a\n2\n"}
+	want := "n\n2\n10\n1a\n"
+
+	for _, rows := range perms {
+		var out strings.Builder
+		if err := SortReader(strings.NewReader("n\n"+rows), &out, SortOptions{Columns: []string{"n"}, Numeric: true}); err != nil {
+			t.Fatalf("SortReader: %v", err)
+		}
+		if out.String() != want {
+			t.Fatalf("input %q: output = %q, want %q", rows, out.String(), want)
+		}
+
+		// Descending reverses the whole order, non-numeric values included.
+		out.Reset()
+		if err := SortReader(strings.NewReader("n\n"+rows), &out, SortOptions{Columns: []string{"n"}, Numeric: true, Descending: true}); err != nil {
+			t.Fatalf("SortReader: %v", err)
+		}
+		if want := "n\n1a\n10\n2\n"; out.String() != want {
+			t.Fatalf("input %q descending: output = %q, want %q", rows, out.String(), want)
+		}
+	}
+}
+
+func TestSortFile_UnknownColumn(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name\nAnn\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	err := SortFile(in, out, SortOptions{Columns: []string{"missing"}})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
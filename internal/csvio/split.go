@@ -0,0 +1,237 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements splitting one file into several: one per distinct
+// value of a column, or sequential chunks of a fixed row count. Every output
+// file repeats the header.
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SplitStats summarizes a split.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - Counts maps each split key to the number of data rows written for
+//     it: the column value for SplitByColumn, the file name for
+//     SplitIntoChunks.
+type SplitStats struct {
+	RowsRead int
+	Counts   map[string]int
+}
+
+// splitOutput is one open output file of a split.
+type splitOutput struct {
+	f *os.File
+	w *csv.Writer
+}
+
+// splitOutputs tracks the open output files of a split, keyed by file name.
+type splitOutputs struct {
+	dir     string
+	headers []string
+	opts    Options
+	open    map[string]*splitOutput
+}
+
+// get returns the writer for name, creating the file and writing the header
+// on first use.
+func (s *splitOutputs) get(name string) (*csv.Writer, error) {
+	if o, ok := s.open[name]; ok {
+		return o.w, nil
+	}
+
+	f, err := os.Create(filepath.Join(s.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("create output csv: %w", err)
+	}
+	w := newWriter(f, s.opts)
+	if err := w.Write(s.headers); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("write headers: %w", err)
+	}
+	s.open[name] = &splitOutput{f: f, w: w}
+	return w, nil
+}
+
+// release flushes and closes the file for name, if open.
+func (s *splitOutputs) release(name string) error {
+	o, ok := s.open[name]
+	if !ok {
+		return nil
+	}
+	delete(s.open, name)
+
+	o.w.Flush()
+	err := o.w.Error()
+	if cerr := o.f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("close %s: %w", name, err)
+	}
+	return nil
+}
+
+// closeAll flushes and closes every open file and returns the first error.
+func (s *splitOutputs) closeAll() error {
+	var first error
+	for name := range s.open {
+		if err := s.release(name); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// openSplit opens inputPath, creates outDir, and returns the input's rows
+// with an empty output set.
+func openSplit(inputPath, outDir string, opts Options) (io.Closer, *rowReader, *splitOutputs, error) {
+	f, err := openCSV(inputPath, opts)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("open csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(f, opts), opts)
+	if err != nil {
+		f.Close()
+		return nil, nil, nil, err
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		f.Close()
+		return nil, nil, nil, fmt.Errorf("create output dir: %w", err)
+	}
+
+	outs := &splitOutputs{dir: outDir, headers: headers, opts: opts, open: make(map[string]*splitOutput)}
+	return f, rows, outs, nil
+}
+
+// SplitByColumn writes each data row of inputPath to outDir/<value>.csv,
+// where value is the row's value in column col (case-insensitive).
+//
+// Values are made safe for use as file names: path separators and other
+// unsafe characters become "_", and an empty value is written to
+// "_empty.csv". Distinct values that map to the same name share a file.
+//
+// One file per distinct value stays open until the input is exhausted, so a
+// very high-cardinality column can exceed the process's open-file limit.
+func SplitByColumn(inputPath, outDir, col string, opts Options) (stats SplitStats, err error) {
+	in, rows, outs, err := openSplit(inputPath, outDir, opts)
+	if err != nil {
+		return SplitStats{}, err
+	}
+	defer in.Close()
+	defer func() {
+		if cerr := outs.closeAll(); err == nil {
+			err = cerr
+		}
+	}()
+
+	idx, err := ColumnIndex(outs.headers, col)
+	if err != nil {
+		return SplitStats{}, err
+	}
+
+	stats = SplitStats{Counts: make(map[string]int)}
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		rec = normalizeRow(rec, len(outs.headers))
+		w, err := outs.get(splitFileName(rec[idx]) + ".csv")
+		if err != nil {
+			return stats, err
+		}
+		if err := w.Write(rec); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+		stats.Counts[rec[idx]]++
+	}
+
+	return stats, nil
+}
+
+// SplitIntoChunks writes the data rows of inputPath to outDir/part_000.csv,
+// part_001.csv, ..., with at most size rows per file. Only one output file is
+// open at a time.
+func SplitIntoChunks(inputPath, outDir string, size int, opts Options) (stats SplitStats, err error) {
+	if size <= 0 {
+		return SplitStats{}, errors.New("split: chunk size must be > 0")
+	}
+
+	in, rows, outs, err := openSplit(inputPath, outDir, opts)
+	if err != nil {
+		return SplitStats{}, err
+	}
+	defer in.Close()
+	defer func() {
+		if cerr := outs.closeAll(); err == nil {
+			err = cerr
+		}
+	}()
+
+	stats = SplitStats{Counts: make(map[string]int)}
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+
+		// Close each chunk as soon as the next one starts.
+		if stats.RowsRead > 0 && stats.RowsRead%size == 0 {
+			if err := outs.release(chunkFileName(stats.RowsRead/size - 1)); err != nil {
+				return stats, err
+			}
+		}
+		name := chunkFileName(stats.RowsRead / size)
+		stats.RowsRead++
+
+		w, err := outs.get(name)
+		if err != nil {
+			return stats, err
+		}
+		if err := w.Write(normalizeRow(rec, len(outs.headers))); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+		stats.Counts[name]++
+	}
+
+	return stats, nil
+}
+
+// chunkFileName returns the file name of the i-th chunk.
+func chunkFileName(i int) string {
+	return fmt.Sprintf("part_%03d.csv", i)
+}
+
+// splitFileName turns a cell value into a safe file name stem.
+func splitFileName(v string) string {
+	v = strings.TrimSpace(v)
+	if v == "" || v == "." || v == ".." {
+		return "_empty"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\' || r == ':' || r == '*' || r == '?' ||
+			r == '"' || r == '<' || r == '>' || r == '|' || r < ' ':
+			return '_'
+		}
+		return r
+	}, v)
+}
@@ -0,0 +1,58 @@
+package csvio
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSplitByColumn(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,state\nAnn,NY\nBob,IL\nCy,NY\nDi,\nEd,a/b\n")
+	dir := filepath.Join(t.TempDir(), "out")
+
+	stats, err := SplitByColumn(in, dir, "STATE", Options{})
+	if err != nil {
+		t.Fatalf("SplitByColumn: %v", err)
+	}
+	if want := map[string]int{"NY": 2, "IL": 1, "": 1, "a/b": 1}; !reflect.DeepEqual(stats.Counts, want) {
+		t.Fatalf("Counts = %v, want %v", stats.Counts, want)
+	}
+
+	files := map[string]string{
+		"NY.csv":     "name,state\nAnn,NY\nCy,NY\n",
+		"IL.csv":     "name,state\nBob,IL\n",
+		"_empty.csv": "name,state\nDi,\n",
+		"a_b.csv":    "name,state\nEd,a/b\n",
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(got) != want {
+			t.Fatalf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestSplitIntoChunks(t *testing.T) {
+	in := writeTemp(t, "in.csv", numberedCSV(5))
+	dir := t.TempDir()
+
+	stats, err := SplitIntoChunks(in, dir, 2, Options{})
+	if err != nil {
+		t.Fatalf("SplitIntoChunks: %v", err)
+	}
+	if want := map[string]int{"part_000.csv": 2, "part_001.csv": 2, "part_002.csv": 1}; !reflect.DeepEqual(stats.Counts, want) {
+		t.Fatalf("Counts = %v, want %v", stats.Counts, want)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "part_002.csv"))
+	if err != nil {
+		t.Fatalf("read last chunk: %v", err)
+	}
+	if want := "n\n4\n"; string(got) != want {
+		t.Fatalf("last chunk = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file computes per-column summary statistics. The file is streamed and
+// only running totals are kept, so memory use does not grow with row count.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+// ColumnStats summarizes one column of a CSV file.
+//
+// Min and Max are lexicographic over non-null values. The Numeric* fields,
+// Mean, and StdDev are only meaningful when Numeric is true, which requires
+// at least one non-null value and every non-null value to parse as a float
+// (surrounding whitespace ignored).
+type ColumnStats struct {
+	Name string
+
+	Count        int
+	NullCount    int
+	NonNullCount int
+
+	Min string
+	Max string
+
+	Numeric    bool
+	NumericMin float64
+	NumericMax float64
+	Mean       float64
+
+	// StdDev is the sample standard deviation (n-1 denominator); it is 0
+	// when there are fewer than two values.
+	StdDev float64
+}
+
+// ComputeStats reads every data row of the file at path and returns one
+// ColumnStats per header, in header order. Cells for which policy.IsNull
+// reports true count as nulls and are excluded from min/max/mean.
+func ComputeStats(path string, policy nulls.Policy, opts Options) ([]ColumnStats, error) {
+	f, err := openCSV(path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("open csv: %w", err)
+	}
+	defer f.Close()
+
+	headers, r, err := readHeader(newReader(f, opts), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := newStatsAccumulators(len(headers))
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read row: %w", err)
+		}
+
+		rec = normalizeRow(rec, len(headers))
+		for i, v := range rec {
+			acc[i].add(v, policy)
+		}
+	}
+
+	return statsResults(headers, acc), nil
+}
+
+// StatsFromRows is ComputeStats for rows already in memory, e.g. from
+// ReadAll, so one read of a file can feed several views. Missing cells in
+// short rows count as "".
+func StatsFromRows(headers []string, rows [][]string, policy nulls.Policy) []ColumnStats {
+	acc := newStatsAccumulators(len(headers))
+	for _, rec := range rows {
+		for i := range acc {
+			v := ""
+			if i < len(rec) {
+				v = rec[i]
+			}
+			acc[i].add(v, policy)
+		}
+	}
+	return statsResults(headers, acc)
+}
+
+// newStatsAccumulators returns n accumulators ready for add.
+func newStatsAccumulators(n int) []statsAccumulator {
+	acc := make([]statsAccumulator, n)
+	for i := range acc {
+		acc[i].numeric = true
+	}
+	return acc
+}
+
+// statsResults converts one accumulator per header into ColumnStats.
+func statsResults(headers []string, acc []statsAccumulator) []ColumnStats {
+	out := make([]ColumnStats, len(headers))
+	for i, h := range headers {
+		out[i] = acc[i].result(h)
+	}
+	return out
+}
+
+// statsAccumulator holds running totals for one column. Mean and variance
+// use Welford's algorithm, which stays accurate over long streams where a
+// naive sum of squares would lose precision.
+type statsAccumulator struct {
+	count, nulls int
+	min, max     string
+
+	numeric          bool
+	numMin, numMax   float64
+	n                int
+	mean, sumSqDelta float64
+}
+
+func (a *statsAccumulator) add(v string, policy nulls.Policy) {
+	a.count++
+	if policy.IsNull(v) {
+		a.nulls++
+		return
+	}
+
+	if nonNull := a.count - a.nulls; nonNull == 1 || v < a.min {
+		a.min = v
+	}
+	if v > a.max {
+		a.max = v
+	}
+
+	if !a.numeric {
+		return
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		a.numeric = false
+		return
+	}
+
+	a.n++
+	if a.n == 1 || f < a.numMin {
+		a.numMin = f
+	}
+	if a.n == 1 || f > a.numMax {
+		a.numMax = f
+	}
+	delta := f - a.mean
+	a.mean += delta / float64(a.n)
+	a.sumSqDelta += delta * (f - a.mean)
+}
+
+func (a *statsAccumulator) result(name string) ColumnStats {
+	s := ColumnStats{
+		Name:         name,
+		Count:        a.count,
+		NullCount:    a.nulls,
+		NonNullCount: a.count - a.nulls,
+		Min:          a.min,
+		Max:          a.max,
+		Numeric:      a.numeric && a.n > 0,
+	}
+	if s.Numeric {
+		s.NumericMin = a.numMin
+		s.NumericMax = a.numMax
+		s.Mean = a.mean
+		if a.n > 1 {
+			s.StdDev = math.Sqrt(a.sumSqDelta / float64(a.n-1))
+		}
+	}
+	return s
+}
@@ -0,0 +1,65 @@
+package csvio
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+func TestComputeStats(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,age\n"+
+		"Cy,2\n"+
+		"Ann,4\n"+
+		"NA,\n"+
+		"Bob,9\n")
+
+	stats, err := ComputeStats(in, nulls.Policy{TreatBlanks: true, TreatNA: true}, Options{})
+	if err != nil {
+		t.Fatalf("ComputeStats: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("len(stats) = %d, want 2", len(stats))
+	}
+
+	name := stats[0]
+	if name.Count != 4 || name.NullCount != 1 || name.NonNullCount != 3 {
+		t.Fatalf("name counts = %+v", name)
+	}
+	if name.Min != "Ann" || name.Max != "Cy" || name.Numeric {
+		t.Fatalf("name = %+v", name)
+	}
+
+	age := stats[1]
+	if !age.Numeric || age.NumericMin != 2 || age.NumericMax != 9 || age.Mean != 5 {
+		t.Fatalf("age = %+v", age)
+	}
+	// Sample stddev of 2, 4, 9: sqrt(((-3)^2 + (-1)^2 + 4^2) / 2) = sqrt(13).
+	if math.Abs(age.StdDev-math.Sqrt(13)) > 1e-9 {
+		t.Fatalf("StdDev = %v, want %v", age.StdDev, math.Sqrt(13))
+	}
+	// Lexicographic, not numeric.
+	if age.Min != "2" || age.Max != "9" {
+		t.Fatalf("age min/max = %q/%q", age.Min, age.Max)
+	}
+}
+
+func TestStatsFromRows_MatchesComputeStats(t *testing.T) {
+	content := "name,age\nCy,2\nAnn,4\nNA,\nBob,9\n"
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true}
+
+	want, err := ComputeStats(writeTemp(t, "in.csv", content), policy, Options{})
+	if err != nil {
+		t.Fatalf("ComputeStats: %v", err)
+	}
+	headers, rows, err := ReadAllFromReader(strings.NewReader(content), Options{})
+	if err != nil {
+		t.Fatalf("ReadAllFromReader: %v", err)
+	}
+
+	if got := StatsFromRows(headers, rows, policy); !reflect.DeepEqual(got, want) {
+		t.Fatalf("StatsFromRows = %+v, want %+v", got, want)
+	}
+}
@@ -4,15 +4,24 @@
 // In particular, it implements normalization of NULL-like values in a streaming,
 // row-by-row fashion so large files can be processed without loading everything
 // into memory.
+//
+// Throughput is tracked by the BenchmarkNullify* benchmarks
+// (go test -bench Nullify ./internal/csvio); run them before and after
+// changes to the streaming loop.
 package csvio
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strconv"
+	"unicode/utf8"
 
 	"github.com/bensabler/go-mail/internal/nulls"
+	"github.com/bensabler/go-mail/internal/progress"
 )
 
 // NullifyStats captures a summary of a nullify operation.
@@ -23,22 +32,338 @@ import (
 //   - RowsRead counts data rows processed (header excluded).
 //   - CellsChecked counts every cell inspected against the null policy.
 //   - CellsNullified counts cells whose value changed as a result of nullification.
+//   - CellsTruncated counts cells shortened by NullifyOptions.MaxCellLength.
+//   - ParseErrors counts malformed rows skipped under
+//     NullifyOptions.MaxParseErrors (including the one that aborted the run,
+//     if any). Skipped rows are not counted in RowsRead.
+//   - PerColumn breaks CellsChecked and CellsNullified down by header name.
+//     It is set only when the whole input was processed; columns sharing a
+//     name are counted together.
 //
 // A cell that is already empty ("") and matches the null policy is considered
 // "checked" but not "nullified".
+//
+// The JSON form (see "nullify --stats-format json") uses snake_case keys.
 type NullifyStats struct {
-	RowsRead       int
-	CellsChecked   int
-	CellsNullified int
+	RowsRead       int                        `json:"rows_read"`
+	CellsChecked   int                        `json:"cells_checked"`
+	CellsNullified int                        `json:"cells_nullified"`
+	CellsTruncated int                        `json:"cells_truncated"`
+	ParseErrors    int                        `json:"parse_errors"`
+	PerColumn      map[string]ColumnNullStats `json:"per_column,omitempty"`
+}
+
+// ColumnNullStats counts the cells of one column checked and nullified by a
+// nullify run.
+type ColumnNullStats struct {
+	CellsChecked   int `json:"cells_checked"`
+	CellsNullified int `json:"cells_nullified"`
+}
+
+// NullRatePct returns CellsNullified as a percentage of CellsChecked, or 0
+// when no cells were checked.
+func (s ColumnNullStats) NullRatePct() float64 {
+	if s.CellsChecked == 0 {
+		return 0
+	}
+	return 100 * float64(s.CellsNullified) / float64(s.CellsChecked)
+}
+
+// NullifyOptions holds optional behavior for NullifyFile.
+//
+// The zero value is valid and reproduces the default behavior: every row is
+// transformed according to the policy and nothing else happens.
+type NullifyOptions struct {
+	// Options controls how the input is parsed.
+	Options
+
+	// OnRow, when non-nil, is called synchronously for every data row after the
+	// null policy has been applied and before the row is written.
+	//
+	// rowNum is the 1-based data row number (the header is not counted).
+	// original holds the row as read (after width normalization) and
+	// transformed holds the values that will be written. Callers must not
+	// retain or modify either slice after the callback returns.
+	//
+	// This hook exists so callers can add auditing, logging, or progress
+	// reporting without changing this package.
+	OnRow func(rowNum int, original, transformed []string)
+
+	// MultiWriter lists additional destinations that receive the same CSV
+	// bytes as the output file, in a single pass (e.g. stdout or an audit
+	// copy). Writes go through io.MultiWriter, so the first failing
+	// destination aborts the whole operation.
+	MultiWriter []io.Writer
+
+	// MaxCellLength caps cell values at this many runes; longer values are
+	// truncated before the row is written. Zero means no limit. This protects
+	// downstream loads into fixed-size columns (e.g. VARCHAR(255)).
+	MaxCellLength int
+
+	// TruncationMarker is appended to truncated values, with the total still
+	// bounded by MaxCellLength (e.g. "..." makes truncation visible). The
+	// default "" truncates silently.
+	TruncationMarker string
+
+	// ColumnPolicies replaces the default policy for the named columns, e.g.
+	// "-" means NULL in "phone" while "N/A" does in "email". Names are matched
+	// case-insensitively against the header and unknown names are an error.
+	// Columns not listed use the policy passed to NullifyFile.
+	ColumnPolicies map[string]nulls.Policy
+
+	// ConditionalRules swap in a different policy for one column on rows
+	// where another column has a given value (see ConditionalRule). Rules are
+	// checked in order and the first match wins for a cell.
+	ConditionalRules []ConditionalRule
+
+	// Progress, when non-nil, is updated after every row is read with the
+	// cells checked and nullified so far. The caller reports the totals with
+	// Progress.Done.
+	Progress *progress.ProgressReporter
+
+	// TypeColumn names a record-type column for files that mix record kinds
+	// (common in EDI-to-CSV conversions, e.g. "H" header, "D" detail, "T"
+	// trailer records). When set, only rows whose TypeColumn value equals
+	// TypeColumnValue are transformed; all other rows are written through
+	// verbatim, without width normalization.
+	TypeColumn string
+
+	// TypeColumnValue is the record type that gets transformed. Defaults to
+	// "D" when TypeColumn is set and this is empty.
+	TypeColumnValue string
+
+	// WriteInputHash and WriteOutputHash record SHA-256 digests of the raw
+	// input and/or the written output file in a sidecar "<outputPath>.sha256"
+	// using the sha256sum format ("<hex>  <path>", input line first). Hashes
+	// are computed incrementally during the single streaming pass.
+	WriteInputHash  bool
+	WriteOutputHash bool
+
+	// NoAtomic makes NullifyFile write the output file in place. By default
+	// it writes a temporary file in the same directory and renames it over
+	// outputPath only after every row is written, so a failed or interrupted
+	// run never leaves a partial output behind. In-place writing is needed
+	// when the directory is not writable or outputPath must keep its inode,
+	// e.g. a symlink or a named pipe.
+	NoAtomic bool
+
+	// Explain writes a sidecar CSV "<outputPath>.explain.csv" with one line
+	// per nullified cell and the rule that matched (see
+	// nulls.Policy.IsNullWithReason), under the header
+	// "row,col,original_value,reason". row is the 1-based data row number
+	// and col the column name. Cells that were already empty are not listed.
+	Explain bool
+
+	// ExplainWriter, when non-nil, receives the explain CSV described under
+	// Explain. NullifyFile sets it to the sidecar file when Explain is set;
+	// NullifyReader callers set it directly.
+	ExplainWriter io.Writer
+
+	// MaxParseErrors is the number of malformed rows (CSV parse errors, such
+	// as a stray quote) that aborts the run. Zero or one keeps the default:
+	// the first one aborts. A larger value skips malformed rows until that
+	// many have been seen; a negative value skips them all. I/O errors
+	// always abort.
+	MaxParseErrors int
+
+	// OnParseError, when non-nil, is called with each parse error whose row
+	// is skipped under MaxParseErrors.
+	OnParseError func(err error)
+}
+
+// ExplainSuffix is appended to the output path to name the Explain sidecar.
+const ExplainSuffix = ".explain.csv"
+
+// ConditionalRule applies Policy to TargetCol instead of the global (or
+// per-column) policy on rows where ConditionCol equals ConditionVal exactly.
+//
+// Example: nullify "phone" placeholders only for contacts who do not want
+// phone contact:
+//
+//	ConditionalRule{
+//		TargetCol:    "phone",
+//		ConditionCol: "contact_preference",
+//		ConditionVal: "email",
+//		Policy:       nulls.Policy{TreatBlanks: true, TreatNA: true},
+//	}
+//
+// Column names are matched case-insensitively against the header; the
+// condition is evaluated on the row as read, before any cell is nullified.
+type ConditionalRule struct {
+	TargetCol    string
+	ConditionCol string
+	ConditionVal string
+	Policy       nulls.Policy
+}
+
+// conditionalRule is a ConditionalRule with column names resolved to indices.
+type conditionalRule struct {
+	target, cond int
+	val          string
+	policy       nulls.Policy
+}
+
+// resolveRules maps rule column names to header indices, failing on unknown
+// columns so typos are caught before any output is written.
+func resolveRules(headers []string, rules []ConditionalRule) ([]conditionalRule, error) {
+	resolved := make([]conditionalRule, 0, len(rules))
+	for _, rule := range rules {
+		target, err := ColumnIndex(headers, rule.TargetCol)
+		if err != nil {
+			return nil, fmt.Errorf("conditional rule target: %w", err)
+		}
+		cond, err := ColumnIndex(headers, rule.ConditionCol)
+		if err != nil {
+			return nil, fmt.Errorf("conditional rule condition: %w", err)
+		}
+		resolved = append(resolved, conditionalRule{target: target, cond: cond, val: rule.ConditionVal, policy: rule.Policy})
+	}
+	return resolved, nil
+}
+
+// resolveColumnPolicies returns the policy for every column: def, replaced by
+// the entry in byName for listed columns. It returns nil when byName is
+// empty so callers can skip per-column lookups entirely.
+func resolveColumnPolicies(headers []string, def nulls.Policy, byName map[string]nulls.Policy) ([]nulls.Policy, error) {
+	if len(byName) == 0 {
+		return nil, nil
+	}
+
+	// Resolve in sorted order so an unknown column is reported the same way
+	// on every run.
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	policies := make([]nulls.Policy, len(headers))
+	for i := range policies {
+		policies[i] = def
+	}
+	for _, name := range names {
+		idx, err := ColumnIndex(headers, name)
+		if err != nil {
+			return nil, fmt.Errorf("column policy: %w", err)
+		}
+		policies[idx] = byName[name]
+	}
+	return policies, nil
 }
 
 // NullifyFile reads an input CSV file and writes a new CSV file with NULL-like
-// values normalized according to the provided policy.
+// values normalized according to the provided policy. It opens the files and
+// delegates the transformation to NullifyReader; see there for details.
+//
+// The output replaces outputPath atomically unless opts.NoAtomic is set.
+//
+// The path "-" selects stdin or stdout. The sidecar features of opts
+// (WriteInputHash, WriteOutputHash and Explain) are implemented here because
+// they are written next to outputPath, so they require an output file.
+func NullifyFile(inputPath, outputPath string, policy nulls.Policy, opts NullifyOptions) (NullifyStats, error) {
+	// Open the input CSV for reading.
+	// Hash the raw input bytes (before decompression and transcoding) when
+	// requested.
+	var inHash *hashingReader
+	f, err := openInput(inputPath, opts.HTTPTimeout, func(r io.Reader) io.Reader {
+		if !opts.WriteInputHash {
+			return r
+		}
+		inHash = newHashingReader(r)
+		return inHash
+	})
+	if err != nil {
+		return NullifyStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer f.Close()
+
+	// Sidecars live next to the output, so stdout output cannot have one.
+	if outputPath == StdioPath && (opts.WriteInputHash || opts.WriteOutputHash) {
+		return NullifyStats{}, errors.New("hash sidecar requires an output file, not stdout")
+	}
+	if outputPath == StdioPath && opts.Explain {
+		return NullifyStats{}, errors.New("explain sidecar requires an output file, not stdout")
+	}
+
+	// Create the output CSV; "-" is stdout. When requested, hash exactly
+	// the bytes written to the file (after any compression).
+	var outHash *hashingWriter
+	tap := func(w io.Writer) io.Writer {
+		if !opts.WriteOutputHash {
+			return w
+		}
+		outHash = newHashingWriter(w)
+		return outHash
+	}
+	var out io.WriteCloser
+	abort := func() {}
+	if opts.NoAtomic {
+		out, err = createOutput(outputPath, tap)
+	} else {
+		out, abort, err = createAtomicOutput(outputPath, tap)
+	}
+	if err != nil {
+		return NullifyStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	// On failure, discard the partial output (atomic mode) before releasing
+	// the file. On success out is closed explicitly below.
+	committed := false
+	defer func() {
+		if !committed {
+			abort()
+		}
+		_ = out.Close()
+	}()
+
+	if opts.Explain {
+		ef, err := os.Create(outputPath + ExplainSuffix)
+		if err != nil {
+			return NullifyStats{}, fmt.Errorf("create explain csv: %w", err)
+		}
+		defer ef.Close()
+		opts.ExplainWriter = ef
+	}
+
+	stats, err := NullifyReader(f, out, policy, opts)
+	if err != nil {
+		return stats, err
+	}
+
+	// Close now: this writes a gzip trailer (which the output hash must
+	// cover) and, in atomic mode, renames the output into place.
+	committed = true
+	if err := out.Close(); err != nil {
+		return stats, fmt.Errorf("close output csv: %w", err)
+	}
+
+	if inHash != nil || outHash != nil {
+		var lines []hashLine
+		if inHash != nil {
+			// The parser stops at EOF, but drain anyway so the digest always
+			// covers the whole file.
+			if _, err := io.Copy(io.Discard, inHash); err != nil {
+				return stats, fmt.Errorf("hash input csv: %w", err)
+			}
+			lines = append(lines, hashLine{sum: inHash.Sum(), path: inputPath})
+		}
+		if outHash != nil {
+			lines = append(lines, hashLine{sum: outHash.Sum(), path: outputPath})
+		}
+		if err := writeHashSidecar(outputPath+".sha256", lines); err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+// NullifyReader reads CSV from src and writes it to dst with NULL-like values
+// normalized according to the provided policy.
 //
 // In this tool, CSV "NULL" is represented as an empty field ("") on output.
 // The function operates in a streaming manner:
 //
-//   - The input file is read row-by-row.
+//   - The input is read row-by-row.
 //   - Each row is normalized to the header width.
 //   - Each cell is checked against the null policy.
 //   - Matching values are replaced with "".
@@ -49,45 +374,94 @@ type NullifyStats struct {
 //
 // The header row is copied verbatim from input to output and is not modified.
 //
+// Optional behavior (such as per-row callbacks) is configured via opts; the zero
+// value of NullifyOptions is the default behavior. The file-only options
+// WriteInputHash, WriteOutputHash and Explain are ignored; use ExplainWriter
+// to capture explanations.
+//
 // Errors are wrapped with contextual information to make CLI error messages
 // actionable (e.g., distinguishing read errors from write errors).
-func NullifyFile(inputPath, outputPath string, policy nulls.Policy) (NullifyStats, error) {
-	// Open the input CSV for reading.
-	in, err := os.Open(inputPath)
+func NullifyReader(src io.Reader, dst io.Writer, policy nulls.Policy, opts NullifyOptions) (NullifyStats, error) {
+	return nullifyReader(src, dst, policy, opts, nil)
+}
+
+// nullifyReader implements NullifyReader. When onChange is non-nil it is
+// called for every cell whose value the policy changed, in input order.
+func nullifyReader(src io.Reader, dst io.Writer, policy nulls.Policy, opts NullifyOptions, onChange func(ChangedCell)) (NullifyStats, error) {
+	in, err := inputReader(src, opts.Options)
 	if err != nil {
 		return NullifyStats{}, fmt.Errorf("open input csv: %w", err)
 	}
-	defer in.Close()
 
-	// Create (or truncate) the output CSV.
-	out, err := os.Create(outputPath)
-	if err != nil {
-		return NullifyStats{}, fmt.Errorf("create output csv: %w", err)
+	// Fan out to any extra destinations alongside the main output.
+	if len(opts.MultiWriter) > 0 {
+		dst = io.MultiWriter(append([]io.Writer{dst}, opts.MultiWriter...)...)
 	}
-	// Best-effort close; write errors are handled via csv.Writer.
-	defer func() {
-		_ = out.Close()
-	}()
+
+	// csv.Writer buffers output; Flush is required to surface write errors.
+	w := newWriter(dst, opts.Options)
+	defer w.Flush()
 
 	// Configure CSV reader to allow variable-length rows.
 	// Structural normalization happens explicitly via normalizeRow.
-	r := csv.NewReader(in)
-	r.FieldsPerRecord = -1
+	headers, r, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return NullifyStats{}, err
+	}
 
-	// csv.Writer buffers output; Flush is required to surface write errors.
-	w := csv.NewWriter(out)
-	defer w.Flush()
+	// Write headers unchanged. Synthesized headers (no header row in the
+	// input) are not written so the output keeps the input's shape.
+	if !r.headerless {
+		if err := w.Write(headers); err != nil {
+			return NullifyStats{}, fmt.Errorf("write headers: %w", err)
+		}
+	}
+
+	rules, err := resolveRules(headers, opts.ConditionalRules)
+	if err != nil {
+		return NullifyStats{}, err
+	}
+
+	// Resolve the record-type filter, if any.
+	typeCol := -1
+	typeVal := opts.TypeColumnValue
+	if opts.TypeColumn != "" {
+		typeCol, err = ColumnIndex(headers, opts.TypeColumn)
+		if err != nil {
+			return NullifyStats{}, fmt.Errorf("type column: %w", err)
+		}
+		if typeVal == "" {
+			typeVal = "D"
+		}
+	}
+
+	// The explain CSV is plain comma-separated whatever the input dialect, so
+	// it can be loaded the same way for every run.
+	var explain *csv.Writer
+	if opts.ExplainWriter != nil {
+		explain = csv.NewWriter(opts.ExplainWriter)
+		if err := explain.Write([]string{"row", "col", "original_value", "reason"}); err != nil {
+			return NullifyStats{}, fmt.Errorf("write explain csv: %w", err)
+		}
+	}
 
-	// Read and write headers unchanged.
-	headers, err := r.Read()
+	columnPolicies, err := resolveColumnPolicies(headers, policy, opts.ColumnPolicies)
 	if err != nil {
-		return NullifyStats{}, fmt.Errorf("read headers: %w", err)
+		return NullifyStats{}, err
 	}
-	if err := w.Write(headers); err != nil {
-		return NullifyStats{}, fmt.Errorf("write headers: %w", err)
+
+	// cellPolicies holds the policy for each column of the current row. It is
+	// only needed (and reused across rows) when conditional rules exist;
+	// otherwise columnPolicies, if set, applies to every row as is.
+	cellPolicies := columnPolicies
+	if len(rules) > 0 {
+		cellPolicies = make([]nulls.Policy, len(headers))
 	}
 
 	stats := NullifyStats{}
+	// Per-column counts are kept by index in the loop and keyed by name once
+	// the input is exhausted, which avoids a map update per cell.
+	perCol := make([]ColumnNullStats, len(headers))
 
 	// Process data rows until EOF.
 	for {
@@ -95,28 +469,98 @@ func NullifyFile(inputPath, outputPath string, policy nulls.Policy) (NullifyStat
 		if err == io.EOF {
 			break
 		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) && (opts.MaxParseErrors < 0 || opts.MaxParseErrors > 1) {
+			stats.ParseErrors++
+			if opts.MaxParseErrors > 0 && stats.ParseErrors >= opts.MaxParseErrors {
+				return stats, fmt.Errorf("read row: too many parse errors (%d): %w", stats.ParseErrors, err)
+			}
+			if opts.OnParseError != nil {
+				opts.OnParseError(err)
+			}
+			continue
+		}
 		if err != nil {
 			return stats, fmt.Errorf("read row: %w", err)
 		}
 
 		stats.RowsRead++
+		opts.Progress.Update(stats.RowsRead,
+			progress.Counter{Name: "cells checked", Value: stats.CellsChecked},
+			progress.Counter{Name: "cells nullified", Value: stats.CellsNullified})
+
+		// Rows of other record types pass through untouched.
+		if typeCol >= 0 && (typeCol >= len(rec) || rec[typeCol] != typeVal) {
+			if opts.OnRow != nil {
+				opts.OnRow(stats.RowsRead, rec, rec)
+			}
+			if err := w.Write(rec); err != nil {
+				return stats, fmt.Errorf("write row: %w", err)
+			}
+			continue
+		}
 
 		// Normalize the record to match the header width.
 		// Short rows are padded with "", long rows are truncated.
 		rec = normalizeRow(rec, len(headers))
 
+		// Keep a copy of the untouched row only when someone wants to see it.
+		var original []string
+		if opts.OnRow != nil {
+			original = append([]string(nil), rec...)
+		}
+
+		// Resolve per-cell policies from the untouched row. Rules are applied
+		// last-to-first so the first matching rule wins.
+		if len(rules) > 0 {
+			for i := range cellPolicies {
+				cellPolicies[i] = policy
+			}
+			copy(cellPolicies, columnPolicies)
+			for k := len(rules) - 1; k >= 0; k-- {
+				if rec[rules[k].cond] == rules[k].val {
+					cellPolicies[rules[k].target] = rules[k].policy
+				}
+			}
+		}
+
 		// Apply null policy cell-by-cell.
 		for i := range rec {
 			stats.CellsChecked++
+			perCol[i].CellsChecked++
+
+			p := policy
+			if cellPolicies != nil {
+				p = cellPolicies[i]
+			}
 
-			if policy.IsNull(rec[i]) {
+			if isNull, reason := p.IsNullWithReason(rec[i]); isNull {
 				// CSV NULL convention: empty field.
 				// Only count as "nullified" if the value actually changed.
 				if rec[i] != "" {
 					stats.CellsNullified++
+					perCol[i].CellsNullified++
+					if onChange != nil {
+						onChange(ChangedCell{Row: stats.RowsRead, Col: headers[i], OriginalValue: rec[i], Reason: reason})
+					}
+					if explain != nil {
+						line := []string{strconv.Itoa(stats.RowsRead), headers[i], rec[i], reason}
+						if err := explain.Write(line); err != nil {
+							return stats, fmt.Errorf("write explain csv: %w", err)
+						}
+					}
 				}
 				rec[i] = ""
 			}
+
+			if opts.MaxCellLength > 0 && utf8.RuneCountInString(rec[i]) > opts.MaxCellLength {
+				rec[i] = truncateCell(rec[i], opts.MaxCellLength, opts.TruncationMarker)
+				stats.CellsTruncated++
+			}
+		}
+
+		if opts.OnRow != nil {
+			opts.OnRow(stats.RowsRead, original, rec)
 		}
 
 		if err := w.Write(rec); err != nil {
@@ -124,11 +568,49 @@ func NullifyFile(inputPath, outputPath string, policy nulls.Policy) (NullifyStat
 		}
 	}
 
+	stats.PerColumn = make(map[string]ColumnNullStats, len(headers))
+	for i, h := range headers {
+		c := stats.PerColumn[h]
+		c.CellsChecked += perCol[i].CellsChecked
+		c.CellsNullified += perCol[i].CellsNullified
+		stats.PerColumn[h] = c
+	}
+
 	// Flush buffered output and check for write errors.
 	w.Flush()
 	if err := w.Error(); err != nil {
 		return stats, fmt.Errorf("flush output csv: %w", err)
 	}
+	if explain != nil {
+		explain.Flush()
+		if err := explain.Error(); err != nil {
+			return stats, fmt.Errorf("flush explain csv: %w", err)
+		}
+	}
 
 	return stats, nil
 }
+
+// truncateCell shortens s to exactly max runes, ending with marker when one is
+// given. If the marker alone does not fit, it is itself cut to max runes.
+//
+// Callers must only pass values longer than max runes.
+func truncateCell(s string, max int, marker string) string {
+	keep := max - utf8.RuneCountInString(marker)
+	if keep <= 0 {
+		return takeRunes(marker, max)
+	}
+	return takeRunes(s, keep) + marker
+}
+
+// takeRunes returns the first n runes of s (Unicode-safe).
+func takeRunes(s string, n int) string {
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
@@ -0,0 +1,653 @@
+package csvio
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+// writeTemp writes content to a file in a per-test temp directory and returns
+// its path.
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	return path
+}
+
+func TestNullifyFile_OnRowReportsChangedRows(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,email,phone\n"+
+		"Ann,ann@example.com,5551234\n"+
+		"Bob,NA,\n"+
+		"Cy, ,NULL\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	type change struct {
+		row                   int
+		original, transformed []string
+	}
+	var changed []change
+
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true, TreatNULLLiteral: true}
+	_, err := NullifyFile(in, out, policy, NullifyOptions{
+		OnRow: func(rowNum int, original, transformed []string) {
+			if reflect.DeepEqual(original, transformed) {
+				return
+			}
+			changed = append(changed, change{
+				row:         rowNum,
+				original:    append([]string(nil), original...),
+				transformed: append([]string(nil), transformed...),
+			})
+		},
+	})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	want := []change{
+		{row: 2, original: []string{"Bob", "NA", ""}, transformed: []string{"Bob", "", ""}},
+		{row: 3, original: []string{"Cy", " ", "NULL"}, transformed: []string{"Cy", "", ""}},
+	}
+	if !reflect.DeepEqual(changed, want) {
+		t.Fatalf("changed rows mismatch\n got: %#v\nwant: %#v", changed, want)
+	}
+}
+
+func TestNullifyFile_MultiWriterCopiesOutput(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,email\nAnn,NA\nBob,bob@example.com\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	var a, b bytes.Buffer
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true}
+	if _, err := NullifyFile(in, out, policy, NullifyOptions{MultiWriter: []io.Writer{&a, &b}}); err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	file, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	want := "name,email\nAnn,\nBob,bob@example.com\n"
+	if string(file) != want {
+		t.Fatalf("output file = %q, want %q", file, want)
+	}
+	if a.String() != want || b.String() != want {
+		t.Fatalf("extra writers differ from output\n a=%q\n b=%q", a.String(), b.String())
+	}
+}
+
+// errWriter fails every write.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("boom") }
+
+func TestNullifyFile_MultiWriterErrorAborts(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name\nAnn\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	var ok bytes.Buffer
+	_, err := NullifyFile(in, out, nulls.Policy{}, NullifyOptions{MultiWriter: []io.Writer{&ok, errWriter{}}})
+	if err == nil {
+		t.Fatalf("expected error from failing destination")
+	}
+}
+
+func TestNullifyFile_AtomicKeepsOriginalOnFailure(t *testing.T) {
+	// Enough rows that the csv.Writer flushes several times before the
+	// malformed last line fails the read.
+	var content strings.Builder
+	content.WriteString("name,note\n")
+	for i := 0; i < 5000; i++ {
+		content.WriteString("Ann,NA\n")
+	}
+	content.WriteString("Bob,\"bad\"quote\n")
+	in := writeTemp(t, "in.csv", content.String())
+
+	tests := []struct {
+		name          string
+		noAtomic      bool
+		wantUntouched bool
+	}{
+		{name: "atomic", noAtomic: false, wantUntouched: true},
+		{name: "no atomic", noAtomic: true, wantUntouched: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			out := filepath.Join(dir, "out.csv")
+			if err := os.WriteFile(out, []byte("previous\n"), 0o600); err != nil {
+				t.Fatalf("write fixture: %v", err)
+			}
+
+			_, err := NullifyFile(in, out, nulls.Policy{TreatNA: true}, NullifyOptions{NoAtomic: tt.noAtomic})
+			if err == nil {
+				t.Fatalf("expected read error")
+			}
+
+			got, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("read output: %v", err)
+			}
+			if untouched := string(got) == "previous\n"; untouched != tt.wantUntouched {
+				t.Fatalf("output untouched = %v, want %v", untouched, tt.wantUntouched)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("read dir: %v", err)
+			}
+			if len(entries) != 1 {
+				t.Fatalf("temporary file left behind: %v", entries)
+			}
+		})
+	}
+}
+
+func TestNullifyFile_AtomicReplaces(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,note\nAnn,NA\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+	if err := os.WriteFile(out, []byte("previous\n"), 0o600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	if _, err := NullifyFile(in, out, nulls.Policy{TreatNA: true}, NullifyOptions{}); err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "name,note\nAnn,\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+	fi, err := os.Stat(out)
+	if err != nil {
+		t.Fatalf("stat output: %v", err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("mode = %o, want the original 600", perm)
+	}
+}
+
+func TestNullifyFile_MaxCellLength(t *testing.T) {
+	in := writeTemp(t, "in.csv", "note\nshort\nabcdefghij\nééééééé\n")
+
+	tests := []struct {
+		name   string
+		marker string
+		want   string
+	}{
+		{name: "silent", marker: "", want: "note\nshort\nabcdef\néééééé\n"},
+		{name: "marker", marker: "...", want: "note\nshort\nabc...\nééé...\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "out.csv")
+			stats, err := NullifyFile(in, out, nulls.Policy{}, NullifyOptions{
+				MaxCellLength:    6,
+				TruncationMarker: tt.marker,
+			})
+			if err != nil {
+				t.Fatalf("NullifyFile: %v", err)
+			}
+			if stats.CellsTruncated != 2 {
+				t.Fatalf("CellsTruncated = %d, want 2", stats.CellsTruncated)
+			}
+
+			got, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("read output: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// benchmarkNullify measures NullifyReader throughput on a generated fixture
+// of the given row count. The fixture is built in memory before the timer
+// starts and output is discarded, so only the streaming loop is timed; rows/sec
+// is reported alongside ns/op.
+func benchmarkNullify(b *testing.B, rows int) {
+	var buf bytes.Buffer
+	buf.WriteString("first_name,last_name,company,city,state,zip,email,phone\n")
+	for i := 0; i < rows; i++ {
+		switch i % 4 {
+		case 0:
+			fmt.Fprintf(&buf, "Ann,Lee,Acme Corp,Albany,NY,12207,ann%d@example.com,5185551234\n", i)
+		case 1:
+			fmt.Fprintf(&buf, "Bob,,NULL,Troy,NY,NA,bob%d@example.com,\n", i)
+		case 2:
+			fmt.Fprintf(&buf, " ,Doe, ,N/A,NY,12180,,NA\n")
+		default:
+			fmt.Fprintf(&buf, "Cy,Ray,,Buffalo,NY,14202,cy%d@example.com,7165554321\n", i)
+		}
+	}
+
+	fixture := buf.Bytes()
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true, TreatNULLLiteral: true}
+
+	b.SetBytes(int64(len(fixture)))
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		if _, err := NullifyReader(bytes.NewReader(fixture), io.Discard, policy, NullifyOptions{}); err != nil {
+			b.Fatalf("NullifyReader: %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+	b.ReportMetric(float64(b.N*rows)/elapsed.Seconds(), "rows/sec")
+}
+
+func BenchmarkNullify1K(b *testing.B)   { benchmarkNullify(b, 1_000) }
+func BenchmarkNullify100K(b *testing.B) { benchmarkNullify(b, 100_000) }
+func BenchmarkNullify1M(b *testing.B)   { benchmarkNullify(b, 1_000_000) }
+
+func TestNullifyFile_ConditionalRules(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,contact_preference,phone\n"+
+		"Ann,email,NA\n"+
+		"Bob,phone,NA\n"+
+		"Cy,email,5551234\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	_, err := NullifyFile(in, out, nulls.Policy{TreatBlanks: true}, NullifyOptions{
+		ConditionalRules: []ConditionalRule{{
+			TargetCol:    "phone",
+			ConditionCol: "contact_preference",
+			ConditionVal: "email",
+			Policy:       nulls.Policy{TreatBlanks: true, TreatNA: true},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	want := "name,contact_preference,phone\n" +
+		"Ann,email,\n" +
+		"Bob,phone,NA\n" +
+		"Cy,email,5551234\n"
+	if string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullifyFile_ConditionalRuleUnknownColumn(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,phone\nAnn,NA\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	_, err := NullifyFile(in, out, nulls.Policy{}, NullifyOptions{
+		ConditionalRules: []ConditionalRule{{TargetCol: "phone", ConditionCol: "missing"}},
+	})
+	if err == nil {
+		t.Fatalf("expected error for unknown condition column")
+	}
+}
+
+func TestNullifyFile_TypeColumn(t *testing.T) {
+	in := writeTemp(t, "in.csv", "type,name,amount\n"+
+		"H,NA,BATCH01\n"+
+		"D,NA,10\n"+
+		"D,Bob,NULL\n"+
+		"T,2\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true, TreatNULLLiteral: true}
+	stats, err := NullifyFile(in, out, policy, NullifyOptions{TypeColumn: "type"})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+	if stats.RowsRead != 4 || stats.CellsNullified != 2 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	want := "type,name,amount\n" +
+		"H,NA,BATCH01\n" +
+		"D,,10\n" +
+		"D,Bob,\n" +
+		"T,2\n"
+	if string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullifyFile_WritesHashSidecar(t *testing.T) {
+	input := "name,email\nAnn,NA\n"
+	in := writeTemp(t, "in.csv", input)
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	_, err := NullifyFile(in, out, nulls.Policy{TreatNA: true}, NullifyOptions{
+		WriteInputHash:  true,
+		WriteOutputHash: true,
+	})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	written, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	inSum := sha256.Sum256([]byte(input))
+	outSum := sha256.Sum256(written)
+	want := hex.EncodeToString(inSum[:]) + "  " + in + "\n" +
+		hex.EncodeToString(outSum[:]) + "  " + out + "\n"
+
+	got, err := os.ReadFile(out + ".sha256")
+	if err != nil {
+		t.Fatalf("read sidecar: %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("sidecar = %q, want %q", got, want)
+	}
+}
+
+func TestNullifyFile_Delimiter(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name;email\nAnn;NA\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	_, err := NullifyFile(in, out, nulls.Policy{TreatNA: true}, NullifyOptions{Options: Options{Delimiter: ';'}})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "name;email\nAnn;\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullifyFile_HashSidecarGzip(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,email\nAnn,NA\n")
+	out := filepath.Join(t.TempDir(), "out.csv.gz")
+
+	_, err := NullifyFile(in, out, nulls.Policy{TreatNA: true}, NullifyOptions{WriteOutputHash: true})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	// The digest covers the compressed file as stored, so sha256sum -c works.
+	written, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	sum := sha256.Sum256(written)
+	want := hex.EncodeToString(sum[:]) + "  " + out + "\n"
+
+	got, err := os.ReadFile(out + ".sha256")
+	if err != nil {
+		t.Fatalf("read sidecar: %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("sidecar = %q, want %q", got, want)
+	}
+}
+
+func TestNullifyFile_Explain(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,email,status\n"+
+		"Ann,NA,\n"+
+		"Bob, ,TBD\n"+
+		"Cy,cy@example.com,NULL\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true, TreatNULLLiteral: true}
+	policy.AddSentinel("TBD")
+	if _, err := NullifyFile(in, out, policy, NullifyOptions{Explain: true}); err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	got, err := os.ReadFile(out + ExplainSuffix)
+	if err != nil {
+		t.Fatalf("read explain sidecar: %v", err)
+	}
+	// Ann's empty status was already NULL and is not listed. csv.Writer
+	// quotes the leading space in Bob's email.
+	want := "row,col,original_value,reason\n" +
+		"1,email,NA,na\n" +
+		"2,email,\" \",blank\n" +
+		"2,status,TBD,sentinel:TBD\n" +
+		"3,status,NULL,null_literal\n"
+	if string(got) != want {
+		t.Fatalf("explain = %q, want %q", got, want)
+	}
+}
+
+func TestNullifyFile_ColumnPolicies(t *testing.T) {
+	in := writeTemp(t, "in.csv", "a,b,c\n"+
+		" ,NA,NA\n"+
+		"NA, ,x\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	// a: blanks only, b: NA only, c: falls back to the default (nothing).
+	stats, err := NullifyFile(in, out, nulls.Policy{}, NullifyOptions{
+		ColumnPolicies: map[string]nulls.Policy{
+			"A": {TreatBlanks: true},
+			"b": {TreatNA: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+	if stats.CellsNullified != 2 {
+		t.Fatalf("CellsNullified = %d, want 2", stats.CellsNullified)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	want := "a,b,c\n" +
+		",,NA\n" +
+		"NA,\" \",x\n"
+	if string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullifyFile_ColumnPoliciesWithConditionalRule(t *testing.T) {
+	in := writeTemp(t, "in.csv", "pref,phone\nemail,N/A\nphone,-\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	_, err := NullifyFile(in, out, nulls.Policy{}, NullifyOptions{
+		ColumnPolicies: map[string]nulls.Policy{"phone": {TreatDash: true}},
+		ConditionalRules: []ConditionalRule{{
+			TargetCol:    "phone",
+			ConditionCol: "pref",
+			ConditionVal: "email",
+			Policy:       nulls.Policy{TreatNA: true},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "pref,phone\nemail,\nphone,\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestNullifyFile_ColumnPolicyUnknownColumn(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name\nAnn\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	_, err := NullifyFile(in, out, nulls.Policy{}, NullifyOptions{
+		ColumnPolicies: map[string]nulls.Policy{"missing": {TreatNA: true}},
+	})
+	if !errors.Is(err, ErrColumnNotFound) {
+		t.Fatalf("err = %v, want ErrColumnNotFound", err)
+	}
+}
+
+func TestNullifyReader(t *testing.T) {
+	src := strings.NewReader("name,email,phone\nAnn,NA,\nBob,bob@example.com,NULL\n")
+	var dst, explain bytes.Buffer
+
+	policy := nulls.Policy{TreatBlanks: true, TreatNA: true, TreatNULLLiteral: true}
+	stats, err := NullifyReader(src, &dst, policy, NullifyOptions{ExplainWriter: &explain})
+	if err != nil {
+		t.Fatalf("NullifyReader: %v", err)
+	}
+
+	if want := "name,email,phone\nAnn,,\nBob,bob@example.com,\n"; dst.String() != want {
+		t.Fatalf("output = %q, want %q", dst.String(), want)
+	}
+	if stats.RowsRead != 2 || stats.CellsChecked != 6 || stats.CellsNullified != 2 {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if want := "row,col,original_value,reason\n1,email,NA,na\n2,phone,NULL,null_literal\n"; explain.String() != want {
+		t.Fatalf("explain = %q, want %q", explain.String(), want)
+	}
+}
+
+func TestNullifyReader_WriteError(t *testing.T) {
+	src := strings.NewReader("name\nAnn\n")
+	if _, err := NullifyReader(src, errWriter{}, nulls.Policy{}, NullifyOptions{}); err == nil {
+		t.Fatalf("expected write error")
+	}
+}
+
+func TestNullifyReader_MaxParseErrors(t *testing.T) {
+	// Row 2 has a stray quote in an unquoted field.
+	const input = "name,note\nAnn,NULL\nBob,5\" screen\nCy,NULL\n"
+	policy := nulls.Policy{TreatNULLLiteral: true}
+
+	if _, err := NullifyReader(strings.NewReader(input), io.Discard, policy, NullifyOptions{}); err == nil {
+		t.Fatal("default: expected the parse error to abort")
+	}
+
+	tests := []struct {
+		name string
+		max  int
+	}{
+		{name: "skip all", max: -1},
+		{name: "limit not reached", max: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst bytes.Buffer
+			var skipped []error
+			stats, err := NullifyReader(strings.NewReader(input), &dst, policy, NullifyOptions{
+				MaxParseErrors: tt.max,
+				OnParseError:   func(err error) { skipped = append(skipped, err) },
+			})
+			if err != nil {
+				t.Fatalf("NullifyReader: %v", err)
+			}
+			if want := "name,note\nAnn,\nCy,\n"; dst.String() != want {
+				t.Fatalf("output = %q, want %q", dst.String(), want)
+			}
+			if stats.RowsRead != 2 || stats.ParseErrors != 1 || len(skipped) != 1 {
+				t.Fatalf("stats = %+v, skipped = %v", stats, skipped)
+			}
+			var perr *csv.ParseError
+			if !errors.As(skipped[0], &perr) || perr.Line != 3 {
+				t.Fatalf("skipped error = %v, want a parse error on line 3", skipped[0])
+			}
+		})
+	}
+}
+
+func TestNullifyReader_MaxParseErrorsReached(t *testing.T) {
+	const input = "a\nx\"y\nok\nx\"z\n"
+
+	stats, err := NullifyReader(strings.NewReader(input), io.Discard, nulls.Policy{}, NullifyOptions{MaxParseErrors: 2})
+	if err == nil || !strings.Contains(err.Error(), "too many parse errors (2)") {
+		t.Fatalf("err = %v, want too many parse errors", err)
+	}
+	if stats.ParseErrors != 2 || stats.RowsRead != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+func TestNullifyFile_PerColumn(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	stats, err := NullifyFile("../../data/test_mail_data.csv", out, nulls.Policy{
+		TreatBlanks:      true,
+		TreatNA:          true,
+		TreatNULLLiteral: true,
+	}, NullifyOptions{})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	want := map[string]ColumnNullStats{
+		"first_name": {CellsChecked: 10},
+		"last_name":  {CellsChecked: 10},
+		"company":    {CellsChecked: 10, CellsNullified: 1},
+		"address1":   {CellsChecked: 10, CellsNullified: 1},
+		"address2":   {CellsChecked: 10, CellsNullified: 3},
+		"city":       {CellsChecked: 10, CellsNullified: 1},
+		"state":      {CellsChecked: 10},
+		"zip":        {CellsChecked: 10, CellsNullified: 1},
+		"email":      {CellsChecked: 10, CellsNullified: 2},
+		"phone":      {CellsChecked: 10, CellsNullified: 1},
+	}
+	if !reflect.DeepEqual(stats.PerColumn, want) {
+		t.Fatalf("PerColumn = %+v, want %+v", stats.PerColumn, want)
+	}
+	if got := stats.PerColumn["address2"].NullRatePct(); got != 30 {
+		t.Fatalf("address2 null rate = %v, want 30", got)
+	}
+}
+
+func TestNullifyStats_JSON(t *testing.T) {
+	in := writeTemp(t, "in.csv", "name,email\nAnn,NA\nBob,\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	stats, err := NullifyFile(in, out, nulls.Policy{TreatBlanks: true, TreatNA: true}, NullifyOptions{})
+	if err != nil {
+		t.Fatalf("NullifyFile: %v", err)
+	}
+
+	b, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"per_column":{"email":{"cells_checked":2,"cells_nullified":1}`) {
+		t.Fatalf("JSON = %s", b)
+	}
+
+	var got NullifyStats
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(got, stats) {
+		t.Fatalf("round trip = %+v, want %+v", got, stats)
+	}
+}
@@ -0,0 +1,72 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements transposing a CSV: swapping rows and columns, e.g. to
+// turn a key,value configuration file into one wide row. Like sorting it
+// cannot stream; the whole file is held in memory.
+package csvio
+
+import (
+	"fmt"
+	"io"
+)
+
+// TransposeFile writes the transpose of inputPath to outputPath. The header
+// row takes part like any other row, so the original first column becomes
+// the new header and each later column becomes a data row.
+//
+// Jagged input is padded with "" to the width of the longest row, so no
+// cell is lost. opts.AutoDetectHeader is ignored: there is no header to
+// detect until after the transpose.
+func TransposeFile(inputPath, outputPath string, opts Options) error {
+	in, err := OpenInputWithOptions(inputPath, opts)
+	if err != nil {
+		return fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return TransposeReader(in, out, opts)
+}
+
+// TransposeReader is TransposeFile for an already-open input and output,
+// e.g. when writing to stdout. r is raw input; opts.Encoding is applied.
+func TransposeReader(r io.Reader, w io.Writer, opts Options) error {
+	in, err := inputReader(r, opts)
+	if err != nil {
+		return fmt.Errorf("open input csv: %w", err)
+	}
+
+	// Read raw records rather than through ReadAll, which would cut long
+	// rows to the header width.
+	grid, err := newReader(in, opts).ReadAll()
+	if err != nil {
+		return fmt.Errorf("read csv: %w", err)
+	}
+	if len(grid) == 0 {
+		return fmt.Errorf("read headers: %w", io.EOF)
+	}
+
+	width := 0
+	for _, row := range grid {
+		width = max(width, len(row))
+	}
+
+	out := make([][]string, width)
+	for j := range out {
+		out[j] = make([]string, len(grid))
+		for i, row := range grid {
+			if j < len(row) {
+				out[j][i] = row[j]
+			}
+		}
+	}
+
+	return WriteRowsToWriter(w, out[0], out[1:], opts)
+}
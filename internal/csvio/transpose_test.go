@@ -0,0 +1,37 @@
+package csvio
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTransposeFile(t *testing.T) {
+	// 3 rows x 4 columns in, 4 rows x 3 columns out.
+	in := writeTemp(t, "in.csv", "key,a,b,c\nx,1,2,3\ny,4,5,6\n")
+	out := filepath.Join(t.TempDir(), "out.csv")
+
+	if err := TransposeFile(in, out, Options{}); err != nil {
+		t.Fatalf("TransposeFile: %v", err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "key,x,y\na,1,4\nb,2,5\nc,3,6\n"; string(got) != want {
+		t.Fatalf("output = %q, want %q", got, want)
+	}
+}
+
+func TestTransposeReader_Jagged(t *testing.T) {
+	var out bytes.Buffer
+	if err := TransposeReader(strings.NewReader("k,v\nhost,db1,db2\nport\n"), &out, Options{}); err != nil {
+		t.Fatalf("TransposeReader: %v", err)
+	}
+	if want := "k,host,port\nv,db1,\n,db2,\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,125 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements trimming leading and trailing whitespace from cells.
+// It follows the same streaming pattern as NullifyFile.
+package csvio
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TrimOptions controls TrimFile.
+type TrimOptions struct {
+	// Options controls how the input is parsed and the output delimiter.
+	Options
+
+	// Headers also trims the header row. Off by default so downstream
+	// schemas that expect the exact original names keep working.
+	Headers bool
+
+	// Columns restricts trimming to the named columns (case-insensitive).
+	// Empty means every column.
+	Columns []string
+}
+
+// TrimStats summarizes a trim.
+//
+//   - RowsRead counts data rows read (header excluded).
+//   - CellsTrimmed counts data cells whose value changed.
+type TrimStats struct {
+	RowsRead     int
+	CellsTrimmed int
+}
+
+// TrimFile writes inputPath to outputPath with strings.TrimSpace applied to
+// every cell in the selected columns.
+func TrimFile(inputPath, outputPath string, opts TrimOptions) (TrimStats, error) {
+	in, err := OpenInputWithOptions(inputPath, opts.Options)
+	if err != nil {
+		return TrimStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	out, err := CreateOutput(outputPath)
+	if err != nil {
+		return TrimStats{}, fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	return TrimReader(in, out, opts)
+}
+
+// TrimReader is TrimFile for an already-open input and output, e.g. when
+// writing to stdout. r is raw input; opts.Encoding is applied.
+func TrimReader(r io.Reader, w io.Writer, opts TrimOptions) (TrimStats, error) {
+	in, err := inputReader(r, opts.Options)
+	if err != nil {
+		return TrimStats{}, fmt.Errorf("open input csv: %w", err)
+	}
+
+	headers, rows, err := readHeader(newReader(in, opts.Options), opts.Options)
+	if err != nil {
+		return TrimStats{}, err
+	}
+
+	cols, err := ColumnIndices(headers, opts.Columns)
+	if err != nil {
+		return TrimStats{}, err
+	}
+	if len(opts.Columns) == 0 {
+		for i := range headers {
+			cols = append(cols, i)
+		}
+	}
+
+	// Column lookup above uses the original names; trim afterwards.
+	out := headers
+	if opts.Headers {
+		out = make([]string, len(headers))
+		for i, h := range headers {
+			out[i] = strings.TrimSpace(h)
+		}
+	}
+
+	cw := newWriter(w, opts.Options)
+	defer cw.Flush()
+
+	if err := cw.Write(out); err != nil {
+		return TrimStats{}, fmt.Errorf("write headers: %w", err)
+	}
+
+	stats := TrimStats{}
+	for {
+		rec, err := rows.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("read row: %w", err)
+		}
+		stats.RowsRead++
+
+		rec = normalizeRow(rec, len(headers))
+		for _, c := range cols {
+			if t := strings.TrimSpace(rec[c]); t != rec[c] {
+				rec[c] = t
+				stats.CellsTrimmed++
+			}
+		}
+
+		if err := cw.Write(rec); err != nil {
+			return stats, fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return stats, fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,49 @@
+package csvio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTrimReader(t *testing.T) {
+	// csv.Writer quotes fields with leading spaces, so untrimmed cells come
+	// back quoted.
+	const input = " name ,city\n Ann ,\tAlbany \nBob,Troy\n"
+
+	tests := []struct {
+		name    string
+		opts    TrimOptions
+		want    string
+		trimmed int
+	}{
+		{
+			name:    "all columns",
+			opts:    TrimOptions{},
+			want:    "\" name \",city\nAnn,Albany\nBob,Troy\n",
+			trimmed: 2,
+		},
+		{
+			name:    "headers and selected columns",
+			opts:    TrimOptions{Headers: true, Columns: []string{"city"}},
+			want:    "name,city\n\" Ann \",Albany\nBob,Troy\n",
+			trimmed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			stats, err := TrimReader(strings.NewReader(input), &out, tt.opts)
+			if err != nil {
+				t.Fatalf("TrimReader: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("output = %q, want %q", out.String(), tt.want)
+			}
+			if stats.RowsRead != 2 || stats.CellsTrimmed != tt.trimmed {
+				t.Fatalf("stats = %+v", stats)
+			}
+		})
+	}
+}
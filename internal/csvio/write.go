@@ -0,0 +1,51 @@
+// Package csvio contains CSV-specific I/O helpers used by the df CLI.
+//
+// This file implements the inverse of ReadAll: writing a header and a set of
+// rows already held in memory.
+package csvio
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteRows writes headers followed by rows to path. The path "-" means
+// standard output, and a ".gz" path is compressed. Errors from closing the
+// file are reported, so a failed final write is never lost.
+func WriteRows(path string, headers []string, rows [][]string, opts Options) (err error) {
+	out, err := CreateOutput(path)
+	if err != nil {
+		return fmt.Errorf("create output csv: %w", err)
+	}
+	defer func() {
+		if cerr := out.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("close output csv: %w", cerr)
+		}
+	}()
+
+	return WriteRowsToWriter(out, headers, rows, opts)
+}
+
+// WriteRowsToWriter is WriteRows for an already-open destination. Only the
+// delimiter in opts applies.
+func WriteRowsToWriter(w io.Writer, headers []string, rows [][]string, opts Options) error {
+	// csv.Writer buffers output; Flush is required to surface write errors.
+	cw := newWriter(w, opts)
+	defer cw.Flush()
+
+	if err := cw.Write(headers); err != nil {
+		return fmt.Errorf("write headers: %w", err)
+	}
+	for _, row := range rows {
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush output csv: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package csvio
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteRowsToWriter(t *testing.T) {
+	var buf bytes.Buffer
+	rows := [][]string{{"Ann", "a;b"}, {"Bob", ""}}
+
+	if err := WriteRowsToWriter(&buf, []string{"name", "note"}, rows, Options{Delimiter: ';'}); err != nil {
+		t.Fatalf("WriteRowsToWriter: %v", err)
+	}
+
+	want := "name;note\nAnn;\"a;b\"\nBob;\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteRowsToWriter_WriteError(t *testing.T) {
+	if err := WriteRowsToWriter(errWriter{}, []string{"n"}, [][]string{{"1"}}, Options{}); err == nil {
+		t.Fatalf("expected error from failing writer")
+	}
+}
+
+func TestWriteRows_RoundTrip(t *testing.T) {
+	for _, name := range []string{"out.csv", "out.csv.gz"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			rows := [][]string{{"Ann", "12207"}, {"Bob", ""}}
+
+			if err := WriteRows(path, []string{"name", "zip"}, rows, Options{}); err != nil {
+				t.Fatalf("WriteRows: %v", err)
+			}
+
+			headers, got, err := ReadAll(path, Options{})
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+			if len(headers) != 2 || headers[0] != "name" || headers[1] != "zip" {
+				t.Fatalf("headers = %q", headers)
+			}
+			if len(got) != 2 || got[0][1] != "12207" || got[1][0] != "Bob" {
+				t.Fatalf("rows = %q", got)
+			}
+		})
+	}
+}
+
+func TestWriteRows_BadPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "out.csv")
+	if err := WriteRows(path, []string{"n"}, nil, Options{}); err == nil {
+		t.Fatalf("expected error for unwritable path")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("unexpected file at %s", path)
+	}
+}
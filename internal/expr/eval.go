@@ -0,0 +1,421 @@
+// Package expr implements the small expression language behind "df eval".
+//
+// An expression combines column references and literals with +, -, * and /,
+// and parentheses:
+//
+//	first_name + " " + last_name
+//	clicks / impressions * 100
+//	`unit price` * qty
+//
+// The language is intentionally narrow: no conditionals, no functions, no
+// comparisons. The parser is a plain recursive descent over this grammar:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | string | name | "`" any text "`" | "(" expr ")"
+//
+// Every value is text that may also be a number. A column value or number
+// literal is a number when it parses as a finite float64; a quoted string
+// ("..." or '...') is always text. "+" adds when both sides are numbers and
+// concatenates when either side is text: a quoted string, the result of a
+// concatenation, or a non-blank value that is not a number. A blank value
+// (a NULL cell) is neither, so "a + 1" with an empty a is an error, as it is
+// for the other operators, which require numbers on both sides.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrEval is wrapped by every error Eval returns, so callers can tell a bad
+// row (e.g. division by zero) from a bad expression.
+var ErrEval = errors.New("cannot evaluate")
+
+// Value is the result of evaluating an expression or any part of it.
+type Value struct {
+	text  string
+	num   float64
+	isNum bool
+	isStr bool // a quoted string or a concatenation, text even when blank
+}
+
+// String returns the value as it is written to a cell. Values read from a
+// column or literal keep their original text, so "007" stays "007" unless
+// arithmetic was applied to it.
+func (v Value) String() string {
+	return v.text
+}
+
+// Number returns the numeric value and whether the value is a number.
+func (v Value) Number() (float64, bool) {
+	return v.num, v.isNum
+}
+
+// textValue returns s as a Value, numeric if it parses as a finite number.
+func textValue(s string) Value {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return Value{text: s}
+	}
+	return Value{text: s, num: f, isNum: true}
+}
+
+// strValue returns s as a Value that is always text.
+func strValue(s string) Value {
+	return Value{text: s, isStr: true}
+}
+
+// isText reports whether v makes "+" concatenate. Blank values do not, so
+// a NULL cell cannot turn an addition into a concatenation.
+func (v Value) isText() bool {
+	return v.isStr || !v.isNum && strings.TrimSpace(v.text) != ""
+}
+
+// numValue returns the computed number f as a Value.
+func numValue(f float64) Value {
+	return Value{text: strconv.FormatFloat(f, 'f', -1, 64), num: f, isNum: true}
+}
+
+// Expr is a parsed expression, safe to evaluate repeatedly.
+type Expr struct {
+	src  string
+	root node
+	cols []string
+}
+
+// Parse parses src. Errors carry the byte offset of the problem.
+func Parse(src string) (*Expr, error) {
+	p := &parser{src: src}
+	p.next()
+
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+
+	e := &Expr{src: src, root: root}
+	seen := make(map[string]bool)
+	walk(root, func(n node) {
+		if c, ok := n.(colRef); ok && !seen[string(c)] {
+			seen[string(c)] = true
+			e.cols = append(e.cols, string(c))
+		}
+	})
+	return e, nil
+}
+
+// String returns the source the expression was parsed from.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Columns returns the column names the expression references, in order of
+// first use, each once.
+func (e *Expr) Columns() []string {
+	return e.cols
+}
+
+// Eval evaluates the expression. col returns the value of the named column
+// for the current row; it is only called with names from Columns.
+func (e *Expr) Eval(col func(name string) string) (Value, error) {
+	return e.root.eval(col)
+}
+
+// node is one node of the syntax tree.
+type node interface {
+	eval(col func(string) string) (Value, error)
+}
+
+type (
+	literal Value
+	colRef  string
+	neg     struct{ x node }
+	binary  struct {
+		op   byte
+		l, r node
+	}
+)
+
+func (n literal) eval(func(string) string) (Value, error) {
+	return Value(n), nil
+}
+
+func (n colRef) eval(col func(string) string) (Value, error) {
+	return textValue(col(string(n))), nil
+}
+
+func (n neg) eval(col func(string) string) (Value, error) {
+	v, err := n.x.eval(col)
+	if err != nil {
+		return Value{}, err
+	}
+	if !v.isNum {
+		return Value{}, fmt.Errorf("%w: -%q is not a number", ErrEval, v.text)
+	}
+	return numValue(-v.num), nil
+}
+
+func (n binary) eval(col func(string) string) (Value, error) {
+	l, err := n.l.eval(col)
+	if err != nil {
+		return Value{}, err
+	}
+	r, err := n.r.eval(col)
+	if err != nil {
+		return Value{}, err
+	}
+
+	if !l.isNum || !r.isNum {
+		if n.op == '+' && (l.isText() || r.isText()) {
+			return strValue(l.text + r.text), nil
+		}
+		return Value{}, fmt.Errorf("%w: %q %c %q needs two numbers", ErrEval, l.text, n.op, r.text)
+	}
+
+	switch n.op {
+	case '+':
+		return numValue(l.num + r.num), nil
+	case '-':
+		return numValue(l.num - r.num), nil
+	case '*':
+		return numValue(l.num * r.num), nil
+	default:
+		if r.num == 0 {
+			return Value{}, fmt.Errorf("%w: division by zero", ErrEval)
+		}
+		return numValue(l.num / r.num), nil
+	}
+}
+
+// walk calls fn for n and every node below it, depth first.
+func walk(n node, fn func(node)) {
+	fn(n)
+	switch n := n.(type) {
+	case neg:
+		walk(n.x, fn)
+	case binary:
+		walk(n.l, fn)
+		walk(n.r, fn)
+	}
+}
+
+// tokKind classifies a token.
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNum
+	tokStr
+	tokName
+	tokOp // one of + - * / ( )
+)
+
+type token struct {
+	kind tokKind
+	text string // the operator, or the number, string or name without quotes
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// parser holds the state of one Parse call: the source, the read offset,
+// the current token, and the first lexing error.
+type parser struct {
+	src string
+	off int
+	tok token
+	err error
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("expr: %s at offset %d", fmt.Sprintf(format, args...), p.tok.pos)
+}
+
+// next advances to the next token. A lexing error is recorded in p.err and
+// reported by the parse function that looks at the token.
+func (p *parser) next() {
+	for p.off < len(p.src) && (p.src[p.off] == ' ' || p.src[p.off] == '\t') {
+		p.off++
+	}
+	start := p.off
+	if p.off >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+
+	c := p.src[p.off]
+	switch {
+	case strings.IndexByte("+-*/()", c) >= 0:
+		p.off++
+		p.tok = token{kind: tokOp, text: string(c), pos: start}
+
+	case c == '"' || c == '\'':
+		var b strings.Builder
+		p.off++
+		for {
+			if p.off >= len(p.src) {
+				p.tok = token{pos: start}
+				p.err = fmt.Errorf("expr: unterminated string at offset %d", start)
+				return
+			}
+			ch := p.src[p.off]
+			p.off++
+			if ch == c {
+				break
+			}
+			if ch == '\\' && p.off < len(p.src) {
+				ch = p.src[p.off]
+				p.off++
+			}
+			b.WriteByte(ch)
+		}
+		p.tok = token{kind: tokStr, text: b.String(), pos: start}
+
+	case c == '`':
+		end := strings.IndexByte(p.src[p.off+1:], '`')
+		if end < 0 {
+			p.tok = token{pos: start}
+			p.err = fmt.Errorf("expr: unterminated `column name` at offset %d", start)
+			return
+		}
+		p.tok = token{kind: tokName, text: p.src[p.off+1 : p.off+1+end], pos: start}
+		p.off += end + 2
+
+	case c >= '0' && c <= '9' || c == '.':
+		for p.off < len(p.src) && (isDigit(p.src[p.off]) || p.src[p.off] == '.') {
+			p.off++
+		}
+		p.tok = token{kind: tokNum, text: p.src[start:p.off], pos: start}
+
+	case isNameStart(c):
+		for p.off < len(p.src) && (isNameStart(p.src[p.off]) || isDigit(p.src[p.off])) {
+			p.off++
+		}
+		p.tok = token{kind: tokName, text: p.src[start:p.off], pos: start}
+
+	default:
+		p.tok = token{pos: start}
+		p.err = fmt.Errorf("expr: unexpected character %q at offset %d", c, start)
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isNameStart reports whether c may start a bare column name. Non-ASCII
+// bytes are allowed so names such as "prénom" need no backquotes.
+func isNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func (p *parser) isOp(ops string) bool {
+	return p.tok.kind == tokOp && strings.Contains(ops, p.tok.text)
+}
+
+func (p *parser) parseExpr() (node, error) {
+	l, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+-") {
+		op := p.tok.text[0]
+		p.next()
+		r, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		l = binary{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseTerm() (node, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*/") {
+		op := p.tok.text[0]
+		p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = binary{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOp("-") {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return neg{x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	tok := p.tok
+	switch {
+	case tok.kind == tokNum:
+		v := textValue(tok.text)
+		if !v.isNum {
+			return nil, p.errorf("bad number %q", tok.text)
+		}
+		p.next()
+		return literal(v), nil
+
+	case tok.kind == tokStr:
+		p.next()
+		return literal(strValue(tok.text)), nil
+
+	case tok.kind == tokName:
+		if tok.text == "" {
+			return nil, p.errorf("empty column name")
+		}
+		p.next()
+		return colRef(tok.text), nil
+
+	case p.isOp("("):
+		p.next()
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOp(")") {
+			if p.err != nil {
+				return nil, p.err
+			}
+			return nil, p.errorf("expected ), got %s", p.tok)
+		}
+		p.next()
+		return x, nil
+	}
+
+	return nil, p.errorf("expected a value, got %s", tok)
+}
@@ -0,0 +1,110 @@
+package expr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	row := map[string]string{
+		"first_name":  "Ann",
+		"last_name":   "Lee",
+		"clicks":      "3",
+		"impressions": "12",
+		"unit price":  "2.50",
+		"zip":         "007",
+		"name":        "NaN",
+		"empty":       "",
+	}
+	col := func(name string) string { return row[name] }
+
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`first_name + " " + last_name`, "Ann Lee"},
+		{`clicks / impressions`, "0.25"},
+		{`clicks / impressions * 100`, "25"},
+		{`1 + 2 * 3`, "7"},
+		{`(1 + 2) * 3`, "9"},
+		{`10 - 4 - 3`, "3"},
+		{`-clicks + 1`, "-2"},
+		{"`unit price` * clicks", "7.5"},
+		{`zip`, "007"},
+		{`zip + 1`, "8"},
+		{`clicks + "1"`, "31"},
+		{`'it\'s ' + name`, "it's NaN"},
+		{`name + empty + "!"`, "NaN!"},
+		{`empty + " " + last_name`, " Lee"},
+		{`empty + ""`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := Parse(tt.src)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			v, err := e.Eval(col)
+			if err != nil {
+				t.Fatalf("Eval: %v", err)
+			}
+			if v.String() != tt.want {
+				t.Fatalf("got %q, want %q", v.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEval_Errors(t *testing.T) {
+	row := map[string]string{"a": "1", "zero": "0", "s": "x", "empty": ""}
+	col := func(name string) string { return row[name] }
+
+	for _, src := range []string{`a / zero`, `s * 2`, `-s`, `a - empty`, `empty + a * 2`, `empty + empty`} {
+		e, err := Parse(src)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", src, err)
+		}
+		if _, err := e.Eval(col); !errors.Is(err, ErrEval) {
+			t.Fatalf("Eval(%q) err = %v, want ErrEval", src, err)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{``, "expected a value, got end of expression at offset 0"},
+		{`a +`, "expected a value, got end of expression at offset 3"},
+		{`(a + b`, "expected ), got end of expression at offset 6"},
+		{`a b`, `unexpected "b" at offset 2`},
+		{`a $ b`, `unexpected character '$' at offset 2`},
+		{`a + "b`, "unterminated string at offset 4"},
+		{"`a", "unterminated `column name` at offset 0"},
+		{"``", "empty column name at offset 0"},
+		{`1.2.3`, `bad number "1.2.3" at offset 0`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	e, err := Parse("b + a * (b - `c d`) + 1")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, want := e.Columns(), []string{"b", "a", "c d"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Columns() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,30 @@
+package nulls
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// LoadPolicyFile reads a Policy from the JSON file at path, such as a
+// nullpolicy.json kept next to an import job so every cycle runs with the
+// same rules. The schema is documented on Policy.
+//
+// Unknown keys are rejected so a misspelled rule fails loudly instead of
+// being silently ignored.
+func LoadPolicyFile(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("read policy file: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var p Policy
+	if err := dec.Decode(&p); err != nil {
+		return Policy{}, fmt.Errorf("parse policy file %s: %w", path, err)
+	}
+	return p, nil
+}
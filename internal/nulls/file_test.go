@@ -0,0 +1,57 @@
+package nulls
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadPolicyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nullpolicy.json")
+	content := `{"treat_blanks": true, "treat_dash": true, "custom_sentinels": ["TBD"]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	got, err := LoadPolicyFile(path)
+	if err != nil {
+		t.Fatalf("LoadPolicyFile: %v", err)
+	}
+	want := Policy{TreatBlanks: true, TreatDash: true, CustomSentinels: []string{"TBD"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("policy = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadPolicyFile_UnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nullpolicy.json")
+	if err := os.WriteFile(path, []byte(`{"treat_nas": true}`), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	if _, err := LoadPolicyFile(path); err == nil {
+		t.Fatalf("expected error for unknown key")
+	}
+}
+
+func TestPolicy_JSONRoundTrip(t *testing.T) {
+	p := Policy{TreatNA: true, TreatNone: true, CustomSentinels: []string{"N/D"}}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `{"treat_na":true,"treat_none":true,"custom_sentinels":["N/D"]}`; string(data) != want {
+		t.Fatalf("json = %s, want %s", data, want)
+	}
+
+	var got Policy
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(got, p) {
+		t.Fatalf("round trip = %+v, want %+v", got, p)
+	}
+}
@@ -21,33 +21,97 @@ import "strings"
 //
 // The policy does not define *how* NULLs are represented in output; it only
 // answers the question: "Should this input value be considered NULL?"
+//
+// Policies can also be stored as JSON (see LoadPolicyFile). Every key is
+// optional and a missing key means false (or no sentinels):
+//
+//	{
+//	  "treat_blanks": true,
+//	  "treat_na": true,
+//	  "treat_null_literal": false,
+//	  "treat_dash": false,
+//	  "treat_none": false,
+//	  "custom_sentinels": ["TBD", "UNKNOWN"]
+//	}
 type Policy struct {
 	// TreatBlanks controls whether empty or whitespace-only values are treated
 	// as NULL. When enabled, values like "", " ", and "\t" are considered NULL.
-	TreatBlanks bool
+	TreatBlanks bool `json:"treat_blanks,omitempty"`
 
 	// TreatNA controls whether common "not available" markers are treated as NULL.
 	// Matching is case-insensitive and currently includes:
 	//   - "NA"
 	//   - "N/A"
-	TreatNA bool
+	TreatNA bool `json:"treat_na,omitempty"`
 
 	// TreatNULLLiteral controls whether the literal string "NULL" should be
 	// treated as NULL. Matching is case-insensitive.
-	TreatNULLLiteral bool
+	TreatNULLLiteral bool `json:"treat_null_literal,omitempty"`
+
+	// TreatDash controls whether a bare "-" or "--", as written by many legacy
+	// mainframe exports, is treated as NULL. Values that merely start with a
+	// dash, such as "-5", are not affected.
+	TreatDash bool `json:"treat_dash,omitempty"`
+
+	// TreatNone controls whether Python's "None" (from str(value) during
+	// export) is treated as NULL. Matching is case-insensitive.
+	//
+	// TreatNone and TreatNULLLiteral are independent: some datasets use
+	// "NULL" and "None" to mean different things, so enabling one never
+	// implies the other.
+	TreatNone bool `json:"treat_none,omitempty"`
+
+	// CustomSentinels lists additional markers, such as "N/D", "TBD" or
+	// "UNKNOWN", that should be treated as NULL. Matching is case-insensitive
+	// and ignores surrounding whitespace, like the built-in markers.
+	CustomSentinels []string `json:"custom_sentinels,omitempty"`
 }
 
+// AddSentinel appends value to CustomSentinels and returns p so calls can be
+// chained:
+//
+//	p := (&nulls.Policy{TreatBlanks: true}).AddSentinel("TBD").AddSentinel("UNKNOWN")
+func (p *Policy) AddSentinel(value string) *Policy {
+	p.CustomSentinels = append(p.CustomSentinels, value)
+	return p
+}
+
+// Reason codes returned by IsNullWithReason, one per rule that can match.
+// Custom sentinels are reported as ReasonSentinelPrefix followed by the
+// sentinel as configured, e.g. "sentinel:TBD".
+const (
+	ReasonBlank          = "blank"
+	ReasonNA             = "na"
+	ReasonNULLLiteral    = "null_literal"
+	ReasonDash           = "dash"
+	ReasonNone           = "none"
+	ReasonSentinelPrefix = "sentinel:"
+)
+
 // IsNull reports whether the input string s should be treated as NULL under
-// the current policy.
+// the current policy. It is IsNullWithReason without the reason.
+func (p Policy) IsNull(s string) bool {
+	isNull, _ := p.IsNullWithReason(s)
+	return isNull
+}
+
+// IsNullWithReason reports whether the input string s should be treated as
+// NULL under the current policy, and which rule matched: ReasonBlank,
+// ReasonNA, ReasonNULLLiteral, ReasonDash, ReasonNone, or
+// ReasonSentinelPrefix plus the sentinel.
+// The reason is "" when s is not NULL.
 //
-// The check is performed in the following order:
+// The check is performed in the following order, and the first match wins:
 //
 //  1. Trim surrounding whitespace.
 //  2. If TreatBlanks is enabled and the trimmed value is empty, return true.
 //  3. Convert the trimmed value to upper case.
 //  4. If TreatNA is enabled and the value matches "NA" or "N/A", return true.
 //  5. If TreatNULLLiteral is enabled and the value matches "NULL", return true.
-//  6. Otherwise, return false.
+//  6. If TreatDash is enabled and the value is "-" or "--", return true.
+//  7. If TreatNone is enabled and the value matches "NONE", return true.
+//  8. If the value matches any of CustomSentinels, return true.
+//  9. Otherwise, return false.
 //
 // Important notes:
 //
@@ -58,13 +122,13 @@ type Policy struct {
 //
 // This function is intentionally conservative: only explicitly enabled markers
 // are treated as NULL to avoid accidental data loss.
-func (p Policy) IsNull(s string) bool {
+func (p Policy) IsNullWithReason(s string) (bool, string) {
 	// Normalize whitespace before applying any rules.
 	trimmed := strings.TrimSpace(s)
 
 	// Empty or whitespace-only values.
 	if p.TreatBlanks && trimmed == "" {
-		return true
+		return true, ReasonBlank
 	}
 
 	// Case-insensitive comparisons for sentinel values.
@@ -72,15 +136,33 @@ func (p Policy) IsNull(s string) bool {
 
 	if p.TreatNA {
 		if upper == "NA" || upper == "N/A" {
-			return true
+			return true, ReasonNA
 		}
 	}
 
 	if p.TreatNULLLiteral {
 		if upper == "NULL" {
-			return true
+			return true, ReasonNULLLiteral
+		}
+	}
+
+	if p.TreatDash {
+		if upper == "-" || upper == "--" {
+			return true, ReasonDash
+		}
+	}
+
+	if p.TreatNone {
+		if upper == "NONE" {
+			return true, ReasonNone
+		}
+	}
+
+	for _, sentinel := range p.CustomSentinels {
+		if strings.EqualFold(trimmed, strings.TrimSpace(sentinel)) {
+			return true, ReasonSentinelPrefix + sentinel
 		}
 	}
 
-	return false
+	return false, ""
 }
@@ -0,0 +1,123 @@
+package nulls
+
+import "testing"
+
+func BenchmarkIsNull(b *testing.B) {
+	p := Policy{TreatBlanks: true, TreatNA: true, TreatNULLLiteral: true}
+	values := []string{"", "  ", "NA", "n/a", "NULL", "Albany", "ben@example.com", "5185551234"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = p.IsNull(values[i%len(values)])
+	}
+}
+
+func TestPolicy_AddSentinel(t *testing.T) {
+	p := (&Policy{}).AddSentinel("UNKNOWN").AddSentinel("N/D")
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "unknown", want: true},
+		{value: " UNKNOWN ", want: true},
+		{value: "n/d", want: true},
+		{value: "known", want: false},
+		{value: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := p.IsNull(tt.value); got != tt.want {
+			t.Errorf("IsNull(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPolicy_IsNullWithReason(t *testing.T) {
+	p := Policy{
+		TreatBlanks:      true,
+		TreatNA:          true,
+		TreatNULLLiteral: true,
+		TreatDash:        true,
+		TreatNone:        true,
+		CustomSentinels:  []string{"TBD"},
+	}
+
+	tests := []struct {
+		value      string
+		wantNull   bool
+		wantReason string
+	}{
+		{value: "", wantNull: true, wantReason: ReasonBlank},
+		{value: " \t", wantNull: true, wantReason: ReasonBlank},
+		{value: "NA", wantNull: true, wantReason: ReasonNA},
+		{value: "n/a", wantNull: true, wantReason: ReasonNA},
+		{value: "Null", wantNull: true, wantReason: ReasonNULLLiteral},
+		{value: "--", wantNull: true, wantReason: ReasonDash},
+		{value: "None", wantNull: true, wantReason: ReasonNone},
+		{value: "tbd", wantNull: true, wantReason: "sentinel:TBD"},
+		{value: "Albany", wantNull: false, wantReason: ""},
+	}
+
+	for _, tt := range tests {
+		gotNull, gotReason := p.IsNullWithReason(tt.value)
+		if gotNull != tt.wantNull || gotReason != tt.wantReason {
+			t.Errorf("IsNullWithReason(%q) = (%v, %q), want (%v, %q)",
+				tt.value, gotNull, gotReason, tt.wantNull, tt.wantReason)
+		}
+		if p.IsNull(tt.value) != tt.wantNull {
+			t.Errorf("IsNull(%q) disagrees with IsNullWithReason", tt.value)
+		}
+	}
+}
+
+func TestPolicy_TreatDash(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{name: "single dash", value: "-", want: true},
+		{name: "double dash", value: "--", want: true},
+		{name: "surrounding spaces", value: "  -- ", want: true},
+		{name: "triple dash", value: "---", want: false},
+		{name: "negative number", value: "-5", want: false},
+	}
+
+	p := Policy{TreatDash: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.IsNull(tt.value); got != tt.want {
+				t.Fatalf("IsNull(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	if (Policy{}).IsNull("-") {
+		t.Fatalf("dash treated as NULL with TreatDash disabled")
+	}
+}
+
+func TestPolicy_TreatNoneIndependentOfNULLLiteral(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		value  string
+		want   bool
+	}{
+		{name: "none lower", policy: Policy{TreatNone: true}, value: "none", want: true},
+		{name: "none upper", policy: Policy{TreatNone: true}, value: "NONE", want: true},
+		{name: "none title", policy: Policy{TreatNone: true}, value: " None ", want: true},
+		{name: "none ignores NULL", policy: Policy{TreatNone: true}, value: "NULL", want: false},
+		{name: "NULL ignores none", policy: Policy{TreatNULLLiteral: true}, value: "None", want: false},
+		{name: "not a prefix match", policy: Policy{TreatNone: true}, value: "Nonesuch", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.IsNull(tt.value); got != tt.want {
+				t.Fatalf("IsNull(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
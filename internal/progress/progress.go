@@ -0,0 +1,102 @@
+// Package progress reports the progress of long-running, row-streaming
+// operations, such as nullifying a multi-million-row file.
+//
+// A ProgressReporter owns the timing and throttling: callers report after
+// every row and the reporter decides when a line is due. On a terminal each
+// line overwrites the previous one with a carriage return; otherwise (e.g.
+// stderr redirected to a log) every line is kept.
+//
+// All methods are safe to call on a nil *ProgressReporter and do nothing, so
+// operations can report unconditionally and callers enable progress only
+// when asked.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultEvery is the default number of rows between progress lines.
+const DefaultEvery = 100_000
+
+// Counter is a named running total shown after the row count, e.g.
+// "cells nullified".
+type Counter struct {
+	Name  string
+	Value int
+}
+
+// ProgressReporter prints a progress line every Every rows.
+type ProgressReporter struct {
+	w         io.Writer
+	every     int
+	overwrite bool
+
+	start time.Time
+	next  int
+
+	// open reports that an overwritten line is on screen without a trailing
+	// newline, which Done must end.
+	open bool
+
+	// now is time.Now, replaceable in tests.
+	now func() time.Time
+}
+
+// New returns a reporter writing to w every every rows, starting the clock
+// now. every <= 0 means DefaultEvery. overwrite selects the terminal style,
+// where each line replaces the previous one.
+func New(w io.Writer, every int, overwrite bool) *ProgressReporter {
+	if every <= 0 {
+		every = DefaultEvery
+	}
+	p := &ProgressReporter{w: w, every: every, overwrite: overwrite, next: every, now: time.Now}
+	p.start = p.now()
+	return p
+}
+
+// Update reports that rows rows have been processed so far, with optional
+// counters. A line is printed each time rows reaches a multiple of the
+// reporting interval; other calls only compare two integers.
+func (p *ProgressReporter) Update(rows int, counters ...Counter) {
+	if p == nil || rows < p.next {
+		return
+	}
+	p.next = (rows/p.every + 1) * p.every
+	p.print(rows, counters)
+}
+
+// Done prints a final line with the totals and ends the line on a terminal.
+// Call it once, after the operation completes successfully.
+func (p *ProgressReporter) Done(rows int, counters ...Counter) {
+	if p == nil {
+		return
+	}
+	p.print(rows, counters)
+	if p.open {
+		fmt.Fprintln(p.w)
+		p.open = false
+	}
+}
+
+// print writes one progress line. Write errors are ignored: progress is
+// advisory and must never fail the operation it describes.
+func (p *ProgressReporter) print(rows int, counters []Counter) {
+	if p.overwrite {
+		// Return to column 0 and clear the old line, which may be longer.
+		fmt.Fprint(p.w, "\r\x1b[K")
+	}
+	fmt.Fprintf(p.w, "progress: %d rows", rows)
+	for _, c := range counters {
+		fmt.Fprintf(p.w, ", %d %s", c.Value, c.Name)
+	}
+	elapsed := p.now().Sub(p.start).Round(100 * time.Millisecond)
+	fmt.Fprintf(p.w, " (%s elapsed)", elapsed)
+
+	if p.overwrite {
+		p.open = true
+		return
+	}
+	fmt.Fprintln(p.w)
+}
@@ -0,0 +1,81 @@
+package progress
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a now func that advances by step on every call.
+func fakeClock(step time.Duration) func() time.Time {
+	t := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t = t.Add(step)
+		return t
+	}
+}
+
+func newTest(w *bytes.Buffer, every int, overwrite bool) *ProgressReporter {
+	p := New(w, every, overwrite)
+	p.now = fakeClock(time.Second)
+	p.start = p.now()
+	return p
+}
+
+func TestUpdate_Throttles(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTest(&buf, 2, false)
+
+	for rows := 1; rows <= 5; rows++ {
+		p.Update(rows, Counter{Name: "cells", Value: rows * 3})
+	}
+	p.Done(5, Counter{Name: "cells", Value: 15})
+
+	want := "progress: 2 rows, 6 cells (1s elapsed)\n" +
+		"progress: 4 rows, 12 cells (2s elapsed)\n" +
+		"progress: 5 rows, 15 cells (3s elapsed)\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestUpdate_SkippedRows(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTest(&buf, 10, false)
+
+	// Jumping past several intervals prints once, then waits for the next.
+	p.Update(25)
+	p.Update(29)
+	p.Update(30)
+
+	want := "progress: 25 rows (1s elapsed)\nprogress: 30 rows (2s elapsed)\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestOverwrite(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTest(&buf, 1, true)
+
+	p.Update(1)
+	p.Done(1)
+
+	want := "\r\x1b[Kprogress: 1 rows (1s elapsed)" +
+		"\r\x1b[Kprogress: 1 rows (2s elapsed)\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNilReporter(t *testing.T) {
+	var p *ProgressReporter
+	p.Update(DefaultEvery)
+	p.Done(DefaultEvery)
+}
+
+func TestNew_DefaultEvery(t *testing.T) {
+	if p := New(&bytes.Buffer{}, 0, false); p.every != DefaultEvery {
+		t.Fatalf("every = %d, want %d", p.every, DefaultEvery)
+	}
+}
@@ -0,0 +1,46 @@
+// Package render contains small, dependency-free helpers for rendering output in
+// a human-friendly way.
+//
+// This file holds the ANSI escape codes used to color the text table, and the
+// terminal check that decides whether coloring is appropriate.
+package render
+
+import (
+	"io"
+	"os"
+)
+
+// AnsiColor is an ANSI SGR escape sequence that styles the text after it.
+type AnsiColor string
+
+// Styles used by the text table renderer.
+const (
+	AnsiBold  AnsiColor = "\x1b[1m"
+	AnsiDim   AnsiColor = "\x1b[2m"
+	AnsiCyan  AnsiColor = "\x1b[36m"
+	AnsiReset AnsiColor = "\x1b[0m"
+)
+
+// Wrap returns s styled with c, followed by a reset so the style does not
+// leak into the text after it.
+func (c AnsiColor) Wrap(s string) string {
+	return string(c) + s + string(AnsiReset)
+}
+
+// IsTerminal reports whether w is a terminal (character device), so callers
+// can turn color off when output is redirected to a file or pipe.
+//
+// Only an *os.File can be a terminal; any other writer, such as a buffer,
+// reports false. Rather than depend on golang.org/x/term, the check uses the
+// file mode, which is accurate for the usual stdout cases.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
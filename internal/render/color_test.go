@@ -0,0 +1,64 @@
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnsiColor_Wrap(t *testing.T) {
+	if got, want := AnsiBold.Wrap("name"), "\x1b[1mname\x1b[0m"; got != want {
+		t.Fatalf("Wrap = %q, want %q", got, want)
+	}
+}
+
+func TestPrintTable_Color(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintTable(&buf, []string{"name", "zip"}, [][]string{{"Ann", ""}}, TableOptions{ColorEnabled: true})
+	if err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	want := []string{
+		AnsiBold.Wrap("name") + "  " + AnsiBold.Wrap("zip"),
+		AnsiCyan.Wrap("----  ---"),
+		"Ann   " + AnsiDim.Wrap("   "),
+		"",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %q, want %q", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestPrintTable_NoColorByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintTable(&buf, []string{"name"}, [][]string{{""}}, TableOptions{}); err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Fatalf("unexpected escape codes in %q", buf.String())
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Fatalf("buffer reported as terminal")
+	}
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	defer f.Close()
+	if IsTerminal(f) {
+		t.Fatalf("regular file reported as terminal")
+	}
+}
@@ -0,0 +1,67 @@
+// Package render contains small, dependency-free helpers for rendering output in
+// a human-friendly way.
+//
+// This file implements Confluence wiki markup output so previews can be pasted
+// straight into team documentation pages.
+package render
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PrintConfluenceTable writes headers and rows as a Confluence wiki markup
+// table:
+//
+//	|| first_name || email ||
+//	| Ben | ben@example.com |
+//
+// Cell values are written in full (MaxCellWidth is not applied) because the
+// output is meant for documentation rather than terminal viewing. Pipe
+// characters are escaped as `\|`, and empty cells are written as a single
+// space because Confluence collapses truly empty cells. If opts.ShowRowIndex
+// is set, a leading "#" column is included.
+//
+// The first write error is returned and stops further output.
+func PrintConfluenceTable(w io.Writer, headers []string, rows [][]string, opts TableOptions) error {
+	ew := &errWriter{w: w}
+
+	// Header row uses double pipes.
+	ew.print("||")
+	if opts.ShowRowIndex {
+		ew.print(" # ||")
+	}
+	for _, h := range headers {
+		ew.print(" " + confluenceEscape(h) + " ||")
+	}
+	ew.print("\n")
+
+	// Data rows use single pipes. Short rows are padded with empty cells.
+	for ri, row := range rows {
+		ew.print("|")
+		if opts.ShowRowIndex {
+			ew.print(" " + strconv.Itoa(opts.RowIndexStart+ri) + " |")
+		}
+		for ci := range headers {
+			ew.print(" " + confluenceEscape(cellAt(row, ci)) + " |")
+		}
+		ew.print("\n")
+	}
+
+	return ew.err
+}
+
+// confluenceEscape makes s safe to place inside a Confluence table cell.
+//
+// Pipes would otherwise start a new cell and newlines would end the row, so
+// pipes are backslash-escaped and newlines are flattened to spaces. Empty
+// values become a single space.
+func confluenceEscape(s string) string {
+	if s == "" {
+		return " "
+	}
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	return strings.ReplaceAll(s, "\n", " ")
+}
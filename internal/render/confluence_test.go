@@ -0,0 +1,35 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintConfluenceTable(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"name", "note"}
+	rows := [][]string{
+		{"Ann", "a|b"},
+		{"Bob", ""},
+	}
+
+	if err := PrintConfluenceTable(&buf, headers, rows, TableOptions{}); err != nil {
+		t.Fatalf("PrintConfluenceTable: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := []string{
+		"|| name || note ||",
+		`| Ann | a\|b |`,
+		"| Bob |   |",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d\n%s", len(lines), len(want), buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
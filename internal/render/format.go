@@ -0,0 +1,237 @@
+// Package render contains small, dependency-free helpers for rendering output in
+// a human-friendly way.
+//
+// This file defines the output formats PrintTable can produce besides the
+// default fixed-width text table.
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format names an output format for PrintTable.
+type Format string
+
+// Supported formats. The string values are what CLI users type after
+// --format.
+const (
+	FormatText       Format = "text"       // fixed-width terminal table (default)
+	FormatBox        Format = "box"        // text table with box-drawing borders
+	FormatJSON       Format = "json"       // JSON array of objects keyed by header
+	FormatJSONL      Format = "jsonl"      // one JSON object per line
+	FormatCSV        Format = "csv"        // comma-separated values
+	FormatTSV        Format = "tsv"        // tab-separated values
+	FormatMarkdown   Format = "markdown"   // GitHub-flavored Markdown table
+	FormatHTML       Format = "html"       // minimal <table> element
+	FormatConfluence Format = "confluence" // Confluence wiki markup
+	FormatVertical   Format = "vertical"   // one "key: value" block per row
+)
+
+// Formats lists every supported format in the order shown in help text.
+var Formats = []Format{
+	FormatText, FormatBox, FormatJSON, FormatJSONL, FormatCSV, FormatTSV,
+	FormatMarkdown, FormatHTML, FormatConfluence, FormatVertical,
+}
+
+// ParseFormat converts a user-supplied name into a Format.
+//
+// Matching is case-insensitive. "table" is accepted as an alias for
+// FormatText because that was the original name of the default output.
+func ParseFormat(s string) (Format, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "table" {
+		return FormatText, nil
+	}
+	for _, f := range Formats {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unknown format %q (want one of: %s)", s, strings.Join(names, ", "))
+}
+
+// PrintJSON writes rows as a JSON array of objects mapping header names to
+// cell values, one object per line, for piping into tools like jq. Keys
+// appear in header order (rather than Go's sorted map order) so output
+// mirrors the file.
+//
+// Each object is encoded and written as soon as it is built, so the output is
+// streamed rather than assembled in memory. PrintTable with FormatJSON calls
+// this function.
+func PrintJSON(w io.Writer, headers []string, rows [][]string) error {
+	ew := &errWriter{w: w}
+	ew.print("[")
+	for i, row := range rows {
+		obj, err := jsonObject(headers, row)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			ew.print(",")
+		}
+		ew.print("\n  " + obj)
+	}
+	if len(rows) > 0 {
+		ew.print("\n")
+	}
+	ew.print("]\n")
+	return ew.err
+}
+
+// printJSONL writes one JSON object per row, newline-delimited.
+func printJSONL(w io.Writer, headers []string, rows [][]string) error {
+	ew := &errWriter{w: w}
+	for _, row := range rows {
+		obj, err := jsonObject(headers, row)
+		if err != nil {
+			return err
+		}
+		ew.print(obj + "\n")
+	}
+	return ew.err
+}
+
+// jsonObject encodes a row as a single-line JSON object keyed by header, with
+// keys in header order.
+func jsonObject(headers []string, row []string) (string, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, h := range headers {
+		k, err := json.Marshal(h)
+		if err != nil {
+			return "", err
+		}
+		v, err := json.Marshal(cellAt(row, i))
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// PrintTSV writes headers and rows as tab-separated values for tools like awk
+// and cut. Unlike the text table, cells are never clipped, padded, or
+// prefixed with a row index. Cells containing tabs, quotes, or newlines are
+// quoted following the CSV convention. PrintTable with FormatTSV calls this
+// function.
+func PrintTSV(w io.Writer, headers []string, rows [][]string) error {
+	return printDelimited(w, headers, rows, '\t')
+}
+
+// printDelimited writes headers and rows with encoding/csv using comma as the
+// separator, so embedded separators, quotes, and newlines are quoted
+// correctly.
+func printDelimited(w io.Writer, headers []string, rows [][]string, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		rec := make([]string, len(headers))
+		for i := range headers {
+			rec[i] = cellAt(row, i)
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// printMarkdown writes a GitHub-flavored Markdown table. Pipes in cells are
+// escaped as `\|` and newlines are flattened to spaces so each row stays on
+// one line.
+func printMarkdown(w io.Writer, headers []string, rows [][]string, opts TableOptions) error {
+	ew := &errWriter{w: w}
+
+	writeRow := func(cells []string) {
+		ew.print("|")
+		for _, c := range cells {
+			ew.print(" " + markdownEscape(c) + " |")
+		}
+		ew.print("\n")
+	}
+
+	head := headers
+	if opts.ShowRowIndex {
+		head = append([]string{"#"}, headers...)
+	}
+	writeRow(head)
+
+	ew.print("|")
+	for range head {
+		ew.print("---|")
+	}
+	ew.print("\n")
+
+	for ri, row := range rows {
+		cells := make([]string, 0, len(head))
+		if opts.ShowRowIndex {
+			cells = append(cells, strconv.Itoa(opts.RowIndexStart+ri))
+		}
+		for i := range headers {
+			cells = append(cells, cellAt(row, i))
+		}
+		writeRow(cells)
+	}
+
+	return ew.err
+}
+
+// markdownEscape makes s safe inside a Markdown table cell.
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// printHTML writes a minimal HTML table with <thead> and <tbody>. All text is
+// HTML-escaped.
+func printHTML(w io.Writer, headers []string, rows [][]string, opts TableOptions) error {
+	ew := &errWriter{w: w}
+
+	ew.print("<table>\n<thead>\n<tr>")
+	if opts.ShowRowIndex {
+		ew.print("<th>#</th>")
+	}
+	for _, h := range headers {
+		ew.print("<th>" + html.EscapeString(h) + "</th>")
+	}
+	ew.print("</tr>\n</thead>\n<tbody>\n")
+
+	for ri, row := range rows {
+		ew.print("<tr>")
+		if opts.ShowRowIndex {
+			ew.print("<td>" + strconv.Itoa(opts.RowIndexStart+ri) + "</td>")
+		}
+		for i := range headers {
+			ew.print("<td>" + html.EscapeString(cellAt(row, i)) + "</td>")
+		}
+		ew.print("</tr>\n")
+	}
+
+	ew.print("</tbody>\n</table>\n")
+	return ew.err
+}
@@ -0,0 +1,219 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	fmtHeaders = []string{"name", "note"}
+	fmtRows    = [][]string{
+		{"Ann", `says "hi", | <b>`},
+		{"Bob"}, // short row: note is padded with ""
+	}
+	fmtWant = [][]string{
+		{"Ann", `says "hi", | <b>`},
+		{"Bob", ""},
+	}
+)
+
+func renderFormat(t *testing.T, f Format) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := PrintTable(&buf, fmtHeaders, fmtRows, TableOptions{Format: f}); err != nil {
+		t.Fatalf("PrintTable(%s): %v", f, err)
+	}
+	return buf.String()
+}
+
+func TestPrintTable_JSON(t *testing.T) {
+	var got []map[string]string
+	if err := json.Unmarshal([]byte(renderFormat(t, FormatJSON)), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	want := []map[string]string{
+		{"name": "Ann", "note": `says "hi", | <b>`},
+		{"name": "Bob", "note": ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestPrintJSON_KeyOrderAndEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintJSON(&buf, []string{"zip", "name"}, [][]string{{"12207", "Ann"}}); err != nil {
+		t.Fatalf("PrintJSON: %v", err)
+	}
+	if want := "[\n  {\"zip\":\"12207\",\"name\":\"Ann\"}\n]\n"; buf.String() != want {
+		t.Fatalf("output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := PrintJSON(&buf, []string{"zip"}, nil); err != nil {
+		t.Fatalf("PrintJSON: %v", err)
+	}
+	if want := "[]\n"; buf.String() != want {
+		t.Fatalf("empty output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintTable_JSONL(t *testing.T) {
+	sc := bufio.NewScanner(strings.NewReader(renderFormat(t, FormatJSONL)))
+	var got []map[string]string
+	for sc.Scan() {
+		var obj map[string]string
+		if err := json.Unmarshal(sc.Bytes(), &obj); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		got = append(got, obj)
+	}
+	if len(got) != 2 || got[1]["name"] != "Bob" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestPrintTable_Delimited(t *testing.T) {
+	for _, tt := range []struct {
+		format Format
+		comma  rune
+	}{
+		{FormatCSV, ','},
+		{FormatTSV, '\t'},
+	} {
+		t.Run(string(tt.format), func(t *testing.T) {
+			r := csv.NewReader(strings.NewReader(renderFormat(t, tt.format)))
+			r.Comma = tt.comma
+			got, err := r.ReadAll()
+			if err != nil {
+				t.Fatalf("csv parse: %v", err)
+			}
+			want := append([][]string{fmtHeaders}, fmtWant...)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPrintTSV(t *testing.T) {
+	long := strings.Repeat("x", 100)
+
+	var buf bytes.Buffer
+	err := PrintTSV(&buf, []string{"name", "note"}, [][]string{{"Ann", "a\tb"}, {"Bob", long}})
+	if err != nil {
+		t.Fatalf("PrintTSV: %v", err)
+	}
+	// No padding, clipping, or index; the embedded tab is quoted.
+	want := "name\tnote\nAnn\t\"a\tb\"\nBob\t" + long + "\n"
+	if buf.String() != want {
+		t.Fatalf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintTable_Markdown(t *testing.T) {
+	lines := strings.Split(strings.TrimSuffix(renderFormat(t, FormatMarkdown), "\n"), "\n")
+	want := []string{
+		"| name | note |",
+		"|---|---|",
+		`| Ann | says "hi", \| <b> |`,
+		"| Bob |  |",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Fatalf("got %q, want %q", lines, want)
+	}
+}
+
+func TestPrintTable_HTML(t *testing.T) {
+	out := renderFormat(t, FormatHTML)
+
+	// Well-formed markup with escaped content parses as XML.
+	dec := xml.NewDecoder(strings.NewReader(out))
+	var cells []string
+	inCell := false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("parse html: %v\n%s", err, out)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			inCell = tok.Name.Local == "td"
+			if inCell {
+				cells = append(cells, "")
+			}
+		case xml.CharData:
+			if inCell {
+				cells[len(cells)-1] += string(tok)
+			}
+		case xml.EndElement:
+			inCell = false
+		}
+	}
+
+	want := []string{"Ann", `says "hi", | <b>`, "Bob", ""}
+	if !reflect.DeepEqual(cells, want) {
+		t.Fatalf("got %q, want %q", cells, want)
+	}
+}
+
+func TestPrintTable_MarkdownAndHTMLRowIndex(t *testing.T) {
+	var md bytes.Buffer
+	err := PrintTable(&md, []string{"name"}, [][]string{{"Ann"}}, TableOptions{Format: FormatMarkdown, ShowRowIndex: true})
+	if err != nil {
+		t.Fatalf("PrintTable(markdown): %v", err)
+	}
+	if want := "| # | name |\n|---|---|\n| 0 | Ann |\n"; md.String() != want {
+		t.Fatalf("markdown = %q, want %q", md.String(), want)
+	}
+
+	var h bytes.Buffer
+	err = PrintTable(&h, []string{"name"}, [][]string{{"Ann"}}, TableOptions{Format: FormatHTML, ShowRowIndex: true})
+	if err != nil {
+		t.Fatalf("PrintTable(html): %v", err)
+	}
+	want := "<table>\n<thead>\n<tr><th>#</th><th>name</th></tr>\n</thead>\n" +
+		"<tbody>\n<tr><td>0</td><td>Ann</td></tr>\n</tbody>\n</table>\n"
+	if h.String() != want {
+		t.Fatalf("html = %q, want %q", h.String(), want)
+	}
+}
+
+func TestPrintTable_Confluence(t *testing.T) {
+	out := renderFormat(t, FormatConfluence)
+	if !strings.HasPrefix(out, "|| name || note ||\n") {
+		t.Fatalf("unexpected confluence output:\n%s", out)
+	}
+}
+
+func TestPrintTable_Text(t *testing.T) {
+	lines := strings.Split(strings.TrimSuffix(renderFormat(t, FormatText), "\n"), "\n")
+	if len(lines) != 2+len(fmtRows) {
+		t.Fatalf("got %d lines:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		got, err := ParseFormat(strings.ToUpper(string(f)))
+		if err != nil || got != f {
+			t.Fatalf("ParseFormat(%q) = %q, %v", f, got, err)
+		}
+	}
+	if got, err := ParseFormat("table"); err != nil || got != FormatText {
+		t.Fatalf("ParseFormat(table) = %q, %v", got, err)
+	}
+	if _, err := ParseFormat("yaml"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
@@ -0,0 +1,46 @@
+// Package render contains small, dependency-free helpers for rendering output in
+// a human-friendly way.
+//
+// This file holds LFWriter, which keeps carriage returns out of output meant
+// for Unix tools.
+package render
+
+import (
+	"bytes"
+	"io"
+)
+
+// LFWriter is an io.Writer that drops every carriage return ('\r') on its
+// way to the underlying writer, so "\r\n" line endings become "\n" and no
+// '\r' byte reaches the output, including any inside CSV field values.
+//
+// encoding/csv already ends records with "\n"; carriage returns in output
+// come from the data itself, e.g. a quoted multi-line note typed on Windows.
+type LFWriter struct {
+	w io.Writer
+}
+
+// NewLFWriter returns an LFWriter writing to w.
+func NewLFWriter(w io.Writer) *LFWriter {
+	return &LFWriter{w: w}
+}
+
+// Write writes p to the underlying writer without its carriage returns. On
+// success it reports len(p) bytes written, as io.Writer requires, even
+// though fewer bytes may have reached the underlying writer.
+func (lw *LFWriter) Write(p []byte) (int, error) {
+	if bytes.IndexByte(p, '\r') < 0 {
+		return lw.w.Write(p)
+	}
+
+	buf := make([]byte, 0, len(p))
+	for _, b := range p {
+		if b != '\r' {
+			buf = append(buf, b)
+		}
+	}
+	if _, err := lw.w.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,29 @@
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestLFWriter(t *testing.T) {
+	var buf bytes.Buffer
+	lw := NewLFWriter(&buf)
+
+	cw := csv.NewWriter(lw)
+	cw.UseCRLF = true
+	if err := cw.WriteAll([][]string{{"id", "note"}, {"1", "line one\r\nline two"}}); err != nil {
+		t.Fatalf("WriteAll: %v", err)
+	}
+
+	if bytes.IndexByte(buf.Bytes(), '\r') >= 0 {
+		t.Fatalf("output contains \\r: %q", buf.String())
+	}
+	if want := "id,note\n1,\"line one\nline two\"\n"; buf.String() != want {
+		t.Fatalf("output = %q, want %q", buf.String(), want)
+	}
+
+	if n, err := lw.Write([]byte("a\r\r\n")); n != 4 || err != nil {
+		t.Fatalf("Write = %d, %v; want 4, nil", n, err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package render contains small, dependency-free helpers for rendering output in
+// a human-friendly way.
+//
+// This file implements PrintTableStream, which renders rows as they arrive on
+// a channel, e.g. from a large file or a database cursor, instead of from a
+// slice built up front.
+package render
+
+import "io"
+
+// DefaultStreamSampleRows is the number of rows PrintTableStream buffers to
+// size the columns when TableOptions.StreamSampleRows is zero.
+const DefaultStreamSampleRows = 100
+
+// PrintTableStream is PrintTable for rows received from a channel. The sender
+// must close rows when done.
+//
+// A fixed-width table has to know its column widths before the header line
+// is printed, so FormatText output first buffers up to opts.StreamSampleRows
+// rows and sizes the columns from those (and opts.FooterRow). The buffered
+// rows are then printed and later rows follow as they arrive, in constant
+// memory; a later cell wider than its column is clipped with an ellipsis,
+// as if MaxCellWidth were reached.
+//
+// Other formats collect every row and pass them to PrintTable: FormatBox
+// sizes its index column from the row count, and FormatJSON writes a single
+// array.
+//
+// rows is always read until it is closed, even after a write error, so the
+// sender is never left blocked. The first write or encoding error is
+// returned.
+func PrintTableStream(w io.Writer, headers []string, rows <-chan []string, opts TableOptions) error {
+	if opts.Format != "" && opts.Format != FormatText {
+		var all [][]string
+		for row := range rows {
+			all = append(all, row)
+		}
+		return PrintTable(w, headers, all, opts)
+	}
+
+	n := opts.StreamSampleRows
+	if n <= 0 {
+		n = DefaultStreamSampleRows
+	}
+	sample := make([][]string, 0, n)
+	for len(sample) < n {
+		row, ok := <-rows
+		if !ok {
+			return printText(w, headers, sample, nil, opts)
+		}
+		sample = append(sample, row)
+	}
+	return printText(w, headers, sample, rows, opts)
+}
@@ -0,0 +1,69 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// sendRows returns a channel that yields rows and is then closed.
+func sendRows(rows [][]string) <-chan []string {
+	ch := make(chan []string)
+	go func() {
+		defer close(ch)
+		for _, row := range rows {
+			ch <- row
+		}
+	}()
+	return ch
+}
+
+func TestPrintTableStream_MatchesPrintTable(t *testing.T) {
+	headers := []string{"name", "zip"}
+	rows := [][]string{{"Ann", "12207"}, {"Bo"}, {"Cy", "9"}}
+
+	for _, format := range []Format{FormatText, FormatCSV, FormatJSON} {
+		opts := TableOptions{Format: format, ShowRowIndex: true, StreamSampleRows: 2}
+
+		var want, got bytes.Buffer
+		if err := PrintTable(&want, headers, rows, opts); err != nil {
+			t.Fatalf("%s: PrintTable: %v", format, err)
+		}
+		if err := PrintTableStream(&got, headers, sendRows(rows), opts); err != nil {
+			t.Fatalf("%s: PrintTableStream: %v", format, err)
+		}
+		if got.String() != want.String() {
+			t.Fatalf("%s: output =\n%q\nwant\n%q", format, got.String(), want.String())
+		}
+	}
+}
+
+func TestPrintTableStream_ClipsRowsAfterSample(t *testing.T) {
+	rows := [][]string{{"Ann", "1"}, {"Bartholomew", "22"}}
+
+	var buf bytes.Buffer
+	if err := PrintTableStream(&buf, []string{"name", "n"}, sendRows(rows), TableOptions{StreamSampleRows: 1}); err != nil {
+		t.Fatalf("PrintTableStream: %v", err)
+	}
+	want := "" +
+		"name  n\n" +
+		"----  -\n" +
+		"Ann   1\n" +
+		"Bar…  …\n"
+	if buf.String() != want {
+		t.Fatalf("output =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestPrintTableStream_DrainsAfterWriteError(t *testing.T) {
+	rows := make([][]string, 10)
+	for i := range rows {
+		rows[i] = []string{"x"}
+	}
+
+	// sendRows would block forever if PrintTableStream stopped reading.
+	err := PrintTableStream(&failWriter{n: 1}, []string{"a"}, sendRows(rows), TableOptions{StreamSampleRows: 2})
+	if !errors.Is(err, errWriteFailed) {
+		t.Fatalf("err = %v, want write error", err)
+	}
+}
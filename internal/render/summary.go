@@ -0,0 +1,58 @@
+// Package render contains small, dependency-free helpers for rendering output in
+// a human-friendly way.
+//
+// This file computes the optional summary footer shown under table previews.
+package render
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ComputeSummaryRow returns one summary cell per header for the given rows.
+//
+// For each column:
+//   - If every non-empty cell parses as a number, the cell is "mean=<value>"
+//     (two decimal places).
+//   - Otherwise it is "<non-empty>/<rows>", the count of non-empty cells out
+//     of the rows supplied.
+//
+// Whitespace-only cells count as empty. The summary describes only the rows
+// passed in (e.g. the rows shown by head), not the whole file.
+func ComputeSummaryRow(headers []string, rows [][]string) []string {
+	summary := make([]string, len(headers))
+
+	for ci := range headers {
+		nonEmpty := 0
+		numeric := true
+		sum := 0.0
+
+		for _, row := range rows {
+			if ci >= len(row) {
+				continue
+			}
+			v := strings.TrimSpace(row[ci])
+			if v == "" {
+				continue
+			}
+			nonEmpty++
+			if !numeric {
+				continue
+			}
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				numeric = false
+				continue
+			}
+			sum += f
+		}
+
+		if numeric && nonEmpty > 0 {
+			summary[ci] = "mean=" + strconv.FormatFloat(sum/float64(nonEmpty), 'f', 2, 64)
+		} else {
+			summary[ci] = strconv.Itoa(nonEmpty) + "/" + strconv.Itoa(len(rows))
+		}
+	}
+
+	return summary
+}
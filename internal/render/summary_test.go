@@ -0,0 +1,21 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeSummaryRow(t *testing.T) {
+	headers := []string{"name", "score", "note"}
+	rows := [][]string{
+		{"Ann", "10", ""},
+		{"Bob", "20", " "},
+		{"", "", "x"},
+	}
+
+	got := ComputeSummaryRow(headers, rows)
+	want := []string{"2/3", "mean=15.00", "1/3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -9,12 +9,14 @@
 //   - optionally prepends a row index column
 //
 // The output is designed for quick inspection and copy/paste, not for perfect
-// alignment in every terminal/font scenario.
+// alignment in every terminal/font scenario. Other output formats (JSON, CSV,
+// Markdown, ...) are selected through TableOptions.Format; see format.go.
 package render
 
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 )
@@ -22,53 +24,131 @@ import (
 // TableOptions controls how tables are rendered.
 //
 // MaxCellWidth limits the number of runes printed per cell. Values longer than
-// this limit are clipped and suffixed with an ellipsis (…).
+// this limit are clipped and suffixed with an ellipsis (…). When it is zero
+// and the output is a terminal, the terminal width is shared among the
+// columns (at least 8 runes each); otherwise 32 is used so redirected output
+// is reproducible.
+//
+// TerminalWidth, when positive, is used instead of the detected terminal
+// width, whether or not the output is a terminal.
 //
 // ShowRowIndex adds a leading "#" column with a zero-based row index. This is
 // useful when discussing records with coworkers or comparing against spreadsheet
 // row numbers during troubleshooting.
+//
+// RowIndexStart is the index shown for the first row, so rows read from the
+// middle of a file (e.g. "head --offset") keep their position in it.
+//
+// FooterRow, when non-nil, is printed below the data rows after a second
+// separator line (for example a summary from ComputeSummaryRow). It takes part
+// in column width computation like any other row.
+//
+// Format selects the output format (see Format). The zero value is FormatText.
+// MaxCellWidth, ColumnWidths, NumericColumns and FooterRow apply only to
+// FormatText and FormatBox; ShowRowIndex applies to the human-oriented formats (text, box,
+// Markdown, HTML, Confluence, vertical) and is ignored by data formats (JSON,
+// JSONL, CSV, TSV).
+//
+// ColumnWidths pins the width of the named columns (matched
+// case-insensitively) instead of sizing them to their content, still bounded
+// by MaxCellWidth. Other columns are sized as usual.
+//
+// NumericColumns lists columns (matched case-insensitively) to right-align,
+// which makes amounts and counts easier to compare. The renderer does not
+// inspect values itself; callers decide which columns are numeric.
+//
+// RecordSeparator is the line printed between records by FormatVertical;
+// the default "" gives a blank line.
+//
+// ColorEnabled styles FormatText output with ANSI escape codes: a bold header
+// row, cyan separator lines, and dimmed empty cells. PrintTable does not check
+// whether w is a terminal; callers decide, typically with IsTerminal.
+//
+// StreamSampleRows is how many rows PrintTableStream buffers to size the
+// columns before printing anything; zero means DefaultStreamSampleRows.
+// PrintTable ignores it.
 type TableOptions struct {
-	MaxCellWidth int
-	ShowRowIndex bool
+	MaxCellWidth     int
+	TerminalWidth    int
+	ColumnWidths     map[string]int
+	NumericColumns   map[string]bool
+	ShowRowIndex     bool
+	RowIndexStart    int
+	FooterRow        []string
+	Format           Format
+	RecordSeparator  string
+	ColorEnabled     bool
+	StreamSampleRows int
+}
+
+// PrintTable renders headers and rows in the format selected by opts.Format.
+//
+// This is the single entry point for row output; callers pick a format rather
+// than a function. Rows shorter than headers are padded with empty cells in
+// every format. The first write or encoding error is returned.
+func PrintTable(w io.Writer, headers []string, rows [][]string, opts TableOptions) error {
+	switch opts.Format {
+	case "", FormatText:
+		return printText(w, headers, rows, nil, opts)
+	case FormatBox:
+		return printBox(w, headers, rows, opts)
+	case FormatJSON:
+		return PrintJSON(w, headers, rows)
+	case FormatJSONL:
+		return printJSONL(w, headers, rows)
+	case FormatCSV:
+		return printDelimited(w, headers, rows, ',')
+	case FormatTSV:
+		return PrintTSV(w, headers, rows)
+	case FormatMarkdown:
+		return printMarkdown(w, headers, rows, opts)
+	case FormatHTML:
+		return printHTML(w, headers, rows, opts)
+	case FormatConfluence:
+		return PrintConfluenceTable(w, headers, rows, opts)
+	case FormatVertical:
+		return PrintVertical(w, headers, rows, opts)
+	default:
+		return fmt.Errorf("unknown table format %q", opts.Format)
+	}
 }
 
-// PrintTable prints headers and rows as a readable fixed-width table.
+// printText prints headers and rows as a readable fixed-width table.
 //
 // The renderer is intentionally small and deterministic:
 //   - No external dependencies
-//   - No color / ANSI formatting
+//   - No color / ANSI formatting unless opts.ColorEnabled is set
 //   - No multiline cells
 //
 // Column widths are computed from the supplied headers and rows, bounded by
 // opts.MaxCellWidth. If a given row is shorter than the header count, missing
 // cells are treated as empty strings.
 //
+// When rest is non-nil, the rows received from it are printed after rows with
+// the same widths (see PrintTableStream). rest is read until it is closed,
+// even after a write error, so its sender is never left blocked.
+//
+// The first write error (for example a closed pipe in "df head big.csv | head
+// -1") stops further output and is returned.
+//
 // Note: This renderer counts width in runes (not bytes), which works well for
 // Unicode text but does not account for terminal display width nuances such as
 // combining characters or East Asian wide glyphs. For df's current use cases,
 // rune width is a practical and stable approximation.
-func PrintTable(w io.Writer, headers []string, rows [][]string, opts TableOptions) {
-	// Default width cap if not specified or invalid.
-	if opts.MaxCellWidth <= 0 {
-		opts.MaxCellWidth = 32
-	}
+func printText(w io.Writer, headers []string, rows [][]string, rest <-chan []string, opts TableOptions) error {
+	ew := &errWriter{w: w}
 
-	// Determine per-column widths (bounded by MaxCellWidth). We consider:
-	//   1) header text
-	//   2) each cell in the provided rows
-	widths := make([]int, len(headers))
-	for i, h := range headers {
-		widths[i] = min(opts.MaxCellWidth, runeLen(h))
-	}
-	for _, row := range rows {
-		for i := range headers {
-			cell := ""
-			if i < len(row) {
-				cell = row[i]
-			}
-			widths[i] = max(widths[i], min(opts.MaxCellWidth, runeLen(cell)))
+	// Default width cap if not specified or invalid. Columns are separated by
+	// two spaces and the index column takes 7 runes.
+	if opts.MaxCellWidth <= 0 {
+		overhead := 2 * (len(headers) - 1)
+		if opts.ShowRowIndex {
+			overhead += 7
 		}
+		opts.MaxCellWidth = autoCellWidth(w, opts, len(headers), overhead)
 	}
+	widths := columnWidths(headers, rows, opts)
+	right := rightAligned(headers, opts)
 
 	// Row index width if enabled.
 	// This is a fixed width to keep output stable and avoid recomputing based on
@@ -78,47 +158,259 @@ func PrintTable(w io.Writer, headers []string, rows [][]string, opts TableOption
 		// Enough for up to 99999 displayed rows without breaking alignment.
 		// (The tool currently prints small previews like head/tail.)
 		idxWidth = 5
-		fmt.Fprintf(w, "%-*s  ", idxWidth, "#")
+		ew.printf("%-*s  ", idxWidth, "#")
 	}
 
-	// Header row.
-	for i, h := range headers {
-		fmt.Fprintf(w, "%-*s", widths[i], clip(h, opts.MaxCellWidth))
-		if i < len(headers)-1 {
-			fmt.Fprint(w, "  ")
+	// style applies c when color is enabled. Padding is applied before
+	// styling so escape codes never count toward column widths.
+	style := func(c AnsiColor, s string) string {
+		if !opts.ColorEnabled {
+			return s
 		}
+		return c.Wrap(s)
 	}
-	fmt.Fprintln(w)
 
-	// Separator row.
-	if opts.ShowRowIndex {
-		fmt.Fprintf(w, "%s  ", strings.Repeat("-", idxWidth))
+	// printCells writes one padded line of cells (header, data, or footer).
+	printCells := func(cells []string, bold bool) {
+		for i := range headers {
+			cell := cellAt(cells, i)
+			padded := pad(clip(cell, widths[i]), widths[i], right[i])
+			switch {
+			case bold:
+				padded = style(AnsiBold, padded)
+			case cell == "":
+				padded = style(AnsiDim, padded)
+			}
+			ew.print(padded)
+			if i < len(headers)-1 {
+				ew.print("  ")
+			}
+		}
+		ew.print("\n")
 	}
-	for i := range headers {
-		fmt.Fprint(w, strings.Repeat("-", widths[i]))
-		if i < len(headers)-1 {
-			fmt.Fprint(w, "  ")
+
+	// Header row.
+	printCells(headers, true)
+
+	// Separator row (also reused above the footer).
+	printSeparator := func() {
+		var b strings.Builder
+		if opts.ShowRowIndex {
+			b.WriteString(strings.Repeat("-", idxWidth) + "  ")
 		}
+		for i := range headers {
+			b.WriteString(strings.Repeat("-", widths[i]))
+			if i < len(headers)-1 {
+				b.WriteString("  ")
+			}
+		}
+		ew.print(style(AnsiCyan, b.String()) + "\n")
 	}
-	fmt.Fprintln(w)
+	printSeparator()
 
 	// Data rows.
 	for ri, row := range rows {
+		if ew.err != nil {
+			break
+		}
 		if opts.ShowRowIndex {
-			fmt.Fprintf(w, "%-*d  ", idxWidth, ri)
+			ew.printf("%-*d  ", idxWidth, opts.RowIndexStart+ri)
 		}
-		for ci := range headers {
-			cell := ""
-			if ci < len(row) {
-				cell = row[ci]
+		printCells(row, false)
+	}
+	if rest != nil {
+		ri := len(rows)
+		for row := range rest {
+			if ew.err != nil {
+				continue
 			}
-			fmt.Fprintf(w, "%-*s", widths[ci], clip(cell, opts.MaxCellWidth))
-			if ci < len(headers)-1 {
-				fmt.Fprint(w, "  ")
+			if opts.ShowRowIndex {
+				ew.printf("%-*d  ", idxWidth, opts.RowIndexStart+ri)
 			}
+			printCells(row, false)
+			ri++
+		}
+	}
+
+	// Footer row (no index value; it is not a data row).
+	if opts.FooterRow != nil {
+		printSeparator()
+		if opts.ShowRowIndex {
+			ew.printf("%-*s  ", idxWidth, "")
 		}
-		fmt.Fprintln(w)
+		printCells(opts.FooterRow, false)
 	}
+
+	return ew.err
+}
+
+// columnWidths returns the display width of each column: the widest of the
+// header, the rows, and opts.FooterRow, or the opts.ColumnWidths override,
+// bounded by opts.MaxCellWidth. Cells wider than their column are clipped.
+func columnWidths(headers []string, rows [][]string, opts TableOptions) []int {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = min(opts.MaxCellWidth, runeLen(h))
+	}
+	measured := rows
+	if opts.FooterRow != nil {
+		measured = append(rows[:len(rows):len(rows)], opts.FooterRow)
+	}
+	for _, row := range measured {
+		for i := range headers {
+			widths[i] = max(widths[i], min(opts.MaxCellWidth, runeLen(cellAt(row, i))))
+		}
+	}
+
+	for name, width := range opts.ColumnWidths {
+		for i, h := range headers {
+			if strings.EqualFold(h, name) && width > 0 {
+				widths[i] = min(width, opts.MaxCellWidth)
+			}
+		}
+	}
+	return widths
+}
+
+// rightAligned reports, per column, whether opts.NumericColumns asks for it
+// to be right-aligned.
+func rightAligned(headers []string, opts TableOptions) []bool {
+	right := make([]bool, len(headers))
+	for name, numeric := range opts.NumericColumns {
+		for i, h := range headers {
+			if numeric && strings.EqualFold(h, name) {
+				right[i] = true
+			}
+		}
+	}
+	return right
+}
+
+// pad pads s with spaces to width runes, on the left when right is set.
+func pad(s string, width int, right bool) string {
+	if right {
+		return fmt.Sprintf("%*s", width, s)
+	}
+	return fmt.Sprintf("%-*s", width, s)
+}
+
+// printBox prints the same table as printText framed with Unicode
+// box-drawing characters: the header is boxed in, data rows are separated by
+// horizontal lines, and the footer (if any) gets its own box section.
+// MaxCellWidth, ShowRowIndex and FooterRow behave as for printText.
+//
+// Box-drawing characters are single-width, so rune-based widths stay aligned.
+func printBox(w io.Writer, headers []string, rows [][]string, opts TableOptions) error {
+	ew := &errWriter{w: w}
+
+	// Size the index column to the largest index shown.
+	idxWidth := 0
+	if opts.ShowRowIndex {
+		idxWidth = len(strconv.Itoa(opts.RowIndexStart + max(len(rows)-1, 0)))
+	}
+
+	if opts.MaxCellWidth <= 0 {
+		// Every column is framed as "│ value " plus the closing "│".
+		overhead := 3*len(headers) + 1
+		if opts.ShowRowIndex {
+			overhead += idxWidth + 3
+		}
+		opts.MaxCellWidth = autoCellWidth(w, opts, len(headers), overhead)
+	}
+	widths := columnWidths(headers, rows, opts)
+	right := rightAligned(headers, opts)
+	if opts.ShowRowIndex {
+		widths = append([]int{idxWidth}, widths...)
+	}
+
+	// line draws a horizontal rule with the given corner and junction runes.
+	line := func(left, mid, right string) {
+		ew.print(left)
+		for i, width := range widths {
+			if i > 0 {
+				ew.print(mid)
+			}
+			ew.print(strings.Repeat("─", width+2))
+		}
+		ew.print(right + "\n")
+	}
+
+	// cells draws one row of values; index is "" for the header and footer.
+	cells := func(index string, values []string) {
+		if opts.ShowRowIndex {
+			ew.printf("│ %-*s ", widths[0], index)
+		}
+		offset := len(widths) - len(headers)
+		for i := range headers {
+			width := widths[offset+i]
+			ew.print("│ " + pad(clip(cellAt(values, i), width), width, right[i]) + " ")
+		}
+		ew.print("│\n")
+	}
+
+	indexHeader := ""
+	if opts.ShowRowIndex {
+		indexHeader = "#"
+	}
+
+	line("┌", "┬", "┐")
+	cells(indexHeader, headers)
+	line("├", "┼", "┤")
+	for ri, row := range rows {
+		if ew.err != nil {
+			break
+		}
+		if ri > 0 {
+			line("├", "┼", "┤")
+		}
+		cells(strconv.Itoa(opts.RowIndexStart+ri), row)
+	}
+	if opts.FooterRow != nil {
+		if len(rows) > 0 {
+			line("├", "┼", "┤")
+		}
+		cells("", opts.FooterRow)
+	}
+	line("└", "┴", "┘")
+
+	return ew.err
+}
+
+// Cell width defaults for printText and printBox.
+const (
+	defaultCellWidth = 32 // when the output is not a terminal
+	minAutoCellWidth = 8  // floor when sharing out the terminal width
+)
+
+// terminalWidth reports the width of the terminal open on fd. It is a
+// variable so tests can fake a terminal.
+var terminalWidth = ttyWidth
+
+// autoCellWidth picks a per-cell width cap when none is configured: the
+// terminal width (or opts.TerminalWidth) left after overhead runes of
+// borders, gaps and row index, divided evenly among cols columns. Output that
+// is not a terminal gets defaultCellWidth.
+func autoCellWidth(w io.Writer, opts TableOptions, cols, overhead int) int {
+	width := opts.TerminalWidth
+	if width <= 0 {
+		f, ok := w.(interface{ Fd() uintptr })
+		if !ok {
+			return defaultCellWidth
+		}
+		if width, ok = terminalWidth(f.Fd()); !ok {
+			return defaultCellWidth
+		}
+	}
+
+	return max((width-overhead)/max(cols, 1), minAutoCellWidth)
+}
+
+// cellAt returns row[i], or "" when the row is too short.
+func cellAt(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
 }
 
 // clip truncates s to at most max runes. If truncation occurs, the result ends
@@ -181,3 +473,26 @@ func max(a, b int) int {
 	}
 	return b
 }
+
+// errWriter remembers the first write error and turns later writes into no-ops,
+// so renderers can emit output linearly and check for failure once at the end.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+// print writes s unless a previous write has already failed.
+func (ew *errWriter) print(s string) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = io.WriteString(ew.w, s)
+}
+
+// printf formats and writes unless a previous write has already failed.
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
@@ -0,0 +1,248 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// failWriter succeeds for the first n writes and fails every write after.
+type failWriter struct {
+	n      int
+	writes int
+}
+
+var errWriteFailed = errors.New("write failed")
+
+func (f *failWriter) Write(p []byte) (int, error) {
+	f.writes++
+	if f.writes > f.n {
+		return 0, errWriteFailed
+	}
+	return len(p), nil
+}
+
+func TestPrintTable_TextLayout(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintTable(&buf, []string{"name", "zip"}, [][]string{{"Ann", "12207"}, {"Bo"}}, TableOptions{
+		ShowRowIndex: true,
+		FooterRow:    []string{"2", "1"},
+	})
+	if err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	want := "" +
+		"#      name  zip  \n" +
+		"-----  ----  -----\n" +
+		"0      Ann   12207\n" +
+		"1      Bo         \n" +
+		"-----  ----  -----\n" +
+		"       2     1    \n"
+	if buf.String() != want {
+		t.Fatalf("output =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestPrintTable_TextReturnsWriteError(t *testing.T) {
+	for _, n := range []int{0, 1, 5} {
+		fw := &failWriter{n: n}
+		err := PrintTable(fw, []string{"name", "zip"}, [][]string{{"Ann", "12207"}, {"Bob", "12180"}}, TableOptions{ShowRowIndex: true})
+		if !errors.Is(err, errWriteFailed) {
+			t.Fatalf("n=%d: err = %v, want write error", n, err)
+		}
+		// Output stops at the first failure.
+		if fw.writes != n+1 {
+			t.Fatalf("n=%d: %d writes attempted, want %d", n, fw.writes, n+1)
+		}
+	}
+}
+
+// fakeTTY is a writer with a file descriptor, standing in for a terminal.
+type fakeTTY struct {
+	bytes.Buffer
+}
+
+func (*fakeTTY) Fd() uintptr { return 42 }
+
+// withTerminalWidth fakes the terminal width lookup for the test.
+func withTerminalWidth(t *testing.T, width int, ok bool) {
+	t.Helper()
+	orig := terminalWidth
+	terminalWidth = func(uintptr) (int, bool) { return width, ok }
+	t.Cleanup(func() { terminalWidth = orig })
+}
+
+func TestAutoCellWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		w        io.Writer
+		width    int
+		ok       bool
+		opts     TableOptions
+		overhead int
+		cols     int
+		want     int
+	}{
+		{name: "not a terminal", w: &bytes.Buffer{}, width: 200, ok: true, cols: 2, want: 32},
+		{name: "fd but not a tty", w: &fakeTTY{}, width: 0, ok: false, cols: 2, want: 32},
+		{name: "shared width", w: &fakeTTY{}, width: 100, ok: true, cols: 2, want: 50},
+		{name: "overhead", w: &fakeTTY{}, width: 100, ok: true, overhead: 10, cols: 2, want: 45},
+		{name: "minimum", w: &fakeTTY{}, width: 40, ok: true, cols: 10, want: 8},
+		{name: "override", w: &bytes.Buffer{}, opts: TableOptions{TerminalWidth: 62}, overhead: 5, cols: 3, want: 19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTerminalWidth(t, tt.width, tt.ok)
+			if got := autoCellWidth(tt.w, tt.opts, tt.cols, tt.overhead); got != tt.want {
+				t.Fatalf("autoCellWidth = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintTable_UsesTerminalWidth(t *testing.T) {
+	withTerminalWidth(t, 10, true)
+
+	var tty fakeTTY
+	long := strings.Repeat("x", 40)
+	if err := PrintTable(&tty, []string{"note"}, [][]string{{long}}, TableOptions{}); err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	if want := strings.Repeat("x", 9) + "…"; !strings.Contains(tty.String(), want+"\n") {
+		t.Fatalf("expected cell clipped to 10 runes, got\n%s", tty.String())
+	}
+}
+
+func TestPrintTable_Box(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintTable(&buf, []string{"name", "zip"}, [][]string{{"Ann", "12207"}, {"Bo"}}, TableOptions{
+		Format:       FormatBox,
+		ShowRowIndex: true,
+	})
+	if err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	want := "" +
+		"┌───┬──────┬───────┐\n" +
+		"│ # │ name │ zip   │\n" +
+		"├───┼──────┼───────┤\n" +
+		"│ 0 │ Ann  │ 12207 │\n" +
+		"├───┼──────┼───────┤\n" +
+		"│ 1 │ Bo   │       │\n" +
+		"└───┴──────┴───────┘\n"
+	if buf.String() != want {
+		t.Fatalf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintTable_RowIndexStart(t *testing.T) {
+	rows := [][]string{{"Ann"}, {"Bo"}}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{format: FormatText, want: "" +
+			"#      name\n" +
+			"-----  ----\n" +
+			"9      Ann \n" +
+			"10     Bo  \n"},
+		{format: FormatBox, want: "" +
+			"┌────┬──────┐\n" +
+			"│ #  │ name │\n" +
+			"├────┼──────┤\n" +
+			"│ 9  │ Ann  │\n" +
+			"├────┼──────┤\n" +
+			"│ 10 │ Bo   │\n" +
+			"└────┴──────┘\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			err := PrintTable(&buf, []string{"name"}, rows, TableOptions{
+				Format:        tt.format,
+				ShowRowIndex:  true,
+				RowIndexStart: 9,
+			})
+			if err != nil {
+				t.Fatalf("PrintTable: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Fatalf("output =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintTable_BoxFooterNoIndex(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintTable(&buf, []string{"n"}, [][]string{{"1"}}, TableOptions{
+		Format:    FormatBox,
+		FooterRow: []string{"sum"},
+	})
+	if err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	want := "" +
+		"┌─────┐\n" +
+		"│ n   │\n" +
+		"├─────┤\n" +
+		"│ 1   │\n" +
+		"├─────┤\n" +
+		"│ sum │\n" +
+		"└─────┘\n"
+	if buf.String() != want {
+		t.Fatalf("output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestPrintTable_ColumnWidths(t *testing.T) {
+	var buf bytes.Buffer
+	err := PrintTable(&buf, []string{"email", "zip"}, [][]string{{"ann@example.com", "12207"}}, TableOptions{
+		MaxCellWidth: 20,
+		ColumnWidths: map[string]int{"EMAIL": 8, "zip": 30},
+	})
+	if err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	// email is pinned to 8 (clipping its value); zip's 30 is capped at 20.
+	want := "" +
+		"email     zip                 \n" +
+		"--------  --------------------\n" +
+		"ann@exa…  12207               \n"
+	if buf.String() != want {
+		t.Fatalf("output =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestPrintTable_NumericColumns(t *testing.T) {
+	headers := []string{"name", "amount"}
+	rows := [][]string{{"Ann", "5.25"}, {"Bob", "1200"}}
+	opts := TableOptions{NumericColumns: map[string]bool{"Amount": true}}
+
+	var buf bytes.Buffer
+	if err := PrintTable(&buf, headers, rows, opts); err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	want := "" +
+		"name  amount\n" +
+		"----  ------\n" +
+		"Ann     5.25\n" +
+		"Bob     1200\n"
+	if buf.String() != want {
+		t.Fatalf("text output =\n%q\nwant\n%q", buf.String(), want)
+	}
+
+	buf.Reset()
+	opts.Format = FormatBox
+	if err := PrintTable(&buf, headers, rows, opts); err != nil {
+		t.Fatalf("PrintTable: %v", err)
+	}
+	if !strings.Contains(buf.String(), "│ Ann  │   5.25 │\n") {
+		t.Fatalf("box output not right-aligned:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package render
+
+// ttyWidth is not implemented on this platform, so tables keep the fixed
+// default width.
+func ttyWidth(fd uintptr) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,45 @@
+// Package render contains small, dependency-free helpers for rendering output in
+// a human-friendly way.
+//
+// This file implements the vertical (record) layout, which suits files too
+// wide for a horizontal table.
+package render
+
+import "io"
+
+// PrintVertical renders each row as a block of "key: value" lines, one line
+// per column, similar to MySQL's \G output. Keys are right-padded to the
+// longest header so values line up.
+//
+// Values are printed in full: MaxCellWidth is ignored because the point of
+// this layout is to show long values that a table would clip. Records are
+// separated by a line containing opts.RecordSeparator, which defaults to a
+// blank line. With opts.ShowRowIndex each record is introduced by a
+// "*** row N ***" line using the zero-based row index.
+//
+// The first write error is returned and stops further output.
+func PrintVertical(w io.Writer, headers []string, rows [][]string, opts TableOptions) error {
+	ew := &errWriter{w: w}
+
+	keyWidth := 0
+	for _, h := range headers {
+		keyWidth = max(keyWidth, runeLen(h))
+	}
+
+	for ri, row := range rows {
+		if ri > 0 {
+			ew.print(opts.RecordSeparator + "\n")
+		}
+		if opts.ShowRowIndex {
+			ew.printf("*** row %d ***\n", opts.RowIndexStart+ri)
+		}
+		for i, h := range headers {
+			ew.printf("%-*s: %s\n", keyWidth, h, cellAt(row, i))
+		}
+		if ew.err != nil {
+			break
+		}
+	}
+
+	return ew.err
+}
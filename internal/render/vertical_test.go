@@ -0,0 +1,58 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintVertical(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	headers := []string{"name", "email_address"}
+	rows := [][]string{{"Ann", long}, {"Bob"}}
+
+	tests := []struct {
+		name string
+		opts TableOptions
+		want string
+	}{
+		{
+			name: "default",
+			want: "name         : Ann\n" +
+				"email_address: " + long + "\n" +
+				"\n" +
+				"name         : Bob\n" +
+				"email_address: \n",
+		},
+		{
+			name: "separator and index",
+			opts: TableOptions{RecordSeparator: "---", ShowRowIndex: true, MaxCellWidth: 5},
+			want: "*** row 0 ***\n" +
+				"name         : Ann\n" +
+				"email_address: " + long + "\n" +
+				"---\n" +
+				"*** row 1 ***\n" +
+				"name         : Bob\n" +
+				"email_address: \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintVertical(&buf, headers, rows, tt.opts); err != nil {
+				t.Fatalf("PrintVertical: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Fatalf("output =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintTable_Vertical(t *testing.T) {
+	got := renderFormat(t, FormatVertical)
+	if !strings.HasPrefix(got, "name: Ann\nnote: ") {
+		t.Fatalf("unexpected vertical output:\n%s", got)
+	}
+}
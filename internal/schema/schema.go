@@ -0,0 +1,241 @@
+// Package schema validates CSV rows against a declared schema: which columns
+// must exist, which must never be NULL, and which must match a pattern, such
+// as an email address format.
+//
+// A schema is usually kept as JSON next to the job that produces the file:
+//
+//	{
+//	  "columns": [
+//	    {"name": "email", "required": true, "not_null": true,
+//	     "regex": "[^@\\s]+@[^@\\s]+\\.[^@\\s]+"},
+//	    {"name": "phone", "regex": "\\+?[0-9 ()-]{7,}"}
+//	  ]
+//	}
+//
+// What counts as NULL is decided by a nulls.Policy, so validate agrees with
+// nullify and stats.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+// Rule names used in ValidationError.Rule and RuleCount.Rule.
+const (
+	RuleRequired = "required" // the column is missing from the header
+	RuleNotNull  = "not_null" // the cell is NULL under the policy
+	RuleRegex    = "regex"    // the cell does not match the column's Regex
+)
+
+// MaxErrors caps ValidationReport.Errors so a badly broken file cannot
+// exhaust memory. Violations and Counts still cover every row.
+const MaxErrors = 1000
+
+// ColumnSchema declares the rules for one column.
+type ColumnSchema struct {
+	// Name is the header name, matched case-insensitively.
+	Name string `json:"name"`
+
+	// Required reports a violation when the header has no such column.
+	// Rules for a missing optional column are skipped.
+	Required bool `json:"required,omitempty"`
+
+	// NotNull reports every NULL cell.
+	NotNull bool `json:"not_null,omitempty"`
+
+	// Regex, when set, must match the whole of every non-NULL cell (it is
+	// anchored at both ends). Go RE2 syntax.
+	Regex string `json:"regex,omitempty"`
+}
+
+// Schema is a set of column rules. Columns the schema does not mention are
+// not checked.
+type Schema struct {
+	Columns []ColumnSchema `json:"columns"`
+}
+
+// LoadFile reads a Schema from the JSON file at path. Unknown keys and
+// invalid regular expressions are rejected so a mistake in the schema fails
+// loudly instead of passing every row.
+func LoadFile(path string) (Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Schema{}, fmt.Errorf("read schema file: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var s Schema
+	if err := dec.Decode(&s); err != nil {
+		return Schema{}, fmt.Errorf("parse schema file %s: %w", path, err)
+	}
+	if _, err := s.compile(); err != nil {
+		return Schema{}, fmt.Errorf("schema file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// compile returns the anchored regular expression for each column, nil
+// where the column has none.
+func (s Schema) compile() ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, len(s.Columns))
+	for i, c := range s.Columns {
+		if c.Name == "" {
+			return nil, fmt.Errorf("column %d has no name", i+1)
+		}
+		if c.Regex == "" {
+			continue
+		}
+		re, err := regexp.Compile(`^(?:` + c.Regex + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", c.Name, err)
+		}
+		res[i] = re
+	}
+	return res, nil
+}
+
+// ValidationError is one rule violation. Row is the 1-based data row number
+// (the header is not counted), or 0 for a missing required column.
+type ValidationError struct {
+	Row   int
+	Col   string
+	Rule  string
+	Value string
+}
+
+// RuleCount totals the violations of one rule in one column. FirstRow and
+// Example are the row number and value of the first one.
+type RuleCount struct {
+	Col      string
+	Rule     string
+	Count    int
+	FirstRow int
+	Example  string
+}
+
+// ValidationReport is the outcome of ValidateFile.
+//
+//   - Rows counts data rows read.
+//   - Violations counts every violation found.
+//   - Counts totals them per column and rule, in schema order.
+//   - Errors lists the first MaxErrors violations in file order.
+type ValidationReport struct {
+	Rows       int
+	Violations int
+	Counts     []RuleCount
+	Errors     []ValidationError
+}
+
+// OK reports whether the file satisfied every rule.
+func (r ValidationReport) OK() bool {
+	return r.Violations == 0
+}
+
+// ValidateFile streams inputPath and checks every row against s, with NULL
+// cells decided by policy. A returned error means the file or schema could
+// not be processed; rule violations are reported in the ValidationReport.
+func ValidateFile(inputPath string, s Schema, policy nulls.Policy, opts csvio.Options) (ValidationReport, error) {
+	in, err := csvio.OpenInputWithOptions(inputPath, opts)
+	if err != nil {
+		return ValidationReport{}, fmt.Errorf("open input csv: %w", err)
+	}
+	defer in.Close()
+
+	return Validate(in, s, policy, opts)
+}
+
+// Validate is ValidateFile for an already-open input. r is raw input;
+// opts.Encoding is applied.
+func Validate(r io.Reader, s Schema, policy nulls.Policy, opts csvio.Options) (ValidationReport, error) {
+	res, err := s.compile()
+	if err != nil {
+		return ValidationReport{}, err
+	}
+
+	cr := csvio.NewReader(r, opts)
+	headers, err := cr.Headers()
+	if err != nil {
+		return ValidationReport{}, err
+	}
+
+	// counts holds one slot per column and rule, so the report keeps schema
+	// order without sorting.
+	rules := []string{RuleRequired, RuleNotNull, RuleRegex}
+	slot := make(map[string]int, len(rules))
+	counts := make([]RuleCount, len(s.Columns)*len(rules))
+	for i, c := range s.Columns {
+		for j, rule := range rules {
+			slot[rule] = j
+			counts[i*len(rules)+j] = RuleCount{Col: c.Name, Rule: rule}
+		}
+	}
+
+	rep := ValidationReport{}
+	report := func(col int, rule string, row int, value string) {
+		rc := &counts[col*len(rules)+slot[rule]]
+		if rc.Count == 0 {
+			rc.FirstRow, rc.Example = row, value
+		}
+		rc.Count++
+		rep.Violations++
+		if len(rep.Errors) < MaxErrors {
+			rep.Errors = append(rep.Errors, ValidationError{Row: row, Col: rc.Col, Rule: rc.Rule, Value: value})
+		}
+	}
+
+	// idx maps each schema column to its header position, or -1 if absent.
+	idx := make([]int, len(s.Columns))
+	for i, c := range s.Columns {
+		idx[i], err = csvio.ColumnIndex(headers, c.Name)
+		if err != nil {
+			idx[i] = -1
+			if c.Required {
+				report(i, RuleRequired, 0, "")
+			}
+		}
+	}
+
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rep, fmt.Errorf("read row: %w", err)
+		}
+		rep.Rows++
+
+		for i, c := range s.Columns {
+			if idx[i] < 0 {
+				continue
+			}
+			v := row[idx[i]]
+			if policy.IsNull(v) {
+				if c.NotNull {
+					report(i, RuleNotNull, rep.Rows, v)
+				}
+				continue
+			}
+			if res[i] != nil && !res[i].MatchString(v) {
+				report(i, RuleRegex, rep.Rows, v)
+			}
+		}
+	}
+
+	for _, rc := range counts {
+		if rc.Count > 0 {
+			rep.Counts = append(rep.Counts, rc)
+		}
+	}
+	return rep, nil
+}
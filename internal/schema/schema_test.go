@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bensabler/go-mail/internal/csvio"
+	"github.com/bensabler/go-mail/internal/nulls"
+)
+
+const emailRegex = `[^@\s]+@[^@\s]+\.[^@\s]+`
+
+func TestValidate(t *testing.T) {
+	const input = "Email,name\n" +
+		"ann@example.com,Ann\n" +
+		",Bob\n" +
+		"not-an-email,Cy\n" +
+		"dee@example.org x,Dee\n"
+
+	s := Schema{Columns: []ColumnSchema{
+		{Name: "email", Required: true, NotNull: true, Regex: emailRegex},
+		{Name: "phone", Required: true},
+		{Name: "zip", NotNull: true},
+	}}
+
+	rep, err := Validate(strings.NewReader(input), s, nulls.Policy{TreatBlanks: true}, csvio.Options{})
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	wantErrors := []ValidationError{
+		{Row: 0, Col: "phone", Rule: RuleRequired},
+		{Row: 2, Col: "email", Rule: RuleNotNull, Value: ""},
+		{Row: 3, Col: "email", Rule: RuleRegex, Value: "not-an-email"},
+		{Row: 4, Col: "email", Rule: RuleRegex, Value: "dee@example.org x"},
+	}
+	if !reflect.DeepEqual(rep.Errors, wantErrors) {
+		t.Fatalf("Errors = %+v, want %+v", rep.Errors, wantErrors)
+	}
+
+	wantCounts := []RuleCount{
+		{Col: "email", Rule: RuleNotNull, Count: 1, FirstRow: 2},
+		{Col: "email", Rule: RuleRegex, Count: 2, FirstRow: 3, Example: "not-an-email"},
+		{Col: "phone", Rule: RuleRequired, Count: 1},
+	}
+	if !reflect.DeepEqual(rep.Counts, wantCounts) {
+		t.Fatalf("Counts = %+v, want %+v", rep.Counts, wantCounts)
+	}
+	if rep.Rows != 4 || rep.Violations != 4 || rep.OK() {
+		t.Fatalf("report = %+v", rep)
+	}
+}
+
+func TestValidate_OK(t *testing.T) {
+	s := Schema{Columns: []ColumnSchema{{Name: "email", NotNull: true, Regex: emailRegex}}}
+
+	rep, err := Validate(strings.NewReader("email\na@b.co\n"), s, nulls.Policy{TreatBlanks: true}, csvio.Options{})
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if !rep.OK() || rep.Rows != 1 {
+		t.Fatalf("report = %+v", rep)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write fixture: %v", err)
+		}
+		return path
+	}
+
+	got, err := LoadFile(write("ok.json", `{"columns": [{"name": "email", "required": true, "not_null": true, "regex": "\\S+@\\S+"}]}`))
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	want := Schema{Columns: []ColumnSchema{{Name: "email", Required: true, NotNull: true, Regex: `\S+@\S+`}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoadFile = %+v, want %+v", got, want)
+	}
+
+	for name, content := range map[string]string{
+		"unknown.json": `{"columns": [{"name": "a", "nullable": false}]}`,
+		"regex.json":   `{"columns": [{"name": "a", "regex": "("}]}`,
+		"noname.json":  `{"columns": [{"required": true}]}`,
+	} {
+		if _, err := LoadFile(write(name, content)); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}